@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+)
+
+type (
+	Middleware struct {
+		token string
+	}
+
+	// response represents error of unauthorized request in format of
+	// Micropub endpoints.
+	response struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}
+)
+
+func NewMiddleware(token string) *Middleware {
+	return &Middleware{token: token}
+}
+
+// Handle wraps next handler by requiring access token of the owner in
+// Authorization header: as Bearer credentials for Micropub clients, or as
+// password of Basic credentials for browsers. Requests are refused if token
+// is not configured.
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.Authorized(r) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Add(common.HeaderWWWAuthenticate, `Bearer`)
+		w.Header().Add(common.HeaderWWWAuthenticate, `Basic realm="pub", charset="UTF-8"`)
+		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		_ = json.NewEncoder(w).Encode(response{
+			Error:            "unauthorized",
+			ErrorDescription: "valid access token is required",
+		})
+	})
+}
+
+// Authorized reports whether request is made with access token of the owner.
+func (m *Middleware) Authorized(r *http.Request) bool {
+	if m.token == "" {
+		return false
+	}
+
+	token := ""
+
+	if _, password, ok := r.BasicAuth(); ok {
+		token = password
+	} else if scheme, credentials, ok := strings.Cut(r.Header.Get(common.HeaderAuthorization), " "); ok &&
+		strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(credentials)
+	}
+
+	// NOTE(toby3d): constant time comparison does not leak matched prefix
+	// of token by response time.
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) == 1
+}
@@ -0,0 +1,56 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/auth"
+	"source.toby3d.me/toby3d/pub/internal/common"
+)
+
+func TestMiddleware_Handle(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		token  string
+		header string
+		expect int
+	}{
+		"bearer":           {token: "s3cr3t", header: "Bearer s3cr3t", expect: http.StatusOK},
+		"basic":            {token: "s3cr3t", header: "Basic b3duZXI6czNjcjN0", expect: http.StatusOK},
+		"wrong token":      {token: "s3cr3t", header: "Bearer s3cr3", expect: http.StatusUnauthorized},
+		"without header":   {token: "s3cr3t", header: "", expect: http.StatusUnauthorized},
+		"not configured":   {token: "", header: "Bearer ", expect: http.StatusUnauthorized},
+		"unknown scheme":   {token: "s3cr3t", header: "Token s3cr3t", expect: http.StatusUnauthorized},
+		"lowercase bearer": {token: "s3cr3t", header: "bearer s3cr3t", expect: http.StatusOK},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := auth.NewMiddleware(tc.token).Handle(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/api", nil)
+			if tc.header != "" {
+				req.Header.Set(common.HeaderAuthorization, tc.header)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tc.expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+
+			if tc.expect == http.StatusUnauthorized && len(resp.Header.Values(common.HeaderWWWAuthenticate)) == 0 {
+				t.Errorf("%s %s returns no %s header", req.Method, req.RequestURI, common.HeaderWWWAuthenticate)
+			}
+		})
+	}
+}
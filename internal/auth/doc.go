@@ -0,0 +1,3 @@
+// Package auth provides access control of private endpoints, which are
+// available only to the owner of site by the configured access token.
+package auth
@@ -0,0 +1,195 @@
+package citation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/mf2"
+)
+
+// NOTE(toby3d): dt-published values are not always RFC 3339 timestamps.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Targets returns unique in-reply-to, like-of and repost-of URLs of entry.
+func Targets(e domain.Entry) []*url.URL {
+	out := make([]*url.URL, 0)
+	seen := make(map[string]struct{})
+
+	for _, list := range [][]*url.URL{e.InReplyTo, e.LikeOf, e.RepostOf} {
+		for _, u := range list {
+			if u == nil {
+				continue
+			}
+
+			if _, ok := seen[u.String()]; ok {
+				continue
+			}
+
+			seen[u.String()] = struct{}{}
+			out = append(out, u)
+		}
+	}
+
+	return out
+}
+
+// Parse reads HTML document from r and returns citation of the first h-entry
+// in it, or citation with document title if there is none. Returned URL is
+// always base, content is truncated to excerpt characters.
+func Parse(r io.Reader, base *url.URL, excerpt int) (*domain.Citation, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse citation document: %w", err)
+	}
+
+	out := &domain.Citation{URL: base}
+
+	item := mf2.ParseNode(doc, base).Find("h-entry")
+	if item == nil {
+		out.Name = strings.Join(strings.Fields(title(doc)), " ")
+
+		return out, nil
+	}
+
+	out.Content = item.String("content")
+	if out.Content == "" {
+		out.Content = item.String("summary")
+	}
+
+	out.Content = truncate(strings.Join(strings.Fields(out.Content), " "), excerpt)
+
+	// NOTE(toby3d): name of notes is implied from their content, so it is
+	// just a noise.
+	out.Name = strings.Join(strings.Fields(item.String("name")), " ")
+	if out.Name != "" && strings.HasPrefix(strings.Join(strings.Fields(item.String("content")), " "), out.Name) {
+		out.Name = ""
+	}
+
+	out.Photo = resolve(base, item.String("photo"))
+
+	for _, layout := range timeLayouts {
+		if out.PublishedAt, err = time.Parse(layout, item.String("published")); err == nil {
+			break
+		}
+	}
+
+	if author := item.Item("author"); author != nil {
+		out.Author.Name = author.String("name")
+
+		out.Author.URL = resolve(base, author.String("url"))
+		out.Author.Photo = resolve(base, author.String("photo"))
+	} else if author := item.String("author"); author != "" {
+		if u, err := url.Parse(author); err == nil && u.IsAbs() {
+			out.Author.URL = resolve(base, author)
+		} else {
+			out.Author.Name = author
+		}
+	}
+
+	return out, nil
+}
+
+// NewHook creates an entry hook which fetches citations of created and
+// updated entries with changed targets in background.
+func NewHook(ucase UseCase, logger *log.Logger) entry.Hook {
+	return entry.HookFunc(func(ctx context.Context, _ domain.Action, _, after *domain.Entry) {
+		if after == nil || after.URL == nil || !after.DeletedAt.IsZero() || !Stale(*after) {
+			return
+		}
+
+		// NOTE(toby3d): fetching must be outlive the request which
+		// changed entry.
+		go func(ctx context.Context, u *url.URL) {
+			if _, err := ucase.Refresh(ctx, u); err != nil {
+				logger.Printf("cannot refresh citations of %s: %s", u, err)
+			}
+		}(context.WithoutCancel(ctx), after.URL)
+	})
+}
+
+// Stale reports whether entry has targets without cached citations or cached
+// citations of removed targets.
+func Stale(e domain.Entry) bool {
+	targets := Targets(e)
+
+	for _, target := range targets {
+		if e.Citation(target) == nil {
+			return true
+		}
+	}
+
+	return len(e.Citations) != len(targets)
+}
+
+// resolve returns raw URL resolved against base. Only http and https URLs are
+// returned, citations are rendered on own pages and must not contain scripts.
+func resolve(base *url.URL, raw string) *url.URL {
+	if raw = strings.TrimSpace(raw); raw == "" {
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil
+	}
+
+	return u
+}
+
+func title(n *html.Node) string {
+	if n.Type == html.ElementNode && n.Data == "title" {
+		if n.FirstChild != nil {
+			return n.FirstChild.Data
+		}
+
+		return ""
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if out := title(c); out != "" {
+			return out
+		}
+	}
+
+	return ""
+}
+
+// truncate cuts text to limit characters on words boundary.
+func truncate(text string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	out := string([]rune(text)[:limit])
+	if i := strings.LastIndex(out, " "); i > 0 {
+		out = out[:i]
+	}
+
+	return strings.TrimRight(out, " ,.;:") + "…"
+}
@@ -0,0 +1,3 @@
+// Package citation provides fetching and caching of reply contexts: h-cite of
+// pages which entries replies to, likes or reposts.
+package citation
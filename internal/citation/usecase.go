@@ -0,0 +1,59 @@
+package citation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Fetch requests target page and returns citation of it.
+		Fetch(ctx context.Context, target *url.URL) (*domain.Citation, error)
+
+		// Refresh fetches citations of all targets of entry on provided
+		// URL and stores them with entry. Citations which cannot be
+		// fetched keeps their previous cached versions.
+		Refresh(ctx context.Context, u *url.URL) (*domain.Entry, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		citation *domain.Citation
+		entry    *domain.Entry
+		err      error
+	}
+)
+
+var (
+	ErrTargetSyntax error = errors.New("target MUST be a valid http or https URL")
+	ErrContentType  error = errors.New("target is not a HTML page")
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Fetch(_ context.Context, _ *url.URL) (*domain.Citation, error) { return nil, nil }
+func (dummyUseCase) Refresh(_ context.Context, _ *url.URL) (*domain.Entry, error)  { return nil, nil }
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(c *domain.Citation, e *domain.Entry, err error) UseCase {
+	return &stubUseCase{
+		citation: c,
+		entry:    e,
+		err:      err,
+	}
+}
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ *url.URL) (*domain.Citation, error) {
+	return ucase.citation, ucase.err
+}
+
+func (ucase *stubUseCase) Refresh(_ context.Context, _ *url.URL) (*domain.Entry, error) {
+	return ucase.entry, ucase.err
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/citation"
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+)
+
+type citationUseCase struct {
+	entries entry.Repository
+	client  *http.Client
+	logger  *log.Logger
+	config  domain.Config
+}
+
+// NewCitationUseCase creates a new citation use case. Provided client MUST be
+// protected from requesting non-public addresses, like httputil.NewClient.
+func NewCitationUseCase(entries entry.Repository, client *http.Client, config domain.Config, logger *log.Logger,
+) citation.UseCase {
+	return &citationUseCase{
+		entries: entries,
+		client:  client,
+		logger:  logger,
+		config:  config,
+	}
+}
+
+// Fetch implements citation.UseCase.
+func (ucase *citationUseCase) Fetch(ctx context.Context, target *url.URL) (*domain.Citation, error) {
+	if target == nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, citation.ErrTargetSyntax
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ucase.config.Citation.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create target request: %w", err)
+	}
+
+	req.Header.Set(common.HeaderAccept, common.MIMETextHTML)
+
+	resp, err := ucase.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch target: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("cannot fetch target: got %d status code", resp.StatusCode)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(common.HeaderContentType)); mediaType !=
+		common.MIMETextHTML {
+		return nil, fmt.Errorf("cannot parse '%s' target: %w", mediaType, citation.ErrContentType)
+	}
+
+	// NOTE(toby3d): h-entry is usually at the beginning of page, so
+	// truncated body is still useful.
+	body, err := httputil.ReadAll(resp.Body, ucase.config.Citation.MaxSize)
+	if err != nil && !errors.Is(err, httputil.ErrTooLarge) {
+		return nil, fmt.Errorf("cannot read target body: %w", err)
+	}
+
+	out, err := citation.Parse(bytes.NewReader(body), resp.Request.URL, ucase.config.Citation.Excerpt)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse target: %w", err)
+	}
+
+	out.URL = target
+	out.FetchedAt = time.Now().UTC()
+
+	return out, nil
+}
+
+// Refresh implements citation.UseCase.
+func (ucase *citationUseCase) Refresh(ctx context.Context, u *url.URL) (*domain.Entry, error) {
+	e, err := ucase.entries.Get(ctx, u.RequestURI())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get entry: %w", err)
+	}
+
+	fetched := make(map[string]*domain.Citation)
+
+	for _, target := range citation.Targets(*e) {
+		c, err := ucase.Fetch(ctx, target)
+		if err != nil {
+			ucase.logger.Printf("cannot fetch citation of %s: %s", target, err)
+
+			continue
+		}
+
+		fetched[target.String()] = c
+	}
+
+	result, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
+		*domain.Entry, error,
+	) {
+		// NOTE(toby3d): targets may be changed while citations are
+		// fetched, so stores citations only of current ones.
+		targets := citation.Targets(*e)
+		citations := make([]*domain.Citation, 0, len(targets))
+
+		for _, target := range targets {
+			if c, ok := fetched[target.String()]; ok {
+				citations = append(citations, c)
+			} else if c = e.Citation(target); c != nil {
+				citations = append(citations, c)
+			}
+		}
+
+		e.Citations = citations
+
+		return e, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot store citations: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,254 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/citation"
+	"source.toby3d.me/toby3d/pub/internal/citation/usecase"
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	config := domain.TestConfig(t)
+	config.Citation.Excerpt = 20
+	ucase := usecase.NewCitationUseCase(entrymemoryrepo.NewMemoryEntryRepository(), srv.Client(), *config,
+		log.New(io.Discard, "", 0))
+
+	for name, tc := range map[string]struct {
+		expect *domain.Citation
+		path   string
+	}{
+		"article": {path: "/article", expect: &domain.Citation{
+			PublishedAt: time.Date(2023, time.January, 2, 12, 0, 0, 0, time.UTC),
+			Photo:       domain.TestURL(t, srv.URL+"/photo.jpg"),
+			Author: domain.Card{
+				URL:  domain.TestURL(t, "https://example.net/"),
+				Name: "Jane Doe",
+			},
+			Name:    "Hello, world",
+			Content: "Lorem ipsum dolor…",
+		}},
+		"note": {path: "/note", expect: &domain.Citation{
+			Author:  domain.Card{URL: domain.TestURL(t, "https://example.net/")},
+			Content: "Just a note",
+		}},
+		"page": {path: "/page", expect: &domain.Citation{Name: "Plain page"}},
+		"unsafe": {path: "/unsafe", expect: &domain.Citation{
+			Author:  domain.Card{Name: "Mallory"},
+			Content: "Click me",
+		}},
+		"relative": {path: "/relative", expect: &domain.Citation{
+			Photo: domain.TestURL(t, srv.URL+"/photo.jpg"),
+			Author: domain.Card{
+				URL:   domain.TestURL(t, srv.URL+"/"),
+				Photo: domain.TestURL(t, srv.URL+"/avatar.jpg"),
+				Name:  "Jane Doe",
+			},
+			Content: "Relative links",
+		}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			target := domain.TestURL(t, srv.URL+tc.path)
+			tc.expect.URL = target
+
+			result, err := ucase.Fetch(context.Background(), target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.FetchedAt.IsZero() {
+				t.Error("expect non-zero fetch time")
+			}
+
+			result.FetchedAt = time.Time{}
+
+			if diff := cmp.Diff(tc.expect, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		for target, expect := range map[string]error{
+			"ftp://example.net/": citation.ErrTargetSyntax,
+			srv.URL + "/feed":    citation.ErrContentType,
+		} {
+			if _, err := ucase.Fetch(context.Background(), domain.TestURL(t, target)); !errors.Is(err, expect) {
+				t.Errorf("Fetch(%s) = %v, want %v", target, err, expect)
+			}
+		}
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+	e.InReplyTo = []*url.URL{domain.TestURL(t, srv.URL+"/note")}
+	e.LikeOf = []*url.URL{domain.TestURL(t, srv.URL+"/missing"), domain.TestURL(t, srv.URL+"/note")}
+
+	// NOTE(toby3d): cached citation of unavailable target must be kept.
+	stale := &domain.Citation{URL: e.LikeOf[0], Name: "Cached"}
+	e.Citations = []*domain.Citation{stale}
+
+	if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	if !citation.Stale(*e) {
+		t.Error("expect stale citations before refresh")
+	}
+
+	result, err := usecase.NewCitationUseCase(entries, srv.Client(), *domain.TestConfig(t),
+		log.New(io.Discard, "", 0)).Refresh(context.Background(), e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if citation.Stale(*result) {
+		t.Errorf("expect fresh citations, got %+v", result.Citations)
+	}
+
+	if c := result.Citation(e.InReplyTo[0]); c == nil || c.Content != "Just a note" {
+		t.Errorf("Citation(%s) = %+v, want fetched note", e.InReplyTo[0], c)
+	}
+
+	if c := result.Citation(e.LikeOf[0]); c != stale {
+		t.Errorf("Citation(%s) = %+v, want %+v", e.LikeOf[0], c, stale)
+	}
+}
+
+func TestRefresh_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<article class="h-entry"><p class="e-content">Removed</p></article>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+	e.InReplyTo = []*url.URL{domain.TestURL(t, srv.URL+"/slow")}
+
+	if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan *domain.Entry)
+
+	go func() {
+		result, err := usecase.NewCitationUseCase(entries, srv.Client(), *domain.TestConfig(t),
+			log.New(io.Discard, "", 0)).Refresh(context.Background(), e.URL)
+		if err != nil {
+			t.Error(err)
+		}
+
+		done <- result
+	}()
+
+	<-started
+
+	// NOTE(toby3d): target is removed while its citation is fetched.
+	if _, err := entries.Update(context.Background(), e.URL.RequestURI(), func(_ context.Context,
+		e *domain.Entry,
+	) (*domain.Entry, error) {
+		e.InReplyTo = nil
+
+		return e, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	close(release)
+
+	if result := <-done; result == nil || len(result.Citations) != 0 {
+		t.Errorf("expect no citations of removed targets, got %+v", result)
+	}
+}
+
+func newTestServer(tb testing.TB) *httptest.Server {
+	tb.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<article class="h-entry">
+			<h1 class="p-name">Hello, world</h1>
+			<a class="p-author h-card" href="https://example.net/">Jane Doe</a>
+			<time class="dt-published" datetime="2023-01-02T12:00:00Z">2 January</time>
+			<img class="u-photo" src="/photo.jpg">
+			<div class="e-content"><p>Lorem ipsum dolor sit amet.</p></div>
+		</article>`)
+	})
+	mux.HandleFunc("/note", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<div class="h-entry">
+			<a class="u-author" href="https://example.net/"></a>
+			<p class="p-name e-content">Just a note</p>
+		</div>`)
+	})
+	mux.HandleFunc("/unsafe", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<div class="h-entry">
+			<div class="p-author h-card">
+				<a class="p-name u-url" href="javascript:alert(1)">Mallory</a>
+				<img class="u-photo" src="data:image/svg+xml,%3Csvg onload=alert(1)%3E">
+			</div>
+			<img class="u-photo" src="javascript:alert(2)">
+			<p class="e-content">Click me</p>
+		</div>`)
+	})
+	mux.HandleFunc("/relative", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<div class="h-entry">
+			<div class="p-author h-card">
+				<a class="p-name u-url" href="/">Jane Doe</a>
+				<img class="u-photo" src="avatar.jpg">
+			</div>
+			<img class="u-photo" src="photo.jpg">
+			<p class="e-content">Relative links</p>
+		</div>`)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<html><head><title> Plain
+			page </title></head><body>Hello</body></html>`)
+	})
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, "application/atom+xml")
+		_, _ = io.WriteString(w, `<feed></feed>`)
+	})
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
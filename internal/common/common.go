@@ -3,22 +3,35 @@ package common
 const charsetUTF8 = "charset=UTF-8"
 
 const (
+	HeaderAccept              string = "Accept"
 	HeaderAcceptLanguage      string = "Accept-Language"
+	HeaderAuthorization       string = "Authorization"
 	HeaderContentType         string = "Content-Type"
+	HeaderETag                string = "ETag"
+	HeaderIdempotencyKey      string = "Idempotency-Key"
+	HeaderIfMatch             string = "If-Match"
 	HeaderLocation            string = "Location"
 	HeaderXContentTypeOptions string = "X-Content-Type-Options"
 	HeaderLink                string = "Link"
+	HeaderWWWAuthenticate     string = "WWW-Authenticate"
 )
 
 const (
-	MIMEApplicationForm            string = "application/x-www-form-urlencoded"
-	MIMEApplicationFormCharsetUTF8 string = MIMEApplicationForm + "; " + charsetUTF8
-	MIMEApplicationJSON            string = "application/json"
-	MIMEApplicationJSONCharsetUTF8 string = MIMEApplicationJSON + "; " + charsetUTF8
-	MIMEMultipartForm              string = "multipart/form-data"
-	MIMEMultipartFormCharsetUTF8   string = MIMEMultipartForm + "; " + charsetUTF8
-	MIMETextHTML                   string = "text/html"
-	MIMETextHTMLCharsetUTF8        string = MIMETextHTML + "; " + charsetUTF8
-	MIMETextPlain                  string = "text/plain"
-	MIMETextPlainCharsetUTF8       string = MIMETextPlain + "; " + charsetUTF8
+	MIMEApplicationAtomXML             string = "application/atom+xml"
+	MIMEApplicationAtomXMLCharsetUTF8  string = MIMEApplicationAtomXML + "; " + charsetUTF8
+	MIMEApplicationFeedJSON            string = "application/feed+json"
+	MIMEApplicationFeedJSONCharsetUTF8 string = MIMEApplicationFeedJSON + "; " + charsetUTF8
+	MIMEApplicationForm                string = "application/x-www-form-urlencoded"
+	MIMEApplicationFormCharsetUTF8     string = MIMEApplicationForm + "; " + charsetUTF8
+	MIMEApplicationJSON                string = "application/json"
+	MIMEApplicationJSONCharsetUTF8     string = MIMEApplicationJSON + "; " + charsetUTF8
+	MIMEApplicationRSSXML              string = "application/rss+xml"
+	MIMEApplicationRSSXMLCharsetUTF8   string = MIMEApplicationRSSXML + "; " + charsetUTF8
+	MIMEMultipartForm                  string = "multipart/form-data"
+	MIMEMultipartFormCharsetUTF8       string = MIMEMultipartForm + "; " + charsetUTF8
+	MIMETextHTML                       string = "text/html"
+	MIMETextHTMLCharsetUTF8            string = MIMETextHTML + "; " + charsetUTF8
+	MIMETextMarkdown                   string = "text/markdown"
+	MIMETextPlain                      string = "text/plain"
+	MIMETextPlainCharsetUTF8           string = MIMETextPlain + "; " + charsetUTF8
 )
@@ -0,0 +1,63 @@
+package contact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type bufferContact struct {
+	Silos    map[string]string `json:"silos"`
+	Name     string            `json:"name"`
+	Nickname string            `json:"nickname"`
+	URL      string            `json:"url"`
+	Photo    string            `json:"photo"`
+}
+
+// Decode reads contacts from JSON in the same format as Micropub contacts
+// query response:
+//
+//	{"contacts": [{"name": "...", "nickname": "...", "url": "...",
+//	"photo": "...", "silos": {"twitter": "..."}}]}
+func Decode(r io.Reader) ([]domain.Contact, error) {
+	buf := new(struct {
+		Contacts []bufferContact `json:"contacts"`
+	})
+
+	if err := json.NewDecoder(r).Decode(buf); err != nil {
+		return nil, fmt.Errorf("cannot decode contacts: %w", err)
+	}
+
+	out := make([]domain.Contact, 0, len(buf.Contacts))
+
+	for _, c := range buf.Contacts {
+		result := domain.Contact{
+			Silos:    c.Silos,
+			Name:     c.Name,
+			Nickname: c.Nickname,
+		}
+
+		for _, field := range []struct {
+			dst **url.URL
+			src string
+		}{{&result.URL, c.URL}, {&result.Photo, c.Photo}} {
+			if field.src == "" {
+				continue
+			}
+
+			u, err := url.Parse(field.src)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode contact '%s': %w", c.Nickname, err)
+			}
+
+			*field.dst = u
+		}
+
+		out = append(out, result)
+	}
+
+	return out, nil
+}
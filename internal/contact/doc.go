@@ -0,0 +1,3 @@
+// Package contact provides a store of persons which can be mentioned in
+// entries, used for autocompletion of mentions by Micropub clients.
+package contact
@@ -0,0 +1,135 @@
+package contact
+
+import (
+	"context"
+	"errors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Repository interface {
+		// Create save provided contact into the store. Returns error if
+		// contact with the same nickname already exists.
+		Create(ctx context.Context, nickname string, c domain.Contact) error
+
+		// Get returns a early stored contact. Returns error if contact
+		// is not exist.
+		Get(ctx context.Context, nickname string) (*domain.Contact, error)
+
+		// Fetch returns all contacts matched by search string, sorted by
+		// name. Empty search returns all contacts.
+		Fetch(ctx context.Context, search string) ([]domain.Contact, int, error)
+
+		// Delete removes contact from the store.
+		Delete(ctx context.Context, nickname string) (bool, error)
+	}
+
+	dummyRepository struct{}
+
+	stubRepository struct {
+		output  *domain.Contact
+		err     error
+		outputs []domain.Contact
+		ok      bool
+	}
+
+	spyRepository struct {
+		subRepository Repository
+		Creates       int
+		Deletes       int
+		Fetches       int
+		Gets          int
+	}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+	// NOTE(toby3d): mockRepository is complicated. Mocking too much is bad.
+)
+
+var (
+	ErrExist    error = errors.New("this contact already exist")
+	ErrNotExist error = errors.New("this contact is not exist")
+)
+
+// NewDummyContactRepository creates an empty repository to satisfy contracts.
+// It is used in tests where repository working is not important.
+func NewDummyContactRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, _ domain.Contact) error { return nil }
+func (dummyRepository) Delete(_ context.Context, _ string) (bool, error)           { return false, nil }
+func (dummyRepository) Get(_ context.Context, _ string) (*domain.Contact, error)   { return nil, nil }
+
+func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.Contact, int, error) {
+	return make([]domain.Contact, 0), 0, nil
+}
+
+// NewStubContactRepository creates a repository that always returns input as
+// a output. It is used in tests where some dependency on the repository is
+// required.
+func NewStubContactRepository(outputs []domain.Contact, output *domain.Contact, err error, ok bool) Repository {
+	return &stubRepository{
+		outputs: outputs,
+		output:  output,
+		err:     err,
+		ok:      ok,
+	}
+}
+
+func (repo *stubRepository) Create(_ context.Context, _ string, _ domain.Contact) error {
+	return repo.err
+}
+
+func (repo *stubRepository) Delete(_ context.Context, _ string) (bool, error) {
+	return repo.ok, repo.err
+}
+
+func (repo *stubRepository) Fetch(_ context.Context, _ string) ([]domain.Contact, int, error) {
+	return repo.outputs, len(repo.outputs), repo.err
+}
+
+func (repo *stubRepository) Get(_ context.Context, _ string) (*domain.Contact, error) {
+	return repo.output, repo.err
+}
+
+// NewSpyContactRepository creates a spy repository which count outside calls,
+// based on provided subRepo. If subRepo is nil, then DummyRepository will be
+// used.
+func NewSpyContactRepository(subRepo Repository) *spyRepository {
+	if subRepo == nil {
+		subRepo = NewDummyContactRepository()
+	}
+
+	return &spyRepository{
+		subRepository: subRepo,
+		Creates:       0,
+		Gets:          0,
+		Fetches:       0,
+		Deletes:       0,
+	}
+}
+
+func (repo *spyRepository) Create(ctx context.Context, nickname string, c domain.Contact) error {
+	repo.Creates++
+
+	return repo.subRepository.Create(ctx, nickname, c)
+}
+
+func (repo *spyRepository) Delete(ctx context.Context, nickname string) (bool, error) {
+	repo.Deletes++
+
+	return repo.subRepository.Delete(ctx, nickname)
+}
+
+func (repo *spyRepository) Fetch(ctx context.Context, search string) ([]domain.Contact, int, error) {
+	repo.Fetches++
+
+	return repo.subRepository.Fetch(ctx, search)
+}
+
+func (repo *spyRepository) Get(ctx context.Context, nickname string) (*domain.Contact, error) {
+	repo.Gets++
+
+	return repo.subRepository.Get(ctx, nickname)
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/contact"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type memoryContactRepository struct {
+	mutex    *sync.RWMutex
+	contacts map[string]domain.Contact // lowercased nickname → contact
+}
+
+func NewMemoryContactRepository() contact.Repository {
+	return &memoryContactRepository{
+		mutex:    new(sync.RWMutex),
+		contacts: make(map[string]domain.Contact),
+	}
+}
+
+func (repo *memoryContactRepository) Create(_ context.Context, nickname string, c domain.Contact) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	nickname = strings.ToLower(nickname)
+	if _, ok := repo.contacts[nickname]; ok {
+		return contact.ErrExist
+	}
+
+	repo.contacts[nickname] = c
+
+	return nil
+}
+
+func (repo *memoryContactRepository) Get(_ context.Context, nickname string) (*domain.Contact, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if out, ok := repo.contacts[strings.ToLower(nickname)]; ok {
+		return &out, nil
+	}
+
+	return nil, contact.ErrNotExist
+}
+
+func (repo *memoryContactRepository) Fetch(_ context.Context, search string) ([]domain.Contact, int, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := make([]domain.Contact, 0)
+
+	for _, c := range repo.contacts {
+		if c.Match(search) {
+			out = append(out, c)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if name := strings.ToLower(out[i].Name); name != strings.ToLower(out[j].Name) {
+			return name < strings.ToLower(out[j].Name)
+		}
+
+		return strings.ToLower(out[i].Nickname) < strings.ToLower(out[j].Nickname)
+	})
+
+	return out, len(out), nil
+}
+
+func (repo *memoryContactRepository) Delete(_ context.Context, nickname string) (bool, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	nickname = strings.ToLower(nickname)
+	if _, ok := repo.contacts[nickname]; !ok {
+		return false, nil
+	}
+
+	delete(repo.contacts, nickname)
+
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package contact
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Create stores a new contact. Contact MUST have a nickname.
+		Create(ctx context.Context, c domain.Contact) error
+
+		// Fetch returns contacts which name, nickname, URL or silos
+		// usernames contains search string. Empty search returns all
+		// contacts.
+		Fetch(ctx context.Context, search string) ([]domain.Contact, error)
+
+		// Get returns contact with provided nickname, with or without
+		// '@' prefix.
+		Get(ctx context.Context, nickname string) (*domain.Contact, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		err      error
+		contacts []domain.Contact
+	}
+)
+
+var ErrNoNickname error = domain.Error{
+	Description: "contact MUST have a nickname",
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusBadRequest,
+}
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Create(_ context.Context, _ domain.Contact) error { return nil }
+
+func (dummyUseCase) Fetch(_ context.Context, _ string) ([]domain.Contact, error) {
+	return make([]domain.Contact, 0), nil
+}
+
+func (dummyUseCase) Get(_ context.Context, _ string) (*domain.Contact, error) {
+	return nil, nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(contacts []domain.Contact, err error) UseCase {
+	return &stubUseCase{
+		contacts: contacts,
+		err:      err,
+	}
+}
+
+func (ucase *stubUseCase) Create(_ context.Context, _ domain.Contact) error { return ucase.err }
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ string) ([]domain.Contact, error) {
+	return ucase.contacts, ucase.err
+}
+
+// Get returns the first of provided contacts with requested nickname.
+func (ucase *stubUseCase) Get(_ context.Context, nickname string) (*domain.Contact, error) {
+	if ucase.err != nil {
+		return nil, ucase.err
+	}
+
+	nickname = strings.TrimPrefix(nickname, "@")
+
+	for i := range ucase.contacts {
+		if strings.EqualFold(ucase.contacts[i].Nickname, nickname) {
+			return &ucase.contacts[i], nil
+		}
+	}
+
+	return nil, ErrNotExist
+}
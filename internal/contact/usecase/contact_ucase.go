@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"source.toby3d.me/toby3d/pub/internal/contact"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type contactUseCase struct {
+	contacts contact.Repository
+}
+
+func NewContactUseCase(contacts contact.Repository) contact.UseCase {
+	return &contactUseCase{
+		contacts: contacts,
+	}
+}
+
+// Create implements contact.UseCase.
+func (ucase *contactUseCase) Create(ctx context.Context, c domain.Contact) error {
+	if c.Nickname = strings.TrimPrefix(strings.TrimSpace(c.Nickname), "@"); c.Nickname == "" {
+		return fmt.Errorf("cannot create contact: %w", contact.ErrNoNickname)
+	}
+
+	if err := ucase.contacts.Create(ctx, c.Nickname, c); err != nil {
+		return fmt.Errorf("cannot create contact: %w", err)
+	}
+
+	return nil
+}
+
+// Fetch implements contact.UseCase.
+func (ucase *contactUseCase) Fetch(ctx context.Context, search string) ([]domain.Contact, error) {
+	out, _, err := ucase.contacts.Fetch(ctx, strings.TrimPrefix(search, "@"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch contacts: %w", err)
+	}
+
+	return out, nil
+}
+
+// Get implements contact.UseCase.
+func (ucase *contactUseCase) Get(ctx context.Context, nickname string) (*domain.Contact, error) {
+	out, err := ucase.contacts.Get(ctx, strings.TrimPrefix(strings.TrimSpace(nickname), "@"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get contact: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/contact"
+	contactmemoryrepo "source.toby3d.me/toby3d/pub/internal/contact/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/contact/usecase"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	jane := domain.TestContact(t)
+	john := domain.TestContact(t)
+	john.Name, john.Nickname, john.Silos = "John Smith", "john", map[string]string{"github": "jsmith"}
+
+	ucase := usecase.NewContactUseCase(contactmemoryrepo.NewMemoryContactRepository())
+
+	for _, c := range []*domain.Contact{john, jane} {
+		if err := ucase.Create(context.Background(), *c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ucase.Create(context.Background(), *jane); !errors.Is(err, contact.ErrExist) {
+		t.Errorf("expect %v error for duplicate, got %v", contact.ErrExist, err)
+	}
+
+	if err := ucase.Create(context.Background(), domain.Contact{Name: "Anonymous"}); !errors.Is(err,
+		contact.ErrNoNickname) {
+		t.Errorf("expect %v error for contact without nickname, got %v", contact.ErrNoNickname, err)
+	}
+
+	for name, tc := range map[string]struct {
+		search string
+		expect []string
+	}{
+		"all":      {search: "", expect: []string{"jane", "john"}},
+		"name":     {search: "doe", expect: []string{"jane"}},
+		"nickname": {search: "@JOH", expect: []string{"john"}},
+		"silo":     {search: "jsmith", expect: []string{"john"}},
+		"host":     {search: "example.net", expect: []string{"jane", "john"}},
+		"none":     {search: "alice", expect: []string{}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ucase.Fetch(context.Background(), tc.search)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nicknames := make([]string, 0, len(out))
+			for i := range out {
+				nicknames = append(nicknames, out[i].Nickname)
+			}
+
+			if diff := cmp.Diff(nicknames, tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	jane := domain.TestContact(t)
+	ucase := usecase.NewContactUseCase(contactmemoryrepo.NewMemoryContactRepository())
+
+	if err := ucase.Create(context.Background(), *jane); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := ucase.Get(context.Background(), "@jane")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(out, jane); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err = ucase.Get(context.Background(), "john"); !errors.Is(err, contact.ErrNotExist) {
+		t.Errorf("expect %v error for unknown nickname, got %v", contact.ErrNotExist, err)
+	}
+}
@@ -18,6 +18,9 @@ var (
 	ActionUpdate   Action = Action{action: "update"}   // "update"
 	ActionDelete   Action = Action{action: "delete"}   // "delete"
 	ActionUndelete Action = Action{action: "undelete"} // "undelete"
+	ActionRevert   Action = Action{action: "revert"}   // "revert"
+	ActionPurge    Action = Action{action: "purge"}    // "purge"
+	ActionRefresh  Action = Action{action: "refresh"}  // "refresh"
 )
 
 var ErrActionSyntax error = Error{
@@ -31,6 +34,9 @@ var stringsActions = map[string]Action{
 	ActionUpdate.action:   ActionUpdate,
 	ActionDelete.action:   ActionDelete,
 	ActionUndelete.action: ActionUndelete,
+	ActionRevert.action:   ActionRevert,
+	ActionPurge.action:    ActionPurge,
+	ActionRefresh.action:  ActionRefresh,
 }
 
 func ParseAction(raw string) (Action, error) {
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Citation represent a cached h-cite of some external page which entry
+// replies to, likes or reposts.
+type Citation struct {
+	FetchedAt   time.Time
+	PublishedAt time.Time // dt-published
+	URL         *url.URL  // u-url
+	Photo       *url.URL  // u-photo
+	Author      Card      // p-author
+	Name        string    // p-name
+	Content     string    // p-content, plain text excerpt
+}
+
+// TestCitation returns a valid Citation for tests.
+func TestCitation(tb testing.TB) *Citation {
+	tb.Helper()
+
+	return &Citation{
+		FetchedAt:   time.Date(2023, time.January, 2, 15, 4, 5, 0, time.UTC),
+		PublishedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+		URL:         &url.URL{Scheme: "https", Host: "example.net", Path: "/notes/hello"},
+		Author: Card{
+			URL:  &url.URL{Scheme: "https", Host: "example.net", Path: "/"},
+			Name: "Jane Doe",
+		},
+		Name:    "Hello, world",
+		Content: "Lorem ipsum dolor sit amet.",
+	}
+}
@@ -3,13 +3,25 @@ package domain
 import (
 	"net/url"
 	"testing"
+	"time"
 )
 
 type (
 	// Config represent a global micropub instance configuration.
 	Config struct {
-		HTTP     ConfigHTTP `envPrefix:"HTTP_"`
-		MediaDir string     `env:"MEDIA_DIR" envDefault:"media"`
+		HTTP        ConfigHTTP        `envPrefix:"HTTP_"`
+		Media       ConfigMedia       `envPrefix:"MEDIA_"`
+		Webmention  ConfigWebmention  `envPrefix:"WEBMENTION_"`
+		WebSub      ConfigWebSub      `envPrefix:"WEBSUB_"`
+		Syndication ConfigSyndication `envPrefix:"SYNDICATION_"`
+		Citation    ConfigCitation    `envPrefix:"CITATION_"`
+		Sanitize    ConfigSanitize    `envPrefix:"SANITIZE_"`
+		Idempotency ConfigIdempotency `envPrefix:"IDEMPOTENCY_"`
+		Trash       ConfigTrash       `envPrefix:"TRASH_"`
+		Shortlink   ConfigShortlink   `envPrefix:"SHORTLINK_"`
+		MediaDir    string            `env:"MEDIA_DIR" envDefault:"media"`
+		Contacts    string            `env:"CONTACTS"` // path to JSON file with contacts, empty means none
+		Token       string            `env:"TOKEN"`    // access token of the owner, empty disables private endpoints
 	}
 
 	// ConfigHTTP represents HTTP configs which used for instance serving
@@ -19,6 +31,92 @@ type (
 		Host  string `env:"HOST" envDefault:"localhost:3000"`
 		Proto string `env:"PROTO" envDefault:"http"`
 	}
+
+	// ConfigMedia represents media storage limits and orphaned files
+	// collecting options.
+	ConfigMedia struct {
+		Quota           int64         `env:"QUOTA" envDefault:"0"` // in bytes, zero means unlimited
+		GracePeriod     time.Duration `env:"GRACE_PERIOD" envDefault:"24h"`
+		CollectInterval time.Duration `env:"COLLECT_INTERVAL" envDefault:"1h"`
+		DryRun          bool          `env:"DRY_RUN" envDefault:"false"`
+		Rehost          bool          `env:"REHOST" envDefault:"false"` // download remote media URLs
+		RemoteMaxSize   int64         `env:"REMOTE_MAX_SIZE" envDefault:"52428800"`
+		RemoteTimeout   time.Duration `env:"REMOTE_TIMEOUT" envDefault:"30s"`
+	}
+
+	// ConfigWebmention represents options of sending webmentions to linked
+	// pages and receiving webmentions from other sites.
+	ConfigWebmention struct {
+		Retries  int           `env:"RETRIES" envDefault:"5"`
+		Backoff  time.Duration `env:"BACKOFF" envDefault:"1m"` // initial delay, doubles on each retry
+		Timeout  time.Duration `env:"TIMEOUT" envDefault:"10s"`
+		Moderate bool          `env:"MODERATE" envDefault:"true"` // hold received mentions until approved
+	}
+
+	// ConfigWebSub represents options of notifying WebSub hubs about
+	// changed feeds and of built-in hub.
+	ConfigWebSub struct {
+		Hubs    []string      `env:"HUBS" envSeparator:","`   // external hubs endpoints
+		Hub     bool          `env:"HUB" envDefault:"false"`  // enable built-in hub
+		Lease   time.Duration `env:"LEASE" envDefault:"240h"` // default subscription lease of built-in hub
+		Retries int           `env:"RETRIES" envDefault:"5"`
+		Backoff time.Duration `env:"BACKOFF" envDefault:"1m"` // initial delay, doubles on each retry
+		Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	}
+
+	// ConfigSyndication represents options of POSSE syndication targets.
+	ConfigSyndication struct {
+		Mastodon ConfigMastodon `envPrefix:"MASTODON_"`
+		Timeout  time.Duration  `env:"TIMEOUT" envDefault:"30s"`
+	}
+
+	// ConfigCitation represents options of fetching reply contexts of
+	// replied, liked and reposted pages.
+	ConfigCitation struct {
+		Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+		MaxSize int64         `env:"MAX_SIZE" envDefault:"1048576"` // in bytes, larger pages are truncated
+		Excerpt int           `env:"EXCERPT" envDefault:"280"`      // max length of content in characters
+	}
+
+	// ConfigSanitize represents allowlists of HTML content sanitiser.
+	// Attributes are provided as 'element:attribute' pairs, '*' element
+	// means any allowed element.
+	ConfigSanitize struct {
+		Elements   []string `env:"ELEMENTS" envSeparator:","`                               // empty means defaults
+		Attributes []string `env:"ATTRIBUTES" envSeparator:","`                             // empty means defaults
+		Schemes    []string `env:"SCHEMES" envSeparator:"," envDefault:"http,https,mailto"` // of absolute URLs
+		Reject     bool     `env:"REJECT" envDefault:"false"`                               // fail instead of stripping
+	}
+
+	// ConfigIdempotency represents options of replaying responses for
+	// retried requests with Idempotency-Key header.
+	ConfigIdempotency struct {
+		Window time.Duration `env:"WINDOW" envDefault:"24h"` // zero disables keys support
+	}
+
+	// ConfigTrash represents options of purging deleted entries.
+	ConfigTrash struct {
+		Retention time.Duration `env:"RETENTION" envDefault:"720h"` // zero keeps deleted entries forever
+		Interval  time.Duration `env:"INTERVAL" envDefault:"1h"`
+	}
+
+	// ConfigShortlink represents options of short permalinks of entries.
+	// Codes are 'date' for NewBase60 day of publishing with ordinal
+	// number within it, or 'sequence' for NewBase60 ordinal number of
+	// entry.
+	ConfigShortlink struct {
+		Host   string `env:"HOST"`                    // short domain, empty means HTTP host
+		Prefix string `env:"PREFIX" envDefault:"/s/"` // path of short URLs on host
+		Codes  string `env:"CODES" envDefault:"date"` // 'date' or 'sequence'
+	}
+
+	// ConfigMastodon represents credentials of Mastodon-API-compatible
+	// syndication target.
+	ConfigMastodon struct {
+		Instance string `env:"INSTANCE"` // instance root URL, empty disables target
+		Token    string `env:"TOKEN"`    // access token with write:statuses scope
+		Name     string `env:"NAME"`     // human-readable target name
+	}
 )
 
 // TestConfig returns a valid Config for tests.
@@ -31,7 +129,63 @@ func TestConfig(tb testing.TB) *Config {
 			Host:  "example.com",
 			Proto: "https",
 		},
+		Media: ConfigMedia{
+			Quota:           0,
+			GracePeriod:     24 * time.Hour,
+			CollectInterval: time.Hour,
+			DryRun:          false,
+			Rehost:          false,
+			RemoteMaxSize:   50 * 1024 * 1024,
+			RemoteTimeout:   30 * time.Second,
+		},
+		Webmention: ConfigWebmention{
+			Retries:  5,
+			Backoff:  time.Millisecond,
+			Timeout:  time.Second,
+			Moderate: true,
+		},
+		WebSub: ConfigWebSub{
+			Hubs:    []string{},
+			Hub:     false,
+			Lease:   240 * time.Hour,
+			Retries: 5,
+			Backoff: time.Millisecond,
+			Timeout: time.Second,
+		},
+		Syndication: ConfigSyndication{
+			Mastodon: ConfigMastodon{
+				Instance: "",
+				Token:    "",
+				Name:     "",
+			},
+			Timeout: time.Second,
+		},
+		Citation: ConfigCitation{
+			Timeout: time.Second,
+			MaxSize: 1024 * 1024,
+			Excerpt: 280,
+		},
+		Sanitize: ConfigSanitize{
+			Elements:   []string{"a", "b", "blockquote", "br", "code", "em", "i", "img", "li", "ol", "p", "pre", "strong", "ul"},
+			Attributes: []string{"*:class", "*:title", "a:href", "a:rel", "img:alt", "img:src"},
+			Schemes:    []string{"http", "https", "mailto"},
+			Reject:     false,
+		},
+		Idempotency: ConfigIdempotency{
+			Window: 24 * time.Hour,
+		},
+		Trash: ConfigTrash{
+			Retention: 720 * time.Hour,
+			Interval:  time.Hour,
+		},
+		Shortlink: ConfigShortlink{
+			Host:   "",
+			Prefix: "/s/",
+			Codes:  "date",
+		},
 		MediaDir: "media",
+		Contacts: "",
+		Token:    "",
 	}
 }
 
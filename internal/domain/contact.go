@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Contact represent a single person which can be mentioned in entries.
+//
+// See: https://indieweb.org/Micropub-extensions#Query_for_Contacts
+type Contact struct {
+	URL      *url.URL          // u-url, personal site
+	Photo    *url.URL          // u-photo
+	Silos    map[string]string // silo name → username, like "twitter": "aaronpk"
+	Name     string            // p-name
+	Nickname string            // p-nickname, unique identifier of contact
+}
+
+// TestContact returns a valid Contact for tests.
+func TestContact(tb testing.TB) *Contact {
+	tb.Helper()
+
+	return &Contact{
+		URL:      &url.URL{Scheme: "https", Host: "example.net", Path: "/"},
+		Photo:    &url.URL{Scheme: "https", Host: "example.net", Path: "/photo.jpg"},
+		Silos:    map[string]string{"mastodon": "@jane@mastodon.example"},
+		Name:     "Jane Doe",
+		Nickname: "jane",
+	}
+}
+
+// Match reports whether name, nickname, URL host or any of silos usernames
+// of contact contains search string, ignoring case. Empty search matches
+// any contact.
+func (c Contact) Match(search string) bool {
+	search = strings.ToLower(strings.TrimSpace(search))
+	if search == "" {
+		return true
+	}
+
+	values := []string{c.Name, c.Nickname}
+	if c.URL != nil {
+		values = append(values, c.URL.Host)
+	}
+
+	for _, username := range c.Silos {
+		values = append(values, username)
+	}
+
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), search) {
+			return true
+		}
+	}
+
+	return false
+}
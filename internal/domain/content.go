@@ -1,8 +1,78 @@
 package domain
 
-import "golang.org/x/net/html"
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
 
 type Content struct {
 	HTML *html.Node
 	Text string
+	// Markdown is a source of HTML content, if it was written in
+	// Markdown. Kept as is for editing.
+	Markdown string
+}
+
+// RenderHTML returns HTML of content without document wrappers. Plain text
+// content is escaped.
+func (c Content) RenderHTML() string {
+	if c.HTML == nil {
+		return template.HTMLEscapeString(c.Text)
+	}
+
+	root := body(c.HTML)
+	buf := bytes.NewBuffer(nil)
+
+	for n := root.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(buf, n); err != nil {
+			return ""
+		}
+	}
+
+	return buf.String()
+}
+
+// PlainText returns text of content, extracted from HTML if plain text is
+// not provided.
+func (c Content) PlainText() string {
+	if c.Text != "" || c.HTML == nil {
+		return c.Text
+	}
+
+	buf := new(strings.Builder)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			buf.WriteString(n.Data)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(body(c.HTML))
+
+	return strings.TrimSpace(buf.String())
+}
+
+// body returns body element of parsed document or n itself if it's a
+// fragment.
+func body(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Body {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if out := body(c); out != nil && out.DataAtom == atom.Body {
+			return out
+		}
+	}
+
+	return n
 }
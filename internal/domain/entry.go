@@ -3,14 +3,18 @@ package domain
 import (
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 )
 
 // Entry represent a single microformats2 entry.
 type Entry struct {
-	CreatedAt   time.Time
-	DeletedAt   time.Time
+	CreatedAt time.Time
+	DeletedAt time.Time
+	// Version is a revision token of stored entry, incremented by
+	// repository on each change of it.
+	Version     uint64
 	Title       string    // p-name
 	Description string    // p-summary
 	Content     Content   // e-content
@@ -21,11 +25,13 @@ type Entry struct {
 	URL  *url.URL // u-url
 	ID   string   // u-uid
 	// TODO(toby3d): Location string // p-location
-	Syndications []*url.URL // u-syndication
-	// TODO(toby3d): Reply *url.URL // u-in-reply-to
-	RSVP RSVP // p-rsvp
-	// TODO(toby3d): Like *url.URL // u-like-of
-	// TODO(toby3d): Repost *url.URL // u-repost-of
+	Syndications []*url.URL  // u-syndication
+	InReplyTo    []*url.URL  // u-in-reply-to
+	RSVP         RSVP        // p-rsvp
+	Status       PostStatus  // post-status
+	LikeOf       []*url.URL  // u-like-of
+	RepostOf     []*url.URL  // u-repost-of
+	Citations    []*Citation // cached reply contexts of u-in-reply-to, u-like-of and u-repost-of
 
 	// Draft Properties
 	// TODO(toby3d): Comments []string // p-comment
@@ -36,13 +42,13 @@ type Entry struct {
 	Audio []*url.URL // u-audio
 	// TODO(toby3d): Like []*url.URL // u-like
 	// TODO(toby3d): Repost []*url.URL // u-repost
-	// TODO(toby3d): BookmarkOf []*url.URL // u-bookmark-of
+	BookmarkOf []*url.URL // u-bookmark-of
 	// TODO(toby3d): Featured []*url.URL // u-featured
-	Latitude  float32 // p-latitude
-	Longitude float32 // p-longitude
-	Altitude  float32 // p-altitude
-	// TODO(toby3d): Duration int // p-duration
-	// TODO(toby3d): Size int // p-size
+	Latitude  float32       // p-latitude
+	Longitude float32       // p-longitude
+	Altitude  float32       // p-altitude
+	Duration  time.Duration // p-duration
+	Size      uint64        // p-size
 	// TODO(toby3d): ListenOf *url.URL // u-listen-of
 	// TODO(toby3d): WatchOf *url.URL // u-watch-of
 	// TODO(toby3d): ReadOf *url.URL // u-read-of
@@ -67,3 +73,91 @@ func TestEntry(tb testing.TB) *Entry {
 		Tags: []string{"lorem", "ipsum", "dor"},
 	}
 }
+
+// Type returns a post type of entry discovered by it's properties.
+//
+// See: https://ptd.spec.indieweb.org/#algorithm
+func (e Entry) Type() PostType {
+	switch {
+	case e.RSVP != RSVPUnd:
+		return PostTypeRSVP
+	case len(e.InReplyTo) > 0:
+		return PostTypeReply
+	case len(e.RepostOf) > 0:
+		return PostTypeRepost
+	case len(e.LikeOf) > 0:
+		return PostTypeLike
+	case len(e.BookmarkOf) > 0:
+		return PostTypeBookmark
+	case len(e.Video) > 0:
+		return PostTypeVideo
+	case len(e.Audio) > 0:
+		return PostTypeAudio
+	case len(e.Photo) > 0:
+		return PostTypePhoto
+	}
+
+	name := strings.Join(strings.Fields(e.Title), " ")
+	if name == "" {
+		return PostTypeNote
+	}
+
+	content := strings.Join(strings.Fields(e.Content.PlainText()), " ")
+	if content == "" {
+		content = strings.Join(strings.Fields(e.Description), " ")
+	}
+
+	if strings.HasPrefix(content, name) {
+		return PostTypeNote
+	}
+
+	return PostTypeArticle
+}
+
+// Date returns publication date of entry, fallbacks to it's creation date.
+// Entries are ordered by this date.
+func (e Entry) Date() time.Time {
+	if !e.PublishedAt.IsZero() {
+		return e.PublishedAt
+	}
+
+	return e.CreatedAt
+}
+
+// Citation returns cached reply context of provided URL, or nil.
+func (e Entry) Citation(u *url.URL) *Citation {
+	if u == nil {
+		return nil
+	}
+
+	for i := range e.Citations {
+		if e.Citations[i] != nil && e.Citations[i].URL != nil && e.Citations[i].URL.String() == u.String() {
+			return e.Citations[i]
+		}
+	}
+
+	return nil
+}
+
+// IsPublished reports whether entry is neither draft nor deleted, so it can be
+// listed publicly.
+func (e Entry) IsPublished() bool {
+	return e.DeletedAt.IsZero() && e.Status != PostStatusDraft
+}
+
+// Clone returns copy of entry which does not share slices with e, so changes
+// of one does not affect another.
+func (e Entry) Clone() Entry {
+	e.Tags = append([]string(nil), e.Tags...)
+	e.Syndications = append([]*url.URL(nil), e.Syndications...)
+	e.InReplyTo = append([]*url.URL(nil), e.InReplyTo...)
+	e.LikeOf = append([]*url.URL(nil), e.LikeOf...)
+	e.RepostOf = append([]*url.URL(nil), e.RepostOf...)
+	e.BookmarkOf = append([]*url.URL(nil), e.BookmarkOf...)
+	e.Photo = append([]*url.URL(nil), e.Photo...)
+	e.Video = append([]*url.URL(nil), e.Video...)
+	e.Audio = append([]*url.URL(nil), e.Audio...)
+	e.Citations = append([]*Citation(nil), e.Citations...)
+
+	return e
+}
@@ -26,11 +26,11 @@ func (e Error) Format(f fmt.State, r rune) {
 func (e Error) FormatError(p xerrors.Printer) error {
 	p.Printf("%d: %s", e.Code, e.Description)
 
-	if !p.Detail() {
-		return e
+	// NOTE(toby3d): Error does not wrap anything, so returning itself
+	// here loops formatting forever.
+	if p.Detail() {
+		e.Frame.Format(p)
 	}
 
-	e.Frame.Format(p)
-
 	return nil
 }
@@ -7,12 +7,18 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // File represent a single media file, like photo.
 type File struct {
-	Path    string // content/example/photo.jpg
-	Content []byte
+	CreatedAt time.Time
+	Path      string // content/example/photo.jpg
+	Content   []byte
+	Width     int           // images and video, in pixels
+	Height    int           // images and video, in pixels
+	Duration  time.Duration // audio and video
+	Bitrate   int           // audio and video, in bits per second
 }
 
 //go:embed testdata/sunset.jpg
@@ -66,6 +72,11 @@ func (f File) Dir() string {
 	return filepath.Dir(f.Path)
 }
 
+// Size returns file content size in bytes.
+func (f File) Size() int {
+	return len(f.Content)
+}
+
 // MediaType returns media type based on file extention.
 func (f File) MediaType() string {
 	return mime.TypeByExtension(f.Ext())
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// IdempotentResponse represent a stored response of request made with
+// Idempotency-Key header, replayed for retries of the same request.
+type IdempotentResponse struct {
+	CreatedAt   time.Time
+	Header      http.Header
+	Fingerprint string // hash of request, retries MUST have the same one
+	Body        []byte
+	StatusCode  int // zero means request is still in progress
+}
+
+// TestIdempotentResponse returns a valid IdempotentResponse for tests.
+func TestIdempotentResponse(tb testing.TB) *IdempotentResponse {
+	tb.Helper()
+
+	return &IdempotentResponse{
+		CreatedAt:   time.Now().UTC(),
+		Header:      http.Header{"Location": {"https://example.com/samples/lipsum"}},
+		Fingerprint: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		Body:        nil,
+		StatusCode:  http.StatusCreated,
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+type (
+	// Mention represent a single received webmention of some entry.
+	Mention struct {
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		PublishedAt time.Time // dt-published of source
+		Source      *url.URL
+		Target      *url.URL
+		Author      Card
+		Type        MentionType
+		Status      MentionStatus
+		Title       string // p-name of source
+		Content     string // plain text content of source
+	}
+
+	// Card represent a minimal h-card of some person.
+	Card struct {
+		URL   *url.URL // u-url
+		Photo *url.URL // u-photo
+		Name  string   // p-name
+	}
+
+	// MentionType describes a relation of source to target.
+	MentionType struct {
+		mentionType string
+	}
+
+	// MentionStatus describes a moderation status of mention.
+	MentionStatus struct {
+		mentionStatus string
+	}
+)
+
+var (
+	MentionTypeUnd      MentionType = MentionType{}           // "und"
+	MentionTypeMention  MentionType = MentionType{"mention"}  // "mention"
+	MentionTypeReply    MentionType = MentionType{"reply"}    // "reply"
+	MentionTypeLike     MentionType = MentionType{"like"}     // "like"
+	MentionTypeRepost   MentionType = MentionType{"repost"}   // "repost"
+	MentionTypeBookmark MentionType = MentionType{"bookmark"} // "bookmark"
+)
+
+var (
+	MentionStatusUnd      MentionStatus = MentionStatus{}           // "und"
+	MentionStatusPending  MentionStatus = MentionStatus{"pending"}  // "pending"
+	MentionStatusApproved MentionStatus = MentionStatus{"approved"} // "approved"
+	MentionStatusRejected MentionStatus = MentionStatus{"rejected"} // "rejected"
+)
+
+var ErrMentionStatusSyntax error = Error{
+	Description: fmt.Sprintf("got unsupported mention status, expect '%s', '%s' or '%s'", MentionStatusPending,
+		MentionStatusApproved, MentionStatusRejected),
+	Frame: xerrors.Caller(1),
+	Code:  http.StatusBadRequest,
+}
+
+var stringsMentionStatuses = map[string]MentionStatus{
+	MentionStatusPending.mentionStatus:  MentionStatusPending,
+	MentionStatusApproved.mentionStatus: MentionStatusApproved,
+	MentionStatusRejected.mentionStatus: MentionStatusRejected,
+}
+
+// TestMention returns a valid pending Mention for tests.
+func TestMention(tb testing.TB) *Mention {
+	tb.Helper()
+
+	now := time.Now().UTC()
+
+	return &Mention{
+		CreatedAt: now,
+		UpdatedAt: now,
+		Source:    &url.URL{Scheme: "https", Host: "example.net", Path: "/replies/1"},
+		Target:    &url.URL{Scheme: "https", Host: "example.com", Path: "/samples/lipsum"},
+		Author: Card{
+			Name: "Jane Doe",
+			URL:  &url.URL{Scheme: "https", Host: "example.net", Path: "/"},
+		},
+		Type:    MentionTypeReply,
+		Status:  MentionStatusPending,
+		Content: "Nice post!",
+	}
+}
+
+// ID returns a stable identifier of source and target pair.
+func (m Mention) ID() string {
+	hash := sha256.Sum256([]byte(m.Source.String() + " " + m.Target.String()))
+
+	return hex.EncodeToString(hash[:16])
+}
+
+func (mt MentionType) String() string {
+	if mt.mentionType == "" {
+		return "und"
+	}
+
+	return mt.mentionType
+}
+
+func (mt MentionType) GoString() string {
+	return "domain.MentionType(" + mt.String() + ")"
+}
+
+func ParseMentionStatus(v string) (MentionStatus, error) {
+	if out, ok := stringsMentionStatuses[v]; ok {
+		return out, nil
+	}
+
+	return MentionStatusUnd, fmt.Errorf("cannot parse '%s' as mention status: %w", v, ErrMentionStatusSyntax)
+}
+
+func (ms MentionStatus) String() string {
+	if ms.mentionStatus == "" {
+		return "und"
+	}
+
+	return ms.mentionStatus
+}
+
+func (ms MentionStatus) GoString() string {
+	return "domain.MentionStatus(" + ms.String() + ")"
+}
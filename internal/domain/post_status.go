@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
 // See: https://indieweb.org/Micropub-extensions#Post_Status
 type PostStatus struct {
 	postStatus string
@@ -11,6 +19,26 @@ var (
 	PostStatusPublished = PostStatus{"published"} // "published"
 )
 
+var ErrPostStatusSyntax error = Error{
+	Description: fmt.Sprintf("got unsupported post status, expect '%s' or '%s'", PostStatusDraft,
+		PostStatusPublished),
+	Frame: xerrors.Caller(1),
+	Code:  http.StatusBadRequest,
+}
+
+var stringsPostStatuses = map[string]PostStatus{
+	PostStatusDraft.postStatus:     PostStatusDraft,
+	PostStatusPublished.postStatus: PostStatusPublished,
+}
+
+func ParsePostStatus(v string) (PostStatus, error) {
+	if out, ok := stringsPostStatuses[strings.ToLower(v)]; ok {
+		return out, nil
+	}
+
+	return PostStatusUnd, fmt.Errorf("cannot parse '%s' as post status: %w", v, ErrPostStatusSyntax)
+}
+
 func (ps PostStatus) String() string {
 	if ps.postStatus == "" {
 		return "und"
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// PostType describes a kind of entry based on it's properties.
+//
+// See: https://ptd.spec.indieweb.org/
+type PostType struct {
+	postType string
+}
+
+var (
+	PostTypeUnd      = PostType{}           // "und"
+	PostTypeNote     = PostType{"note"}     // "note"
+	PostTypeArticle  = PostType{"article"}  // "article"
+	PostTypePhoto    = PostType{"photo"}    // "photo"
+	PostTypeVideo    = PostType{"video"}    // "video"
+	PostTypeAudio    = PostType{"audio"}    // "audio"
+	PostTypeReply    = PostType{"reply"}    // "reply"
+	PostTypeLike     = PostType{"like"}     // "like"
+	PostTypeRepost   = PostType{"repost"}   // "repost"
+	PostTypeBookmark = PostType{"bookmark"} // "bookmark"
+	PostTypeRSVP     = PostType{"rsvp"}     // "rsvp"
+)
+
+var ErrPostTypeSyntax error = Error{
+	Description: "got unsupported post type",
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusBadRequest,
+}
+
+var stringsPostTypes = map[string]PostType{
+	PostTypeNote.postType:     PostTypeNote,
+	PostTypeArticle.postType:  PostTypeArticle,
+	PostTypePhoto.postType:    PostTypePhoto,
+	PostTypeVideo.postType:    PostTypeVideo,
+	PostTypeAudio.postType:    PostTypeAudio,
+	PostTypeReply.postType:    PostTypeReply,
+	PostTypeLike.postType:     PostTypeLike,
+	PostTypeRepost.postType:   PostTypeRepost,
+	PostTypeBookmark.postType: PostTypeBookmark,
+	PostTypeRSVP.postType:     PostTypeRSVP,
+}
+
+func ParsePostType(v string) (PostType, error) {
+	if out, ok := stringsPostTypes[strings.ToLower(v)]; ok {
+		return out, nil
+	}
+
+	return PostTypeUnd, fmt.Errorf("cannot parse '%s' as post type: %w", v, ErrPostTypeSyntax)
+}
+
+func (pt PostType) String() string {
+	if pt.postType == "" {
+		return "und"
+	}
+
+	return pt.postType
+}
+
+func (pt PostType) GoString() string {
+	return "domain.PostType(" + pt.String() + ")"
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+// Redirect represent a former URL of moved entry. Redirect without target is
+// a tombstone of permanently removed entry.
+type Redirect struct {
+	CreatedAt time.Time
+	From      *url.URL
+	To        *url.URL
+}
+
+// Gone reports whether redirect is a tombstone.
+func (r Redirect) Gone() bool {
+	return r.To == nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+type (
+	// Revision represent an immutable snapshot of entry stored after each
+	// change of it.
+	Revision struct {
+		CreatedAt time.Time
+		Entry     Entry  // state of entry after change
+		Action    Action // action which produces this revision
+		Author    string // URL of change author, if known
+		Client    string // client which made a change, like User-Agent
+		Number    int    // sequence number of revision, starts from 1
+	}
+
+	// Change represent a difference of a single property between two
+	// revisions of entry.
+	Change struct {
+		Property string
+		Before   []string
+		After    []string
+	}
+)
+
+// TestRevision returns a valid Revision for tests.
+func TestRevision(tb testing.TB) *Revision {
+	tb.Helper()
+
+	return &Revision{
+		CreatedAt: time.Date(2023, time.January, 2, 15, 4, 5, 0, time.UTC),
+		Entry:     *TestEntry(tb),
+		Action:    ActionCreate,
+		Author:    "https://example.com/",
+		Client:    "Quill",
+		Number:    1,
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+// Shortlink represent a compact code of entry permalink.
+type Shortlink struct {
+	CreatedAt time.Time
+	URL       *url.URL // permalink of entry
+	Short     *url.URL // short URL of code on configured domain
+	Code      string
+}
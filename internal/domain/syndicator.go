@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+)
+
+// Syndicator represent a single POSSE target where entries can be syndicated
+// to.
+//
+// See: https://indieweb.org/Micropub-extensions#Syndication_Targets
+type Syndicator struct {
+	UID  *url.URL // unique identifier of target
+	Name string   // human-readable name of target
+}
+
+// TestSyndicator returns a valid Syndicator for tests.
+func TestSyndicator(tb testing.TB) *Syndicator {
+	tb.Helper()
+
+	return &Syndicator{
+		UID:  &url.URL{Scheme: "https", Host: "mastodon.example", Path: "/"},
+		Name: "Mastodon",
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+)
+
+// TestURL returns parsed raw URL for tests, failing test if it is invalid.
+func TestURL(tb testing.TB, raw string) *url.URL {
+	tb.Helper()
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return u
+}
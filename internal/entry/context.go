@@ -0,0 +1,19 @@
+package entry
+
+import "context"
+
+type versionContextKey struct{}
+
+// NewVersionContext returns a copy of ctx which requires changes of entries
+// made within it to be based on provided version, like If-Match precondition
+// does. Changes of entry with another version fails with ErrConflict.
+func NewVersionContext(ctx context.Context, version uint64) context.Context {
+	return context.WithValue(ctx, versionContextKey{}, version)
+}
+
+// VersionFromContext returns expected version of changed entry, if any.
+func VersionFromContext(ctx context.Context) (uint64, bool) {
+	out, ok := ctx.Value(versionContextKey{}).(uint64)
+
+	return out, ok
+}
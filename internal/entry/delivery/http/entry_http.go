@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,16 +16,34 @@ import (
 
 	"golang.org/x/net/html"
 
+	"source.toby3d.me/toby3d/pub/internal/citation"
 	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/contact"
 	"source.toby3d.me/toby3d/pub/internal/domain"
 	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/linkify"
+	"source.toby3d.me/toby3d/pub/internal/markdown"
 	"source.toby3d.me/toby3d/pub/internal/media"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+	"source.toby3d.me/toby3d/pub/internal/sanitize"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
 )
 
 type (
 	Handler struct {
-		entries entry.UseCase
-		media   media.UseCase
+		entries     entry.UseCase
+		media       media.UseCase
+		syndication syndication.UseCase
+		contacts    contact.UseCase
+		search      search.UseCase
+		revisions   revision.UseCase
+		redirects   redirect.UseCase
+		shortlinks  shortlink.UseCase
+		citations   citation.UseCase
+		sanitizer   *sanitize.Policy
 	}
 
 	Request struct {
@@ -34,12 +53,40 @@ type (
 	RequestCreate struct {
 		Properties Properties `json:"properties"`
 		Type       []string   `json:"type"` // h-entry
+		// NOTE(toby3d): form-encoded requests provides it as top-level
+		// key, JSON requests as a property.
+		SyndicateTo []string `json:"mp-syndicate-to,omitempty"`
+		ContentType []string `json:"mp-content-type,omitempty"`
 	}
 
 	RequestSource struct {
 		URL        URL
+		PostType   domain.PostType
 		Q          string
+		After      string
+		Before     string
+		Category   string
 		Properties []string
+		Limit      int
+		Revision   int  // number of stored revision instead of the current state
+		Deleted    bool // list the trash instead of entries
+	}
+
+	RequestRevisions struct {
+		URL URL
+	}
+
+	RequestDiff struct {
+		URL  URL
+		From int
+		To   int
+	}
+
+	RequestSearch struct {
+		Term       string
+		Properties []string
+		Offset     int
+		Limit      int
 	}
 
 	RequestUpdate struct {
@@ -60,11 +107,87 @@ type (
 		Action string `json:"action"`
 	}
 
+	RequestPurge struct {
+		URL    URL    `json:"url"`
+		Action string `json:"action"` // purge
+	}
+
+	RequestRefresh struct {
+		URL    URL    `json:"url"`
+		Action string `json:"action"` // refresh
+	}
+
+	RequestRevert struct {
+		URL      URL    `json:"url"`
+		Action   string `json:"action"` // revert
+		Revision int    `json:"revision"`
+	}
+
 	ResponseSource struct {
 		Properties Properties `json:"properties"`
 		Type       []string   `json:"type,omitempty"`
 	}
 
+	ResponseSourceList struct {
+		Paging *ResponsePaging   `json:"paging,omitempty"`
+		Items  []*ResponseSource `json:"items"`
+	}
+
+	ResponsePaging struct {
+		After  string `json:"after,omitempty"`
+		Before string `json:"before,omitempty"`
+	}
+
+	ResponseSyndicateTo struct {
+		SyndicateTo []ResponseSyndicator `json:"syndicate-to"`
+	}
+
+	ResponseSyndicator struct {
+		UID  string `json:"uid"`
+		Name string `json:"name"`
+	}
+
+	// ResponseCategory lists known tags, the most used first. Counts is an
+	// extension which provides usage of each tag.
+	ResponseCategory struct {
+		Counts     map[string]int `json:"counts"`
+		Categories []string       `json:"categories"`
+	}
+
+	ResponseRevisions struct {
+		Revisions []ResponseRevision `json:"revisions"`
+	}
+
+	ResponseRevision struct {
+		Published DateTime `json:"published"`
+		Action    string   `json:"action"`
+		Author    string   `json:"author,omitempty"`
+		Client    string   `json:"client,omitempty"`
+		Revision  int      `json:"revision"`
+	}
+
+	ResponseDiff struct {
+		Changes []ResponseChange `json:"changes"`
+	}
+
+	ResponseChange struct {
+		Property string   `json:"property"`
+		Before   []string `json:"before,omitempty"`
+		After    []string `json:"after,omitempty"`
+	}
+
+	ResponseContacts struct {
+		Contacts []ResponseContact `json:"contacts"`
+	}
+
+	ResponseContact struct {
+		Silos    map[string]string `json:"silos,omitempty"`
+		Name     string            `json:"name,omitempty"`
+		Nickname string            `json:"nickname"`
+		URL      string            `json:"url,omitempty"`
+		Photo    string            `json:"photo,omitempty"`
+	}
+
 	Properties struct {
 		Audio       []Figure   `json:"audio,omitempty"`
 		Featured    []URL      `json:"featured,omitempty"`
@@ -73,6 +196,7 @@ type (
 		Repost      []URL      `json:"repost,omitempty"`
 		Syndication []URL      `json:"syndication,omitempty"`
 		URL         []URL      `json:"url,omitempty"`
+		Shortlink   []URL      `json:"shortlink,omitempty"` // read-only
 		Video       []Figure   `json:"video,omitempty"`
 		Published   []DateTime `json:"published,omitempty"`
 		Updated     []DateTime `json:"updated,omitempty"`
@@ -88,12 +212,15 @@ type (
 		Longitude   []float32  `json:"longitude,omitempty"`
 		Duration    []uint64   `json:"duration,omitempty"`
 		Size        []uint64   `json:"size,omitempty"`
+		LikeOf      []URL      `json:"like-of,omitempty"`
+		RepostOf    []URL      `json:"repost-of,omitempty"`
+		BookmarkOf  []URL      `json:"bookmark-of,omitempty"`
+		SyndicateTo []string   `json:"mp-syndicate-to,omitempty"`
+		ContentType []string   `json:"mp-content-type,omitempty"`
+		PostStatus  []string   `json:"post-status,omitempty"`
 		// Author        []Author        `json:"author,omitempty"`
 		// Location      []Location      `json:"location,omitempty"`
-		// LikeOf        []LikeOf        `json:"like-of,omitempty"`
-		// RepostOf      []RepostOf      `json:"repost-of,omitempty"`
 		// Comment       []Comment       `json:"comment,omitempty"`
-		// BookmarkOf    []BookmarkOf    `json:"bookmark-of,omitempty"`
 		// ListenOf      []ListenOf      `json:"listen-of,omitempty"`
 		// WatchOf       []WatchOf       `json:"watch-of,omitempty"`
 		// ReadOf        []ReadOf        `json:"read-of,omitempty"`
@@ -113,8 +240,9 @@ type (
 	}
 
 	Content struct {
-		HTML  *html.Node `json:"-"`
-		Value string     `json:"-"`
+		HTML     *html.Node `json:"-"`
+		Value    string     `json:"-"`
+		Markdown string     `json:"-"`
 	}
 
 	URL struct {
@@ -130,7 +258,9 @@ type (
 	}
 
 	bufferHTML struct {
-		HTML string `json:"html,omitempty"`
+		HTML     string `json:"html,omitempty"`
+		Value    string `json:"value,omitempty"`
+		Markdown string `json:"markdown,omitempty"`
 	}
 
 	bufferMedia struct {
@@ -139,12 +269,30 @@ type (
 	}
 )
 
-const MaxBodySize int64 = 100 * 1024 * 1024 // 100mb
+const (
+	MaxBodySize int64 = 100 * 1024 * 1024 // 100mb
+
+	// DefaultLimit is a number of listed entries if client does not
+	// provide it, MaxLimit is the upper bound of provided limit.
+	DefaultLimit int = 20
+	MaxLimit     int = 100
+)
 
-func NewHandler(entries entry.UseCase, media media.UseCase) *Handler {
+func NewHandler(entries entry.UseCase, media media.UseCase, syndication syndication.UseCase,
+	contacts contact.UseCase, search search.UseCase, revisions revision.UseCase, redirects redirect.UseCase,
+	shortlinks shortlink.UseCase, citations citation.UseCase, sanitizer *sanitize.Policy,
+) *Handler {
 	return &Handler{
-		entries: entries,
-		media:   media,
+		entries:     entries,
+		media:       media,
+		syndication: syndication,
+		contacts:    contacts,
+		search:      search,
+		revisions:   revisions,
+		redirects:   redirects,
+		shortlinks:  shortlinks,
+		citations:   citations,
+		sanitizer:   sanitizer,
 	}
 }
 
@@ -153,7 +301,22 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	default:
 		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 	case "", http.MethodGet:
-		h.handleSource(w, r)
+		switch q := r.URL.Query(); {
+		default:
+			h.handleSource(w, r)
+		case strings.EqualFold(q.Get("q"), "syndicate-to"):
+			h.handleSyndicateTo(w, r)
+		case strings.EqualFold(q.Get("q"), "category"):
+			h.handleCategory(w, r)
+		case strings.EqualFold(q.Get("q"), "contact"):
+			h.handleContact(w, r)
+		case strings.EqualFold(q.Get("q"), "search"):
+			h.handleSearch(w, r)
+		case strings.EqualFold(q.Get("q"), "revisions"):
+			h.handleRevisions(w, r)
+		case strings.EqualFold(q.Get("q"), "diff"):
+			h.handleDiff(w, r)
+		}
 	case http.MethodPost:
 		mediaType, _, err := mime.ParseMediaType(r.Header.Get(common.HeaderContentType))
 		if err != nil {
@@ -186,6 +349,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				h.handleDelete(w, r)
 			case domain.ActionUndelete.String():
 				h.handleUndelete(w, r)
+			case domain.ActionRevert.String():
+				h.handleRevert(w, r)
+			case domain.ActionPurge.String():
+				h.handlePurge(w, r)
+			case domain.ActionRefresh.String():
+				h.handleRefresh(w, r)
 			}
 		case common.MIMEApplicationForm:
 			switch strings.ToLower(r.FormValue("action")) {
@@ -195,6 +364,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				h.handleDelete(w, r)
 			case domain.ActionUndelete.String():
 				h.handleUndelete(w, r)
+			case domain.ActionRevert.String():
+				h.handleRevert(w, r)
+			case domain.ActionPurge.String():
+				h.handlePurge(w, r)
+			case domain.ActionRefresh.String():
+				h.handleRefresh(w, r)
 			}
 		case common.MIMEMultipartForm:
 			h.handleCreate(w, r)
@@ -216,6 +391,14 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.linkify(r.Context(), &req.Properties)
+
+	if err := h.sanitize(&req.Properties); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
 	if r.MultipartForm != nil {
 		for k, dst := range map[string]*[]Figure{
 			"photo": &req.Properties.Photo,
@@ -252,9 +435,35 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 			}
 
 			*dst = append(*dst, Figure{Value: location, Alt: ""})
+
+			if k == "photo" {
+				continue
+			}
+
+			// NOTE(toby3d): fill audio/video properties from extracted
+			// media metadata, if client does not provide it.
+			uploaded, err := h.media.Download(r.Context(), location.Path)
+			if err != nil || uploaded == nil {
+				continue
+			}
+
+			if len(req.Properties.Duration) == 0 && uploaded.Duration > 0 {
+				req.Properties.Duration = append(req.Properties.Duration,
+					uint64(uploaded.Duration.Round(time.Second).Seconds()))
+			}
+
+			if len(req.Properties.Size) == 0 {
+				req.Properties.Size = append(req.Properties.Size, uint64(uploaded.Size()))
+			}
 		}
 	}
 
+	if err := h.rehost(r.Context(), &req.Properties); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
 	in := new(domain.Entry)
 	req.populate(in)
 
@@ -265,6 +474,14 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if uids := append(req.SyndicateTo, req.Properties.SyndicateTo...); len(uids) > 0 {
+		// NOTE(toby3d): entry is already created, so failed targets
+		// does not fail the whole request.
+		if syndicated, _ := h.syndication.Syndicate(r.Context(), out.URL, uids...); syndicated != nil {
+			out = syndicated
+		}
+	}
+
 	w.Header().Set(common.HeaderLocation, out.URL.String())
 
 	links := make([]string, 0)
@@ -272,6 +489,11 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		links = append(links, `<`+out.Syndications[i].String()+`>; rel="syndication"`)
 	}
 
+	// NOTE(toby3d): shortlink is usually already generated by hook.
+	if short, err := h.shortlinks.Create(r.Context(), *out); err == nil && short != nil {
+		links = append(links, `<`+short.Short.String()+`>; rel="shortlink"`)
+	}
+
 	w.Header().Set(common.HeaderLink, strings.Join(links, ", "))
 	w.WriteHeader(http.StatusCreated)
 }
@@ -290,257 +512,1024 @@ func (h *Handler) handleSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	out, err := h.entries.Source(r.Context(), req.URL.URL)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if req.URL.URL == nil {
+		h.handleSourceList(w, r, req)
 
 		return
 	}
 
-	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
-	if err = json.NewEncoder(w).Encode(NewResponseSource(out, req.Properties...)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-}
-
-func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	if req.Revision > 0 {
+		h.handleSourceRevision(w, r, req)
 
 		return
 	}
 
-	req := new(RequestUpdate)
-	if err := req.bind(r); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		println(err.Error())
+	out, err := h.source(r.Context(), req.URL.URL)
+	if err != nil {
+		switch {
+		case errors.Is(err, redirect.ErrGone):
+			http.Error(w, err.Error(), http.StatusGone)
+		case errors.Is(err, entry.ErrNotExist):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 
 		return
 	}
 
-	in, err := h.entries.Source(r.Context(), req.URL.URL)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	resp := NewResponseSource(out, req.Properties...)
 
-		return
+	if short, err := h.shortlinks.Source(r.Context(), out.URL); err == nil && short != nil {
+		w.Header().Add(common.HeaderLink, `<`+short.Short.String()+`>; rel="shortlink"`)
+
+		if requested(req.Properties, "shortlink") {
+			resp.Properties.Shortlink = append(resp.Properties.Shortlink, URL{URL: short.Short})
+		}
 	}
 
-	req.populate(in)
+	w.Header().Set(common.HeaderETag, etag(out))
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
 
-	out, err := h.entries.Update(r.Context(), req.URL.URL, entry.UpdateOptions{
-		// TODO(toby3d)
-		// Add:     req.Add,
-		// Replace: req.Replace,
-		// Delete:  req.Delete,
+func (h *Handler) handleSourceList(w http.ResponseWriter, r *http.Request, req *RequestSource) {
+	page, err := h.entries.Fetch(r.Context(), entry.Query{
+		Type:        req.PostType,
+		Tag:         req.Category,
+		After:       req.After,
+		Before:      req.Before,
+		Limit:       req.Limit,
+		SkipDeleted: !req.Deleted,
+		Deleted:     req.Deleted,
 	})
 	if err != nil {
+		if errors.Is(err, entry.ErrCursorSyntax) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
 		return
 	}
 
-	if out.URL.RequestURI() == req.URL.RequestURI() {
-		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
-		if err = json.NewEncoder(w).Encode(NewResponseSource(out)); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		} else {
-			w.WriteHeader(http.StatusOK)
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseSourceList(page, req.Properties...)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) handleSourceRevision(w http.ResponseWriter, r *http.Request, req *RequestSource) {
+	out, err := h.revisions.Source(r.Context(), req.URL.URL, req.Revision)
+	if err != nil {
+		if errors.Is(err, revision.ErrNotExist) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
 		}
 
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
 		return
 	}
 
-	w.Header().Set(common.HeaderLocation, out.URL.String())
-	w.WriteHeader(http.StatusCreated)
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseSource(&out.Entry, req.Properties...)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
-func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+func (h *Handler) handleRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
 		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	req := new(RequestDelete)
+	req := new(RequestRevisions)
 	if err := req.bind(r); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
-	if _, err := h.entries.Delete(r.Context(), req.URL.URL); err != nil {
+	out, err := h.revisions.Fetch(r.Context(), req.URL.URL)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
 		return
 	}
 
-	w.Header().Set(common.HeaderContentType, common.MIMETextPlainCharsetUTF8)
-	w.WriteHeader(http.StatusNoContent)
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseRevisions(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
-func (h *Handler) handleUndelete(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+func (h *Handler) handleDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
 		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	req := new(RequestUndelete)
+	req := new(RequestDiff)
 	if err := req.bind(r); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
-	out, err := h.entries.Undelete(r.Context(), req.URL.URL)
+	out, err := h.revisions.Diff(r.Context(), req.URL.URL, req.From, req.To)
 	if err != nil {
+		if errors.Is(err, revision.ErrNotExist) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
 		return
 	}
 
-	if out.URL.RequestURI() == req.URL.RequestURI() {
-		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
-		if err = json.NewEncoder(w).Encode(NewResponseSource(out)); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		} else {
-			w.WriteHeader(http.StatusOK)
-		}
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseDiff(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) handleSyndicateTo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	w.Header().Set(common.HeaderLocation, out.URL.String())
-	w.WriteHeader(http.StatusCreated)
-}
+	out, err := h.syndication.Targets(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 
-func NewRequestCreate() *RequestCreate {
-	return &RequestCreate{
-		Type:       make([]string, 0),
-		Properties: Properties{},
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseSyndicateTo(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
-func (r *RequestCreate) bind(req *http.Request) error {
-	mediaType, _, err := mime.ParseMediaType(req.Header.Get(common.HeaderContentType))
-	if err != nil {
-		return fmt.Errorf("cannot understand requested Content-Type: %w", err)
+func (h *Handler) handleCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
 	}
 
-	switch mediaType {
-	default:
-		return fmt.Errorf("unsupported media type, got '%s', want '%s', '%s' or '%s'", mediaType,
-			common.MIMEApplicationJSON, common.MIMEMultipartForm, common.MIMEApplicationForm)
-	case common.MIMEApplicationJSON:
-		err = json.NewDecoder(req.Body).Decode(r)
-	case common.MIMEMultipartForm, common.MIMEApplicationForm:
-		in := make(map[string][]string)
+	out, err := h.entries.Categories(r.Context(), r.URL.Query().Get("filter"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 
-		switch mediaType {
-		case common.MIMEMultipartForm:
-			if err = req.ParseMultipartForm(MaxBodySize); err != nil {
-				return fmt.Errorf("cannot parse creation multipart body: %w", err)
-			}
+		return
+	}
 
-			in = req.MultipartForm.Value
-		case common.MIMEApplicationForm:
-			if err = req.ParseForm(); err != nil {
-				return fmt.Errorf("cannot parse creation form body: %w", err)
-			}
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseCategory(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
 
-			in = req.Form
-		}
+func (h *Handler) handleContact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 
-		r.Type = append(r.Type, in["h"]...)
+		return
+	}
 
-		for k, v := range in {
-			switch {
-			case strings.HasSuffix(k, "[]"):
-				in[strings.TrimSuffix(k, "[]")] = v
+	// NOTE(toby3d): some clients send the same 'filter' as for categories
+	// instead of 'search'.
+	search := r.URL.Query().Get("search")
+	if search == "" {
+		search = r.URL.Query().Get("filter")
+	}
 
-				fallthrough
-			case strings.HasPrefix(k, "mp-"), k == "h":
-				delete(in, k)
-			}
-		}
+	out, err := h.contacts.Fetch(r.Context(), search)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 
-		// NOTE(toby3d): hack to encode URL values
-		var src []byte
-		if src, err = json.Marshal(in); err != nil {
-			return fmt.Errorf("cannot marshal creation values for decoding: %w", err)
-		}
+		return
+	}
 
-		err = json.Unmarshal(src, &r.Properties)
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseContacts(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
+}
 
-	if err != nil {
-		return fmt.Errorf("cannot decode creation request body: %w", err)
+func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
 	}
 
-	for i := range r.Type {
-		r.Type[i] = strings.TrimPrefix(r.Type[i], "h-")
+	req := new(RequestSearch)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
 	}
 
-	return nil
-}
+	result, err := h.search.Search(r.Context(), search.Query{
+		Term:   req.Term,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 
-func (r *RequestCreate) populate(dst *domain.Entry) {
-	if len(r.Properties.Content) > 0 {
-		dst.Content.HTML = r.Properties.Content[0].HTML
-		dst.Content.Text = r.Properties.Content[0].Value
+		return
 	}
 
-	if len(r.Properties.Summary) > 0 {
-		dst.Description = r.Properties.Summary[0]
+	page := &entry.Page{Entries: result.Entries}
+	if next := req.Offset + len(result.Entries); next < result.Total {
+		page.Next = strconv.Itoa(next)
 	}
 
-	if len(r.Properties.Published) > 0 {
-		dst.PublishedAt = r.Properties.Published[0].Time
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseSourceList(page, req.Properties...)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestUpdate)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		println(err.Error())
+
+		return
+	}
+
+	h.linkify(r.Context(), req.Add, req.Replace)
+
+	if err := h.sanitize(req.Add, req.Replace); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.rehost(r.Context(), req.Add, req.Replace); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	header := r.Header.Get(common.HeaderIfMatch)
+
+	var out *domain.Entry
+
+	for {
+		in, err := h.entries.Source(r.Context(), req.URL.URL)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if !ifMatch(header, in) {
+			http.Error(w, entry.ErrConflict.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		req.populate(in)
+
+		// NOTE(toby3d): in is already a stored entry with applied
+		// changes, so it must not overwrite changes made after it was
+		// requested. Clients without If-Match does not care about
+		// versions, so changes are applied again on the fresh state:
+		// each conflict means that someone else has succeeded.
+		out, err = h.entries.Update(entry.NewVersionContext(r.Context(), in.Version), req.URL.URL,
+			entry.UpdateOptions{Replace: in})
+		if err == nil {
+			break
+		}
+
+		if errors.Is(err, entry.ErrConflict) && header == "" && r.Context().Err() == nil {
+			continue
+		}
+
+		if errors.Is(err, entry.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderETag, etag(out))
+
+	if out.URL.RequestURI() == req.URL.RequestURI() {
+		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+		if err := json.NewEncoder(w).Encode(NewResponseSource(out)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		return
+	}
+
+	w.Header().Set(common.HeaderLocation, out.URL.String())
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestDelete)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx, err := h.precondition(r, req.URL.URL)
+	if err != nil {
+		if errors.Is(err, entry.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if _, err = h.entries.Delete(ctx, req.URL.URL); err != nil {
+		if errors.Is(err, entry.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextPlainCharsetUTF8)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleUndelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestUndelete)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx, err := h.precondition(r, req.URL.URL)
+	if err != nil {
+		if errors.Is(err, entry.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	out, err := h.entries.Undelete(ctx, req.URL.URL)
+	if err != nil {
+		if errors.Is(err, entry.ErrConflict) {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderETag, etag(out))
+
+	if out.URL.RequestURI() == req.URL.RequestURI() {
+		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+		if err = json.NewEncoder(w).Encode(NewResponseSource(out)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		return
+	}
+
+	w.Header().Set(common.HeaderLocation, out.URL.String())
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) handlePurge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestPurge)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx, err := h.precondition(r, req.URL.URL)
+	if err == nil {
+		err = h.entries.Purge(ctx, req.URL.URL)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, entry.ErrConflict):
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		case errors.Is(err, entry.ErrNotDeleted):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, entry.ErrNotExist):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextPlainCharsetUTF8)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestRefresh)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if _, err := h.citations.Refresh(r.Context(), req.URL.URL); err != nil {
+		if errors.Is(err, entry.ErrNotExist) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextPlainCharsetUTF8)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleRevert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestRevert)
+	if err := req.bind(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	out, err := h.revisions.Revert(r.Context(), req.URL.URL, req.Revision)
+	if err != nil {
+		if errors.Is(err, revision.ErrNotExist) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	if err = json.NewEncoder(w).Encode(NewResponseSource(out)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// source returns entry on provided URL, or entry which was moved from it.
+func (h *Handler) source(ctx context.Context, u *url.URL) (*domain.Entry, error) {
+	out, err := h.entries.Source(ctx, u)
+	if err == nil || !errors.Is(err, entry.ErrNotExist) {
+		return out, err
+	}
+
+	target, rerr := h.redirects.Resolve(ctx, u)
+	if rerr != nil {
+		if errors.Is(rerr, redirect.ErrGone) {
+			return nil, rerr
+		}
+
+		return nil, err
+	}
+
+	return h.entries.Source(ctx, target)
+}
+
+// precondition returns request context which requires changes of entry on u
+// to be based on version provided by If-Match header, if any. Returns
+// entry.ErrConflict if header does not match the current entry.
+func (h *Handler) precondition(r *http.Request, u *url.URL) (context.Context, error) {
+	header := r.Header.Get(common.HeaderIfMatch)
+	if header == "" {
+		return r.Context(), nil
+	}
+
+	current, err := h.entries.Source(r.Context(), u)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check precondition: %w", err)
+	}
+
+	if !ifMatch(header, current) {
+		return nil, entry.ErrConflict
+	}
+
+	return entry.NewVersionContext(r.Context(), current.Version), nil
+}
+
+// etag returns a strong entity tag of entry state.
+func etag(e *domain.Entry) string {
+	return `"` + strconv.FormatUint(e.Version, 10) + `"`
+}
+
+// ifMatch reports whether If-Match header matches the current entry. Empty
+// header matches any state, weak tags never matches.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9110#section-13.1.1
+func ifMatch(header string, e *domain.Entry) bool {
+	if header = strings.TrimSpace(header); header == "" || header == "*" {
+		return true
+	}
+
+	tag := etag(e)
+
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimSpace(candidate) == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// linkify converts plain text contents of provided properties into HTML with
+// linked URLs, hashtags and mentions of contacts. Hashtags are added into
+// categories, original text is kept.
+func (h *Handler) linkify(ctx context.Context, properties ...*Properties) {
+	for _, p := range properties {
+		if p == nil {
+			continue
+		}
+
+		for i := range p.Content {
+			if p.Content[i].HTML != nil || p.Content[i].Value == "" {
+				continue
+			}
+
+			result := linkify.Linkify(ctx, p.Content[i].Value, h.contacts)
+
+			node, err := html.Parse(strings.NewReader(result.HTML))
+			if err != nil {
+				continue
+			}
+
+			p.Content[i].HTML = node
+
+		tags:
+			for _, tag := range result.Tags {
+				for _, category := range p.Category {
+					if strings.EqualFold(category, tag) {
+						continue tags
+					}
+				}
+
+				p.Category = append(p.Category, tag)
+			}
+		}
+	}
+}
+
+// sanitize cleans HTML contents of provided properties by sanitizer policy.
+func (h *Handler) sanitize(properties ...*Properties) error {
+	for _, p := range properties {
+		if p == nil {
+			continue
+		}
+
+		for i := range p.Content {
+			if p.Content[i].HTML == nil {
+				continue
+			}
+
+			if err := h.sanitizer.Sanitize(p.Content[i].HTML); err != nil {
+				return fmt.Errorf("cannot sanitize content: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rehost replaces remote media URLs of provided properties by local copies.
+func (h *Handler) rehost(ctx context.Context, properties ...*Properties) error {
+	for _, p := range properties {
+		if p == nil {
+			continue
+		}
+
+		for _, figures := range [][]Figure{p.Photo, p.Video, p.Audio} {
+			for i := range figures {
+				if figures[i].Value == nil || !figures[i].Value.IsAbs() {
+					continue
+				}
+
+				location, err := h.media.Rehost(ctx, figures[i].Value)
+				if err != nil {
+					return fmt.Errorf("cannot rehost media: %w", err)
+				}
+
+				figures[i].Value = location
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewRequestCreate() *RequestCreate {
+	return &RequestCreate{
+		Type:       make([]string, 0),
+		Properties: Properties{},
+	}
+}
+
+func (r *RequestCreate) bind(req *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(common.HeaderContentType))
+	if err != nil {
+		return fmt.Errorf("cannot understand requested Content-Type: %w", err)
+	}
+
+	switch mediaType {
+	default:
+		return fmt.Errorf("unsupported media type, got '%s', want '%s', '%s' or '%s'", mediaType,
+			common.MIMEApplicationJSON, common.MIMEMultipartForm, common.MIMEApplicationForm)
+	case common.MIMEApplicationJSON:
+		err = json.NewDecoder(req.Body).Decode(r)
+	case common.MIMEMultipartForm, common.MIMEApplicationForm:
+		in := make(map[string][]string)
+
+		switch mediaType {
+		case common.MIMEMultipartForm:
+			if err = req.ParseMultipartForm(MaxBodySize); err != nil {
+				return fmt.Errorf("cannot parse creation multipart body: %w", err)
+			}
+
+			in = req.MultipartForm.Value
+		case common.MIMEApplicationForm:
+			if err = req.ParseForm(); err != nil {
+				return fmt.Errorf("cannot parse creation form body: %w", err)
+			}
+
+			in = req.Form
+		}
+
+		r.Type = append(r.Type, in["h"]...)
+
+		for k, v := range in {
+			switch strings.TrimSuffix(k, "[]") {
+			case "mp-syndicate-to":
+				r.SyndicateTo = append(r.SyndicateTo, v...)
+			case "mp-content-type":
+				r.ContentType = append(r.ContentType, v...)
+			}
+
+			switch {
+			case strings.HasSuffix(k, "[]"):
+				in[strings.TrimSuffix(k, "[]")] = v
+
+				fallthrough
+			case strings.HasPrefix(k, "mp-"), k == "h":
+				delete(in, k)
+			}
+		}
+
+		// NOTE(toby3d): hack to encode URL values
+		var src []byte
+		if src, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("cannot marshal creation values for decoding: %w", err)
+		}
+
+		err = json.Unmarshal(src, &r.Properties)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot decode creation request body: %w", err)
+	}
+
+	for i := range r.Type {
+		r.Type[i] = strings.TrimPrefix(r.Type[i], "h-")
+	}
+
+	r.Properties.renderMarkdown(append(r.ContentType, r.Properties.ContentType...)...)
+
+	return nil
+}
+
+func (r *RequestCreate) populate(dst *domain.Entry) {
+	if len(r.Properties.Content) > 0 {
+		dst.Content.HTML = r.Properties.Content[0].HTML
+		dst.Content.Text = r.Properties.Content[0].Value
+		dst.Content.Markdown = r.Properties.Content[0].Markdown
+	}
+
+	if len(r.Properties.Summary) > 0 {
+		dst.Description = r.Properties.Summary[0]
+	}
+
+	if len(r.Properties.Published) > 0 {
+		dst.PublishedAt = r.Properties.Published[0].Time
+	}
+
+	if len(r.Properties.Name) > 0 {
+		dst.Title = r.Properties.Name[0]
+	}
+
+	if len(r.Properties.Updated) > 0 {
+		dst.UpdatedAt = r.Properties.Updated[0].Time
+	}
+
+	if len(r.Properties.URL) > 0 {
+		dst.URL = r.Properties.URL[0].URL
+	}
+
+	dst.Tags = append(dst.Tags, r.Properties.Category...)
+
+	for i := range r.Properties.Photo {
+		dst.Photo = append(dst.Photo, r.Properties.Photo[i].Value)
+	}
+
+	for i := range r.Properties.Video {
+		dst.Video = append(dst.Video, r.Properties.Video[i].Value)
+	}
+
+	for i := range r.Properties.Audio {
+		dst.Audio = append(dst.Audio, r.Properties.Audio[i].Value)
+	}
+
+	for _, link := range []struct {
+		dst *[]*url.URL
+		src []URL
+	}{
+		{dst: &dst.InReplyTo, src: r.Properties.InReplyTo},
+		{dst: &dst.LikeOf, src: r.Properties.LikeOf},
+		{dst: &dst.RepostOf, src: r.Properties.RepostOf},
+		{dst: &dst.BookmarkOf, src: r.Properties.BookmarkOf},
+	} {
+		for i := range link.src {
+			*link.dst = append(*link.dst, link.src[i].URL)
+		}
+	}
+
+	if len(r.Properties.Duration) > 0 {
+		dst.Duration = time.Duration(r.Properties.Duration[0]) * time.Second
+	}
+
+	if len(r.Properties.Size) > 0 {
+		dst.Size = r.Properties.Size[0]
+	}
+
+	for i := range r.Properties.Syndication {
+		dst.Syndications = append(dst.Syndications, r.Properties.Syndication[i].URL)
+	}
+
+	if len(r.Properties.PostStatus) > 0 {
+		if status, err := domain.ParsePostStatus(r.Properties.PostStatus[0]); err == nil {
+			dst.Status = status
+		}
+	}
+}
+
+func (r *RequestSource) bind(req *http.Request) error {
+	query := req.URL.Query()
+	if r.Q = query.Get("q"); !strings.EqualFold(r.Q, "source") {
+		return fmt.Errorf("'q' query MUST be 'source', got '%s'", r.Q)
+	}
+
+	var err error
+
+	// NOTE(toby3d): source query without url lists entries.
+	if query.Has("url") {
+		if r.URL.URL, err = url.Parse(query.Get("url")); err != nil {
+			return fmt.Errorf("cannot unmarshal 'url' query: %w", err)
+		}
+	}
+
+	r.Properties = bindProperties(query)
+	if r.Limit, err = bindLimit(query); err != nil {
+		return err
+	}
+
+	if query.Has("post-type") {
+		if r.PostType, err = domain.ParsePostType(query.Get("post-type")); err != nil {
+			return fmt.Errorf("cannot unmarshal 'post-type' query: %w", err)
+		}
+	}
+
+	r.After = query.Get("after")
+	r.Before = query.Get("before")
+	r.Category = query.Get("category")
+
+	if query.Has("revision") {
+		if r.Revision, err = bindRevision(query, "revision"); err != nil {
+			return err
+		}
+	}
+
+	if query.Has("deleted") {
+		if r.Deleted, err = strconv.ParseBool(query.Get("deleted")); err != nil {
+			return fmt.Errorf("'deleted' query MUST be a boolean, got '%s'", query.Get("deleted"))
+		}
+	}
+
+	return nil
+}
+
+func (r *RequestRevisions) bind(req *http.Request) error {
+	var err error
+	if r.URL.URL, err = bindURL(req.URL.Query()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RequestDiff) bind(req *http.Request) error {
+	query := req.URL.Query()
+
+	var err error
+	if r.URL.URL, err = bindURL(query); err != nil {
+		return err
+	}
+
+	if r.From, err = bindRevision(query, "from"); err != nil {
+		return err
+	}
+
+	if r.To, err = bindRevision(query, "to"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requested reports whether property is requested by properties filter. Empty
+// filter requests all properties.
+func requested(properties []string, property string) bool {
+	if len(properties) == 0 {
+		return true
+	}
+
+	for i := range properties {
+		if properties[i] == property {
+			return true
+		}
 	}
 
-	if len(r.Properties.Name) > 0 {
-		dst.Title = r.Properties.Name[0]
-	}
+	return false
+}
 
-	if len(r.Properties.Updated) > 0 {
-		dst.UpdatedAt = r.Properties.Updated[0].Time
+// bindURL returns required URL of entry from 'url' query.
+func bindURL(query url.Values) (*url.URL, error) {
+	if query.Get("url") == "" {
+		return nil, errors.New("'url' query MUST be provided")
 	}
 
-	if len(r.Properties.URL) > 0 {
-		dst.URL = r.Properties.URL[0].URL
+	out, err := url.Parse(query.Get("url"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal 'url' query: %w", err)
 	}
 
-	dst.Tags = append(dst.Tags, r.Properties.Category...)
+	return out, nil
+}
 
-	for i := range r.Properties.Photo {
-		dst.Photo = append(dst.Photo, r.Properties.Photo[i].Value)
+// bindRevision returns revision number from provided query key.
+func bindRevision(query url.Values, key string) (int, error) {
+	out, err := strconv.Atoi(query.Get(key))
+	if err != nil || out < 1 {
+		return 0, fmt.Errorf("'%s' query MUST be a revision number, got '%s'", key, query.Get(key))
 	}
 
-	for i := range r.Properties.Syndication {
-		dst.Syndications = append(dst.Syndications, r.Properties.Syndication[i].URL)
-	}
+	return out, nil
 }
 
-func (r *RequestSource) bind(req *http.Request) error {
+func (r *RequestSearch) bind(req *http.Request) error {
 	query := req.URL.Query()
-	if r.Q = query.Get("q"); !strings.EqualFold(r.Q, "source") {
-		return fmt.Errorf("'q' query MUST be 'source', got '%s'", r.Q)
+	if r.Term = strings.TrimSpace(query.Get("q-term")); r.Term == "" {
+		return errors.New("'q-term' query MUST NOT be empty")
 	}
 
 	var err error
-	if r.URL.URL, err = url.Parse(query.Get("url")); err != nil {
-		return fmt.Errorf("cannot unmarshal 'url' query: %w", err)
+
+	r.Properties = bindProperties(query)
+	if r.Limit, err = bindLimit(query); err != nil {
+		return err
+	}
+
+	// NOTE(toby3d): search results are ranked, so cursor is just an
+	// offset of the next page.
+	if query.Has("after") {
+		if r.Offset, err = strconv.Atoi(query.Get("after")); err != nil || r.Offset < 0 {
+			return fmt.Errorf("'after' query MUST be a cursor of search results, got '%s'", query.Get("after"))
+		}
 	}
 
+	return nil
+}
+
+// bindProperties returns requested properties of listed entries.
+func bindProperties(query url.Values) []string {
+	out := make([]string, 0)
+
 	for k, v := range query {
 		if k != "properties" && k != "properties[]" {
 			continue
 		}
 
-		r.Properties = append(r.Properties, v...)
+		out = append(out, v...)
 	}
 
-	return nil
+	return out
+}
+
+// bindLimit returns requested number of listed entries, DefaultLimit if it is
+// not provided and MaxLimit at most.
+func bindLimit(query url.Values) (int, error) {
+	if !query.Has("limit") {
+		return DefaultLimit, nil
+	}
+
+	out, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || out < 1 {
+		return 0, fmt.Errorf("'limit' query MUST be a positive number, got '%s'", query.Get("limit"))
+	}
+
+	if out > MaxLimit {
+		out = MaxLimit
+	}
+
+	return out, nil
 }
 
 func (r *RequestUpdate) bind(req *http.Request) error {
@@ -552,6 +1541,12 @@ func (r *RequestUpdate) bind(req *http.Request) error {
 		return fmt.Errorf("invalid action, got '%s', want '%s'", r.Action, "update")
 	}
 
+	for _, p := range []*Properties{r.Add, r.Replace} {
+		if p != nil {
+			p.renderMarkdown(p.ContentType...)
+		}
+	}
+
 	return nil
 }
 
@@ -565,6 +1560,14 @@ func (r RequestUpdate) populate(dst *domain.Entry) {
 			dst.Photo = make([]*url.URL, 0)
 		}
 
+		if len(r.Replace.Video) > 0 {
+			dst.Video = make([]*url.URL, 0)
+		}
+
+		if len(r.Replace.Audio) > 0 {
+			dst.Audio = make([]*url.URL, 0)
+		}
+
 		if len(r.Replace.Category) > 0 {
 			dst.Tags = make([]string, 0)
 		}
@@ -573,6 +1576,22 @@ func (r RequestUpdate) populate(dst *domain.Entry) {
 			dst.Syndications = make([]*url.URL, 0)
 		}
 
+		if len(r.Replace.InReplyTo) > 0 {
+			dst.InReplyTo = make([]*url.URL, 0)
+		}
+
+		if len(r.Replace.LikeOf) > 0 {
+			dst.LikeOf = make([]*url.URL, 0)
+		}
+
+		if len(r.Replace.RepostOf) > 0 {
+			dst.RepostOf = make([]*url.URL, 0)
+		}
+
+		if len(r.Replace.BookmarkOf) > 0 {
+			dst.BookmarkOf = make([]*url.URL, 0)
+		}
+
 		r.Replace.CopyTo(dst)
 	}
 
@@ -649,6 +1668,216 @@ func (r *RequestUndelete) bind(req *http.Request) error {
 	return nil
 }
 
+func (r *RequestPurge) bind(req *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(common.HeaderContentType))
+	if err != nil {
+		return fmt.Errorf("cannot understand requested Content-Type: %w", err)
+	}
+
+	switch mediaType {
+	default:
+		return fmt.Errorf("unsupported media type, got '%s', want '%s' or '%s'", mediaType,
+			common.MIMEApplicationJSON, common.MIMEApplicationForm)
+	case common.MIMEApplicationJSON:
+		if err = json.NewDecoder(req.Body).Decode(r); err != nil {
+			return fmt.Errorf("cannot decode JSON body: %w", err)
+		}
+	case common.MIMEApplicationForm:
+		if err = req.ParseForm(); err != nil {
+			return fmt.Errorf("cannot parse form body: %w", err)
+		}
+
+		r.Action = req.PostFormValue("action")
+		if r.URL.URL, err = url.Parse(req.PostFormValue("url")); err != nil {
+			return fmt.Errorf("cannot parse url query: %w", err)
+		}
+	}
+
+	if !strings.EqualFold(r.Action, "purge") {
+		return fmt.Errorf("invalid action, got '%s', want '%s'", r.Action, "purge")
+	}
+
+	if r.URL.URL == nil {
+		return errors.New("purge request MUST contain url")
+	}
+
+	return nil
+}
+
+func (r *RequestRefresh) bind(req *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(common.HeaderContentType))
+	if err != nil {
+		return fmt.Errorf("cannot understand requested Content-Type: %w", err)
+	}
+
+	switch mediaType {
+	default:
+		return fmt.Errorf("unsupported media type, got '%s', want '%s' or '%s'", mediaType,
+			common.MIMEApplicationJSON, common.MIMEApplicationForm)
+	case common.MIMEApplicationJSON:
+		if err = json.NewDecoder(req.Body).Decode(r); err != nil {
+			return fmt.Errorf("cannot decode JSON body: %w", err)
+		}
+	case common.MIMEApplicationForm:
+		if err = req.ParseForm(); err != nil {
+			return fmt.Errorf("cannot parse form body: %w", err)
+		}
+
+		r.Action = req.PostFormValue("action")
+		if r.URL.URL, err = url.Parse(req.PostFormValue("url")); err != nil {
+			return fmt.Errorf("cannot parse url query: %w", err)
+		}
+	}
+
+	if !strings.EqualFold(r.Action, "refresh") {
+		return fmt.Errorf("invalid action, got '%s', want '%s'", r.Action, "refresh")
+	}
+
+	if r.URL.URL == nil {
+		return errors.New("refresh request MUST contain url")
+	}
+
+	return nil
+}
+
+func (r *RequestRevert) bind(req *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(common.HeaderContentType))
+	if err != nil {
+		return fmt.Errorf("cannot understand requested Content-Type: %w", err)
+	}
+
+	switch mediaType {
+	default:
+		return fmt.Errorf("unsupported media type, got '%s', want '%s' or '%s'", mediaType,
+			common.MIMEApplicationJSON, common.MIMEApplicationForm)
+	case common.MIMEApplicationJSON:
+		if err = json.NewDecoder(req.Body).Decode(r); err != nil {
+			return fmt.Errorf("cannot decode JSON body: %w", err)
+		}
+	case common.MIMEApplicationForm:
+		if err = req.ParseForm(); err != nil {
+			return fmt.Errorf("cannot parse form body: %w", err)
+		}
+
+		r.Action = req.PostFormValue("action")
+		if r.URL.URL, err = url.Parse(req.PostFormValue("url")); err != nil {
+			return fmt.Errorf("cannot parse url query: %w", err)
+		}
+
+		if r.Revision, err = strconv.Atoi(req.PostFormValue("revision")); err != nil {
+			return fmt.Errorf("cannot parse revision number: %w", err)
+		}
+	}
+
+	if !strings.EqualFold(r.Action, "revert") {
+		return fmt.Errorf("invalid action, got '%s', want '%s'", r.Action, "revert")
+	}
+
+	if r.URL.URL == nil || r.Revision < 1 {
+		return errors.New("revert request MUST contain url and revision number")
+	}
+
+	return nil
+}
+
+func NewResponseRevisions(src []domain.Revision) *ResponseRevisions {
+	out := &ResponseRevisions{Revisions: make([]ResponseRevision, 0, len(src))}
+
+	for i := range src {
+		out.Revisions = append(out.Revisions, ResponseRevision{
+			Published: DateTime{Time: src[i].CreatedAt},
+			Action:    src[i].Action.String(),
+			Author:    src[i].Author,
+			Client:    src[i].Client,
+			Revision:  src[i].Number,
+		})
+	}
+
+	return out
+}
+
+func NewResponseDiff(src []domain.Change) *ResponseDiff {
+	out := &ResponseDiff{Changes: make([]ResponseChange, 0, len(src))}
+
+	for i := range src {
+		out.Changes = append(out.Changes, ResponseChange{
+			Property: src[i].Property,
+			Before:   src[i].Before,
+			After:    src[i].After,
+		})
+	}
+
+	return out
+}
+
+func NewResponseSyndicateTo(src []domain.Syndicator) *ResponseSyndicateTo {
+	out := &ResponseSyndicateTo{SyndicateTo: make([]ResponseSyndicator, 0, len(src))}
+
+	for i := range src {
+		out.SyndicateTo = append(out.SyndicateTo, ResponseSyndicator{
+			UID:  src[i].UID.String(),
+			Name: src[i].Name,
+		})
+	}
+
+	return out
+}
+
+func NewResponseCategory(src []entry.Category) *ResponseCategory {
+	out := &ResponseCategory{
+		Counts:     make(map[string]int, len(src)),
+		Categories: make([]string, 0, len(src)),
+	}
+
+	for i := range src {
+		out.Categories = append(out.Categories, src[i].Name)
+		out.Counts[src[i].Name] = src[i].Count
+	}
+
+	return out
+}
+
+func NewResponseContacts(src []domain.Contact) *ResponseContacts {
+	out := &ResponseContacts{Contacts: make([]ResponseContact, 0, len(src))}
+
+	for i := range src {
+		c := ResponseContact{
+			Silos:    src[i].Silos,
+			Name:     src[i].Name,
+			Nickname: src[i].Nickname,
+		}
+
+		if src[i].URL != nil {
+			c.URL = src[i].URL.String()
+		}
+
+		if src[i].Photo != nil {
+			c.Photo = src[i].Photo.String()
+		}
+
+		out.Contacts = append(out.Contacts, c)
+	}
+
+	return out
+}
+
+func NewResponseSourceList(src *entry.Page, properties ...string) *ResponseSourceList {
+	out := &ResponseSourceList{Items: make([]*ResponseSource, 0, len(src.Entries))}
+
+	for i := range src.Entries {
+		out.Items = append(out.Items, NewResponseSource(&src.Entries[i], properties...))
+	}
+
+	if src.Next != "" || src.Prev != "" {
+		out.Paging = &ResponsePaging{
+			After:  src.Next,
+			Before: src.Prev,
+		}
+	}
+
+	return out
+}
+
 func NewResponseSource(src *domain.Entry, properties ...string) *ResponseSource {
 	out := &ResponseSource{
 		Type: make([]string, 0),
@@ -656,18 +1885,30 @@ func NewResponseSource(src *domain.Entry, properties ...string) *ResponseSource
 			Updated:     make([]DateTime, 0),
 			Published:   make([]DateTime, 0),
 			Photo:       make([]Figure, 0),
+			Video:       make([]Figure, 0),
+			Audio:       make([]Figure, 0),
 			Syndication: make([]URL, 0),
 			Content:     make([]Content, 0),
 			Category:    make([]string, 0),
 			Name:        make([]string, 0),
 			Summary:     make([]string, 0),
+			Duration:    make([]uint64, 0),
+			Size:        make([]uint64, 0),
+			InReplyTo:   make([]URL, 0),
+			LikeOf:      make([]URL, 0),
+			RepostOf:    make([]URL, 0),
+			BookmarkOf:  make([]URL, 0),
+			PostStatus:  make([]string, 0),
+			URL:         make([]URL, 0),
 		},
 	}
 
 	if len(properties) == 0 {
 		out.Type = append(out.Type, "h-entry")
 		properties = []string{
-			"updated", "published", "photo", "syndication", "content", "category", "name", "summary",
+			"updated", "published", "photo", "video", "audio", "syndication", "content", "category", "name",
+			"summary", "duration", "size", "in-reply-to", "like-of", "repost-of", "bookmark-of",
+			"post-status", "url",
 		}
 	}
 
@@ -697,31 +1938,89 @@ func NewResponseSource(src *domain.Entry, properties ...string) *ResponseSource
 					Value: src.Photo[j],
 				})
 			}
+		case "video":
+			for j := range src.Video {
+				out.Properties.Video = append(out.Properties.Video, Figure{
+					Value: src.Video[j],
+				})
+			}
+		case "audio":
+			for j := range src.Audio {
+				out.Properties.Audio = append(out.Properties.Audio, Figure{
+					Value: src.Audio[j],
+				})
+			}
+		case "duration":
+			if src.Duration <= 0 {
+				continue
+			}
+
+			out.Properties.Duration = append(out.Properties.Duration,
+				uint64(src.Duration.Round(time.Second).Seconds()))
+		case "size":
+			if src.Size == 0 {
+				continue
+			}
+
+			out.Properties.Size = append(out.Properties.Size, src.Size)
 		case "syndication":
 			for j := range src.Syndications {
 				out.Properties.Syndication = append(out.Properties.Syndication, URL{
 					URL: src.Syndications[j],
 				})
 			}
+		case "in-reply-to":
+			for j := range src.InReplyTo {
+				out.Properties.InReplyTo = append(out.Properties.InReplyTo, URL{URL: src.InReplyTo[j]})
+			}
+		case "like-of":
+			for j := range src.LikeOf {
+				out.Properties.LikeOf = append(out.Properties.LikeOf, URL{URL: src.LikeOf[j]})
+			}
+		case "repost-of":
+			for j := range src.RepostOf {
+				out.Properties.RepostOf = append(out.Properties.RepostOf, URL{URL: src.RepostOf[j]})
+			}
+		case "bookmark-of":
+			for j := range src.BookmarkOf {
+				out.Properties.BookmarkOf = append(out.Properties.BookmarkOf, URL{URL: src.BookmarkOf[j]})
+			}
 		case "content":
 			if src.Content.Text == "" && src.Content.HTML == nil {
 				continue
 			}
 
 			out.Properties.Content = append(out.Properties.Content, Content{
-				Value: src.Content.Text,
-				HTML:  src.Content.HTML,
+				Value:    src.Content.Text,
+				HTML:     src.Content.HTML,
+				Markdown: src.Content.Markdown,
 			})
 		case "category":
 			out.Properties.Category = append(out.Properties.Category, src.Tags...)
 		case "name":
-			out.Properties.Name = append(out.Properties.Name, out.Properties.Name...)
+			if src.Title == "" {
+				continue
+			}
+
+			out.Properties.Name = append(out.Properties.Name, src.Title)
+		case "url":
+			if src.URL == nil {
+				continue
+			}
+
+			out.Properties.URL = append(out.Properties.URL, URL{URL: src.URL})
 		case "summary":
 			if src.Description == "" {
 				continue
 			}
 
 			out.Properties.Summary = append(out.Properties.Summary, src.Description)
+		case "post-status":
+			if src.Status == domain.PostStatusUnd {
+				continue
+			}
+
+			out.Properties.PostStatus = append(out.Properties.PostStatus, src.Status.String())
 		}
 	}
 
@@ -744,6 +2043,7 @@ func (p Properties) CopyTo(dst *domain.Entry) {
 	if len(p.Content) > 0 {
 		dst.Content.HTML = p.Content[0].HTML
 		dst.Content.Text = p.Content[0].Value
+		dst.Content.Markdown = p.Content[0].Markdown
 	}
 
 	if len(p.Name) > 0 {
@@ -754,15 +2054,53 @@ func (p Properties) CopyTo(dst *domain.Entry) {
 		dst.Description = p.Summary[0]
 	}
 
+	if len(p.PostStatus) > 0 {
+		if status, err := domain.ParsePostStatus(p.PostStatus[0]); err == nil {
+			dst.Status = status
+		}
+	}
+
 	for i := range p.Photo {
 		dst.Photo = append(dst.Photo, p.Photo[i].Value)
 	}
 
+	for i := range p.Video {
+		dst.Video = append(dst.Video, p.Video[i].Value)
+	}
+
+	for i := range p.Audio {
+		dst.Audio = append(dst.Audio, p.Audio[i].Value)
+	}
+
+	if len(p.Duration) > 0 {
+		dst.Duration = time.Duration(p.Duration[0]) * time.Second
+	}
+
+	if len(p.Size) > 0 {
+		dst.Size = p.Size[0]
+	}
+
 	dst.Tags = append(dst.Tags, p.Category...)
 
 	for i := range p.Syndication {
 		dst.Syndications = append(dst.Syndications, p.Syndication[i].URL)
 	}
+
+	for i := range p.InReplyTo {
+		dst.InReplyTo = append(dst.InReplyTo, p.InReplyTo[i].URL)
+	}
+
+	for i := range p.LikeOf {
+		dst.LikeOf = append(dst.LikeOf, p.LikeOf[i].URL)
+	}
+
+	for i := range p.RepostOf {
+		dst.RepostOf = append(dst.RepostOf, p.RepostOf[i].URL)
+	}
+
+	for i := range p.BookmarkOf {
+		dst.BookmarkOf = append(dst.BookmarkOf, p.BookmarkOf[i].URL)
+	}
 }
 
 func (f *Figure) UnmarshalJSON(v []byte) error {
@@ -809,16 +2147,34 @@ func (d Delete) CopyTo(dst *domain.Entry) {
 			dst.Title = ""
 		case "photo":
 			dst.Photo = make([]*url.URL, 0)
+		case "video":
+			dst.Video = make([]*url.URL, 0)
+		case "audio":
+			dst.Audio = make([]*url.URL, 0)
+		case "duration":
+			dst.Duration = 0
+		case "size":
+			dst.Size = 0
 		case "published":
 			dst.PublishedAt = time.Time{}
 		case "summary":
 			dst.Description = ""
+		case "post-status":
+			dst.Status = domain.PostStatusUnd
 		case "updated":
 			dst.UpdatedAt = time.Time{}
 		case "url":
 			dst.URL = new(url.URL)
 		case "syndication":
 			dst.Syndications = make([]*url.URL, 0)
+		case "in-reply-to":
+			dst.InReplyTo = make([]*url.URL, 0)
+		case "like-of":
+			dst.LikeOf = make([]*url.URL, 0)
+		case "repost-of":
+			dst.RepostOf = make([]*url.URL, 0)
+		case "bookmark-of":
+			dst.BookmarkOf = make([]*url.URL, 0)
 		}
 	}
 
@@ -887,6 +2243,7 @@ func (c *Content) UnmarshalJSON(v []byte) error {
 	switch v[0] {
 	case '{':
 		err = json.Unmarshal(v, buf)
+		c.Value = buf.Value
 	case '"':
 		c.Value, err = strconv.Unquote(string(v))
 	}
@@ -895,6 +2252,10 @@ func (c *Content) UnmarshalJSON(v []byte) error {
 		return err
 	}
 
+	if buf.Markdown != "" {
+		c.Markdown, buf.HTML = buf.Markdown, markdown.Render(buf.Markdown)
+	}
+
 	if buf.HTML == "" {
 		return nil
 	}
@@ -920,7 +2281,36 @@ func (c Content) MarshalJSON() ([]byte, error) {
 
 	// NOTE(toby3d): trim '<html><head></head><body>' prefix and
 	// '</body></html>' suffix
-	return []byte(`{"html":"` + out[25:len(out)-14] + `"}`), nil
+	return json.Marshal(bufferHTML{HTML: out[25 : len(out)-14], Value: c.Value, Markdown: c.Markdown})
+}
+
+// renderMarkdown renders plain text contents as Markdown if any of provided
+// mp-content-type hints selects it.
+func (p *Properties) renderMarkdown(hints ...string) {
+	isMarkdown := false
+
+	for _, hint := range hints {
+		if strings.EqualFold(hint, "markdown") || strings.EqualFold(hint, common.MIMETextMarkdown) {
+			isMarkdown = true
+		}
+	}
+
+	if !isMarkdown {
+		return
+	}
+
+	for i := range p.Content {
+		if p.Content[i].HTML != nil || p.Content[i].Value == "" {
+			continue
+		}
+
+		node, err := html.Parse(strings.NewReader(markdown.Render(p.Content[i].Value)))
+		if err != nil {
+			continue
+		}
+
+		p.Content[i] = Content{HTML: node, Markdown: p.Content[i].Value}
+	}
 }
 
 func (dt *DateTime) UnmarshalJSON(b []byte) error {
@@ -1,22 +1,43 @@
 package http_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/net/html"
 
+	"source.toby3d.me/toby3d/pub/internal/citation"
 	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/contact"
 	"source.toby3d.me/toby3d/pub/internal/domain"
 	"source.toby3d.me/toby3d/pub/internal/entry"
 	delivery "source.toby3d.me/toby3d/pub/internal/entry/delivery/http"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
 	"source.toby3d.me/toby3d/pub/internal/media"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	redirectmemoryrepo "source.toby3d.me/toby3d/pub/internal/redirect/repository/memory"
+	redirectucase "source.toby3d.me/toby3d/pub/internal/redirect/usecase"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+	revisionmemoryrepo "source.toby3d.me/toby3d/pub/internal/revision/repository/memory"
+	revisionucase "source.toby3d.me/toby3d/pub/internal/revision/usecase"
+	"source.toby3d.me/toby3d/pub/internal/sanitize"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
 )
 
 type testRequest struct {
@@ -48,6 +69,11 @@ func TestHandler_Create(t *testing.T) {
 					"This post should have one category, test1"},
 				"category": []string{"test1"},
 			},
+			"markdown": {
+				"h":               []string{"entry"},
+				"content":         []string{"Micropub test of creating an h-entry with **Markdown** content"},
+				"mp-content-type": []string{"markdown"},
+			},
 		} {
 			name, input := name, input
 
@@ -67,6 +93,7 @@ func TestHandler_Create(t *testing.T) {
 			"simple":     `{"type": ["h-entry"], "properties": {"content": ["Micropub test of creating an h-entry with a JSON request"]}}`,
 			"categories": `{"type": ["h-entry"], "properties": {"content": ["Micropub test of creating an h-entry with a JSON request containing multiple categories. This post should have two categories, test1 and test2."], "category": ["test1", "test2"]}}`,
 			"html":       `{"type": ["h-entry"], "properties": {"content": [{"html": "<p>This post has <b>bold</b> and <i>italic</i> text.</p>"}]}}`,
+			"markdown":   `{"type": ["h-entry"], "properties": {"content": [{"markdown": "This post has **bold** and _italic_ text."}]}}`,
 			"photo":      `{"type": ["h-entry"], "properties": {"content": ["Micropub test of creating a photo referenced by URL. This post should include a photo of a sunset."], "photo": ["https://micropub.rocks/media/sunset.jpg"]}}`,
 			"object":     `{"type": ["h-entry"], "properties": {"published": ["2017-05-31T12:03:36-07:00"], "content": ["Lunch meeting"], "checkin": [{"type": ["h-card"], "properties": {"name": ["Los Gorditos"], "url": ["https://foursquare.com/v/502c4bbde4b06e61e06d1ebf"], "latitude": [45.524330801154], "longitude": [-122.68068808051], "street-address": ["922 NW Davis St"], "locality": ["Portland"], "region": ["OR"], "country-name": ["United States"], "postal-code": ["97209"]}}]}}`,
 			"photo-alt":  `{"type": ["h-entry"], "properties": {"content": ["Micropub test of creating a photo referenced by URL with alt text. This post should include a photo of a sunset."], "photo": [{"value": "https://micropub.rocks/media/sunset.jpg", "alt": "Photo of a sunset"}]}}`,
@@ -85,6 +112,408 @@ func TestHandler_Create(t *testing.T) {
 	// TODO(toby3d): multipart requests
 }
 
+func TestHandler_Syndicate(t *testing.T) {
+	t.Parallel()
+
+	syndicator := domain.TestSyndicator(t)
+	syndicated := domain.TestEntry(t)
+	syndicated.Syndications = []*url.URL{{Scheme: "https", Host: "mastodon.example", Path: "/@alice/42"}}
+	handler := delivery.NewHandler(entry.NewStubUseCase(nil, domain.TestEntry(t), true), media.NewDummyUseCase(),
+		syndication.NewStubUseCase([]domain.Syndicator{*syndicator}, syndicated, nil), contact.NewDummyUseCase(),
+		search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	t.Run("syndicate-to", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/?q=syndicate-to", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		out := new(delivery.ResponseSyndicateTo)
+		if err := json.NewDecoder(w.Result().Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+
+		expect := &delivery.ResponseSyndicateTo{SyndicateTo: []delivery.ResponseSyndicator{{
+			UID:  syndicator.UID.String(),
+			Name: syndicator.Name,
+		}}}
+
+		if diff := cmp.Diff(out, expect); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(url.Values{
+			"h":                 {"entry"},
+			"content":           {"Syndicated"},
+			"mp-syndicate-to[]": {syndicator.UID.String()},
+		}.Encode()))
+		req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		expect := `<` + syndicated.Syndications[0].String() + `>; rel="syndication"`
+		if link := w.Result().Header.Get(common.HeaderLink); link != expect {
+			t.Errorf("%s %s = %s, want %s", req.Method, req.RequestURI, link, expect)
+		}
+	})
+}
+
+func TestHandler_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	const input = `{"type": ["h-entry"], "properties": {"url": ["https://example.com/notes/1"], ` +
+		`"content": [{"html": "<p onclick=\"alert(1)\">Hello</p><script>alert(1)</script>"}]}}`
+
+	for name, tc := range map[string]struct {
+		expect string
+		status int
+		reject bool
+	}{
+		"strip":  {reject: false, status: http.StatusCreated, expect: "<p>Hello</p>"},
+		"reject": {reject: true, status: http.StatusBadRequest},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := domain.TestConfig(t).Sanitize
+			config.Reject = tc.reject
+			entries := entrymemoryrepo.NewMemoryEntryRepository()
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(input))
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+
+			w := httptest.NewRecorder()
+			delivery.NewHandler(entryucase.NewEntryUseCase(entries), media.NewDummyUseCase(),
+				syndication.NewDummyUseCase(), contact.NewDummyUseCase(), search.NewDummyUseCase(),
+				revision.NewDummyUseCase(), redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+				sanitize.NewPolicy(config)).ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != tc.status {
+				t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.status)
+			}
+
+			if tc.expect == "" {
+				return
+			}
+
+			out, err := entries.Get(context.Background(), "/notes/1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if html := out.Content.RenderHTML(); html != tc.expect {
+				t.Errorf("got '%s', want '%s'", html, tc.expect)
+			}
+		})
+	}
+}
+
+func TestHandler_Linkify(t *testing.T) {
+	t.Parallel()
+
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	handler := delivery.NewHandler(entryucase.NewEntryUseCase(entries), media.NewDummyUseCase(),
+		syndication.NewDummyUseCase(), contact.NewStubUseCase([]domain.Contact{*domain.TestContact(t)}, nil),
+		search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	const content = "Hi @jane, see https://example.com/ #IndieWeb"
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(url.Values{
+		"h":        {"entry"},
+		"url":      {"https://example.com/notes/1"},
+		"content":  {content},
+		"category": {"indieweb"},
+	}.Encode()))
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if resp := w.Result(); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusCreated)
+	}
+
+	out, err := entries.Get(context.Background(), "/notes/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expect = `<p>Hi <a class="h-card" href="https://example.net/">@jane</a>, see ` +
+		`<a href="https://example.com/">https://example.com/</a> <a href="/tags/IndieWeb" rel="tag">#IndieWeb</a></p>`
+	if html := out.Content.RenderHTML(); html != expect {
+		t.Errorf("got '%s', want '%s'", html, expect)
+	}
+
+	if out.Content.Text != content {
+		t.Errorf("got '%s' text, want original '%s'", out.Content.Text, content)
+	}
+
+	if diff := cmp.Diff(out.Tags, []string{"indieweb"}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHandler_SourceList(t *testing.T) {
+	t.Parallel()
+
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		e := domain.TestEntry(t)
+		e.URL = &url.URL{Path: "/notes/" + strconv.Itoa(i)}
+		e.PublishedAt = published.AddDate(0, 0, i)
+
+		if i%2 == 0 {
+			e.Tags = []string{"even"}
+		}
+
+		if i == 4 {
+			e.DeletedAt = published
+		}
+
+		if err := entries.Create(context.Background(), e.URL.Path, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := delivery.NewHandler(entryucase.NewEntryUseCase(entries), media.NewDummyUseCase(),
+		syndication.NewDummyUseCase(), contact.NewDummyUseCase(), search.NewDummyUseCase(),
+		revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+	fetch := func(tb testing.TB, query url.Values, status int) *delivery.ResponseSourceList {
+		tb.Helper()
+
+		query.Set("q", "source")
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		out := new(delivery.ResponseSourceList)
+		if status == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				tb.Fatal(err)
+			}
+		}
+
+		return out
+	}
+	urls := func(out *delivery.ResponseSourceList) []string {
+		result := make([]string, 0, len(out.Items))
+		for i := range out.Items {
+			for j := range out.Items[i].Properties.URL {
+				result = append(result, out.Items[i].Properties.URL[j].Path)
+			}
+		}
+
+		return result
+	}
+
+	t.Run("paging", func(t *testing.T) {
+		t.Parallel()
+
+		first := fetch(t, url.Values{"limit": {"2"}}, http.StatusOK)
+		if diff := cmp.Diff(urls(first), []string{"/notes/3", "/notes/2"}); diff != "" {
+			t.Error(diff)
+		}
+
+		if first.Paging == nil || first.Paging.After == "" || first.Paging.Before != "" {
+			t.Fatalf("expect only after cursor, got %+v", first.Paging)
+		}
+
+		second := fetch(t, url.Values{"limit": {"2"}, "after": {first.Paging.After}}, http.StatusOK)
+		if diff := cmp.Diff(urls(second), []string{"/notes/1", "/notes/0"}); diff != "" {
+			t.Error(diff)
+		}
+
+		if second.Paging == nil || second.Paging.After != "" || second.Paging.Before == "" {
+			t.Errorf("expect only before cursor, got %+v", second.Paging)
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		t.Parallel()
+
+		out := fetch(t, url.Values{"category": {"even"}, "post-type": {"article"}}, http.StatusOK)
+		if diff := cmp.Diff(urls(out), []string{"/notes/2", "/notes/0"}); diff != "" {
+			t.Error(diff)
+		}
+
+		if out.Paging != nil {
+			t.Errorf("expect no paging, got %+v", out.Paging)
+		}
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		t.Parallel()
+
+		out := fetch(t, url.Values{"limit": {"1"}, "properties[]": {"name"}}, http.StatusOK)
+		if len(out.Items) != 1 {
+			t.Fatalf("expect 1 item, got %d", len(out.Items))
+		}
+
+		properties, err := json.Marshal(out.Items[0].Properties)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expect := `{"name":["` + domain.TestEntry(t).Title + `"]}`; string(properties) != expect {
+			t.Errorf("expect %s properties, got %s", expect, properties)
+		}
+
+		if len(out.Items[0].Type) != 0 {
+			t.Errorf("expect no type for filtered properties, got %v", out.Items[0].Type)
+		}
+	})
+
+	for name, query := range map[string]url.Values{
+		"limit":     {"limit": {"-1"}},
+		"post-type": {"post-type": {"unknown"}},
+		"cursor":    {"after": {"!"}},
+	} {
+		name, query := name, query
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fetch(t, query, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestHandler_Autocomplete(t *testing.T) {
+	t.Parallel()
+
+	e := domain.TestEntry(t)
+	e.Tags = []string{"indieweb", "micropub"}
+	jane := domain.TestContact(t)
+	handler := delivery.NewHandler(entry.NewStubUseCase(nil, e, true), media.NewDummyUseCase(),
+		syndication.NewDummyUseCase(), contact.NewStubUseCase([]domain.Contact{*jane}, nil),
+		search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	for name, tc := range map[string]struct {
+		output any
+		expect any
+		query  string
+	}{
+		"category": {
+			query:  "q=category&filter=indie",
+			output: new(delivery.ResponseCategory),
+			expect: &delivery.ResponseCategory{
+				Counts:     map[string]int{"indieweb": 1, "micropub": 1},
+				Categories: []string{"indieweb", "micropub"},
+			},
+		},
+		"contact": {
+			query:  "q=contact&search=jan",
+			output: new(delivery.ResponseContacts),
+			expect: &delivery.ResponseContacts{Contacts: []delivery.ResponseContact{{
+				Silos:    jane.Silos,
+				Name:     jane.Name,
+				Nickname: jane.Nickname,
+				URL:      jane.URL.String(),
+				Photo:    jane.Photo.String(),
+			}}},
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if err := json.NewDecoder(w.Result().Body).Decode(tc.output); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.output, tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestHandler_Search(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]domain.Entry, 0)
+	for i := 0; i < 3; i++ {
+		e := domain.TestEntry(t)
+		e.URL = &url.URL{Path: "/notes/" + strconv.Itoa(i)}
+		entries = append(entries, *e)
+	}
+
+	handler := delivery.NewHandler(entry.NewDummyUseCase(), media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+		contact.NewDummyUseCase(), search.NewStubUseCase(entries, nil), revision.NewDummyUseCase(),
+		redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	for name, tc := range map[string]struct {
+		query  url.Values
+		status int
+	}{
+		"first":    {query: url.Values{"q-term": {"lorem"}, "limit": {"2"}}, status: http.StatusOK},
+		"next":     {query: url.Values{"q-term": {"lorem"}, "after": {"2"}}, status: http.StatusOK},
+		"term":     {query: url.Values{"q-term": {" "}}, status: http.StatusBadRequest},
+		"cursor":   {query: url.Values{"q-term": {"lorem"}, "after": {"-1"}}, status: http.StatusBadRequest},
+		"no limit": {query: url.Values{"q-term": {"lorem"}, "limit": {"0"}}, status: http.StatusBadRequest},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.query.Set("q", "search")
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/?"+tc.query.Encode(), nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tc.status {
+				t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.status)
+			}
+
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			out := new(delivery.ResponseSourceList)
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(out.Items) != len(entries) {
+				t.Errorf("expect %d items, got %d", len(entries), len(out.Items))
+			}
+		})
+	}
+}
+
 func doCreateRequest(tb testing.TB, r io.Reader, contentType string) {
 	tb.Helper()
 
@@ -93,7 +522,9 @@ func doCreateRequest(tb testing.TB, r io.Reader, contentType string) {
 
 	w := httptest.NewRecorder()
 	delivery.NewHandler(entry.NewStubUseCase(nil, domain.TestEntry(tb), true),
-		media.NewDummyUseCase()).ServeHTTP(w, req)
+		media.NewDummyUseCase(), syndication.NewDummyUseCase(), contact.NewDummyUseCase(), search.NewDummyUseCase(),
+		revision.NewDummyUseCase(), redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(tb).Sanitize)).ServeHTTP(w, req)
 
 	resp := w.Result()
 
@@ -134,6 +565,11 @@ func TestContent_UnmarshalJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	testMarkdown, err := html.Parse(strings.NewReader("<p><strong>Hello</strong> <em>World</em></p>\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tc := range []struct {
 		name string
 		in   string
@@ -152,6 +588,13 @@ func TestContent_UnmarshalJSON(t *testing.T) {
 			HTML:  testContent,
 			Value: "",
 		},
+	}, {
+		name: "markdown",
+		in:   `{"markdown":"**Hello** _World_"}`,
+		out: delivery.Content{
+			HTML:     testMarkdown,
+			Markdown: "**Hello** _World_",
+		},
 	}} {
 		tc := tc
 
@@ -184,6 +627,11 @@ func TestContent_MarshalJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	testLines, err := html.Parse(strings.NewReader("<p>Hello</p>\n<p>World</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tc := range []struct {
 		in   delivery.Content
 		out  string
@@ -208,7 +656,22 @@ func TestContent_MarshalJSON(t *testing.T) {
 			HTML:  testContent,
 			Value: `Hello World`,
 		},
-		out: `{"content":[{"html":"\u003cb\u003eHello\u003c/b\u003e \u003ci\u003eWorld\u003c/i\u003e"}]}`,
+		out: `{"content":[{"html":"\u003cb\u003eHello\u003c/b\u003e \u003ci\u003eWorld\u003c/i\u003e",` +
+			`"value":"Hello World"}]}`,
+	}, {
+		name: "markdown",
+		in: delivery.Content{
+			HTML:     testContent,
+			Markdown: "**Hello** _World_",
+		},
+		out: `{"content":[{"html":"\u003cb\u003eHello\u003c/b\u003e \u003ci\u003eWorld\u003c/i\u003e",` +
+			`"markdown":"**Hello** _World_"}]}`,
+	}, {
+		name: "lines",
+		in: delivery.Content{
+			HTML: testLines,
+		},
+		out: `{"content":[{"html":"\u003cp\u003eHello\u003c/p\u003e\n\u003cp\u003eWorld\u003c/p\u003e"}]}`,
 	}} {
 		tc := tc
 
@@ -320,3 +783,556 @@ func TestFigure_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Revisions(t *testing.T) {
+	t.Parallel()
+
+	revisions := revisionmemoryrepo.NewMemoryRevisionRepository()
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(),
+		revision.NewHook(revisions, log.New(io.Discard, "", 0)))
+	handler := delivery.NewHandler(entries, media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+		contact.NewDummyUseCase(), search.NewDummyUseCase(), revisionucase.NewRevisionUseCase(revisions, entries),
+		redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	e := domain.TestEntry(t)
+	if _, err := entries.Create(context.Background(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(tb testing.TB, req *http.Request, status int, out any) {
+		tb.Helper()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		if out == nil {
+			return
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	post := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(body))
+		req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+
+		return req
+	}
+
+	do(t, post(`{"action": "update", "url": "`+e.URL.String()+`", "replace": {"name": ["Updated"]}}`),
+		http.StatusOK, nil)
+
+	list := new(delivery.ResponseRevisions)
+	do(t, httptest.NewRequest(http.MethodGet, "https://example.com/?q=revisions&url="+e.URL.String(), nil),
+		http.StatusOK, list)
+
+	if len(list.Revisions) != 2 || list.Revisions[1].Action != "update" || list.Revisions[1].Revision != 2 {
+		t.Fatalf("expect create and update revisions, got %+v", list.Revisions)
+	}
+
+	changes := new(delivery.ResponseDiff)
+	do(t, httptest.NewRequest(http.MethodGet, "https://example.com/?q=diff&from=1&to=2&url="+e.URL.String(),
+		nil), http.StatusOK, changes)
+
+	var found bool
+
+	for _, change := range changes.Changes {
+		if change.Property != "name" {
+			continue
+		}
+
+		found = true
+
+		if diff := cmp.Diff(delivery.ResponseChange{
+			Property: "name",
+			Before:   []string{e.Title},
+			After:    []string{"Updated"},
+		}, change); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	if !found {
+		t.Errorf("expect name change, got %+v", changes.Changes)
+	}
+
+	snapshot := new(delivery.ResponseSource)
+	do(t, httptest.NewRequest(http.MethodGet, "https://example.com/?q=source&revision=1&url="+e.URL.String(),
+		nil), http.StatusOK, snapshot)
+
+	if diff := cmp.Diff([]string{e.Title}, snapshot.Properties.Name); diff != "" {
+		t.Error(diff)
+	}
+
+	do(t, httptest.NewRequest(http.MethodGet, "https://example.com/?q=source&revision=42&url="+e.URL.String(),
+		nil), http.StatusNotFound, nil)
+
+	reverted := new(delivery.ResponseSource)
+	do(t, post(`{"action": "revert", "url": "`+e.URL.String()+`", "revision": 1}`), http.StatusOK, reverted)
+
+	if diff := cmp.Diff([]string{e.Title}, reverted.Properties.Name); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHandler_Precondition(t *testing.T) {
+	t.Parallel()
+
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository())
+	handler := delivery.NewHandler(entries, media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+		contact.NewDummyUseCase(), search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	e := domain.TestEntry(t)
+	if _, err := entries.Create(context.Background(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(tb testing.TB, req *http.Request, ifMatch string, status int) *http.Response {
+		tb.Helper()
+
+		if ifMatch != "" {
+			req.Header.Set(common.HeaderIfMatch, ifMatch)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		return resp
+	}
+	post := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(body))
+		req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+
+		return req
+	}
+	update := `{"action": "update", "url": "` + e.URL.String() + `", "replace": {"name": ["Updated"]}}`
+	remove := `{"action": "delete", "url": "` + e.URL.String() + `"}`
+
+	resp := do(t, httptest.NewRequest(http.MethodGet, "https://example.com/?q=source&url="+e.URL.String(), nil),
+		"", http.StatusOK)
+	if tag := resp.Header.Get(common.HeaderETag); tag != `"1"` {
+		t.Errorf("expect ETag of created entry, got %s", tag)
+	}
+
+	do(t, post(update), `"42"`, http.StatusPreconditionFailed)
+	do(t, post(update), `W/"1"`, http.StatusPreconditionFailed)
+
+	resp = do(t, post(update), `"1"`, http.StatusOK)
+	if tag := resp.Header.Get(common.HeaderETag); tag != `"2"` {
+		t.Errorf("expect ETag of updated entry, got %s", tag)
+	}
+
+	do(t, post(remove), `"1"`, http.StatusPreconditionFailed)
+	do(t, post(remove), `"1", "2"`, http.StatusNoContent)
+}
+
+func TestHandler_ConcurrentUpdate(t *testing.T) {
+	t.Parallel()
+
+	const updates int = 8
+
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository())
+
+	// NOTE(toby3d): all requests reads the same version of entry before
+	// any of them stores changes.
+	handler := delivery.NewHandler(&barrierUseCase{UseCase: entries, mutex: new(sync.Mutex), ready: make(chan struct{}),
+		wait: updates}, media.NewDummyUseCase(), syndication.NewDummyUseCase(), contact.NewDummyUseCase(),
+		search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(),
+		citation.NewDummyUseCase(), sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	e := domain.TestEntry(t)
+	e.Tags = nil
+
+	if _, err := entries.Create(context.Background(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+
+		go func(tag string) {
+			defer wg.Done()
+
+			// NOTE(toby3d): clients without If-Match never get 412.
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(
+				`{"action": "update", "url": "`+e.URL.String()+`", "add": {"category": ["`+tag+`"]}}`))
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != http.StatusOK {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+			}
+		}("tag" + strconv.Itoa(i))
+	}
+
+	wg.Wait()
+
+	out, err := entries.Source(context.Background(), e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Tags) != updates {
+		t.Errorf("expect all %d concurrent updates applied, got %v", updates, out.Tags)
+	}
+}
+
+// barrierUseCase holds results of the first wait calls of Source until all of
+// them are made.
+type barrierUseCase struct {
+	entry.UseCase
+	mutex *sync.Mutex
+	ready chan struct{}
+	calls int
+	wait  int
+}
+
+func (ucase *barrierUseCase) Source(ctx context.Context, u *url.URL) (*domain.Entry, error) {
+	out, err := ucase.UseCase.Source(ctx, u)
+
+	ucase.mutex.Lock()
+	ucase.calls++
+	call := ucase.calls
+
+	if call == ucase.wait {
+		close(ucase.ready)
+	}
+	ucase.mutex.Unlock()
+
+	if call <= ucase.wait {
+		<-ucase.ready
+	}
+
+	return out, err
+}
+
+func TestHandler_Replace(t *testing.T) {
+	t.Parallel()
+
+	rehosted := &url.URL{Path: "/media/rehosted"}
+
+	for property, tc := range map[string]struct {
+		field  func(e *domain.Entry) *[]*url.URL
+		expect string
+	}{
+		"in-reply-to": {func(e *domain.Entry) *[]*url.URL { return &e.InReplyTo }, "https://b.example/2"},
+		"like-of":     {func(e *domain.Entry) *[]*url.URL { return &e.LikeOf }, "https://b.example/2"},
+		"repost-of":   {func(e *domain.Entry) *[]*url.URL { return &e.RepostOf }, "https://b.example/2"},
+		"bookmark-of": {func(e *domain.Entry) *[]*url.URL { return &e.BookmarkOf }, "https://b.example/2"},
+		"photo":       {func(e *domain.Entry) *[]*url.URL { return &e.Photo }, rehosted.String()},
+		"video":       {func(e *domain.Entry) *[]*url.URL { return &e.Video }, rehosted.String()},
+		"audio":       {func(e *domain.Entry) *[]*url.URL { return &e.Audio }, rehosted.String()},
+	} {
+		property, tc := property, tc
+
+		t.Run(property, func(t *testing.T) {
+			t.Parallel()
+
+			entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository())
+			handler := delivery.NewHandler(entries, media.NewStubUseCase(nil, nil, rehosted),
+				syndication.NewDummyUseCase(), contact.NewDummyUseCase(), search.NewDummyUseCase(),
+				revision.NewDummyUseCase(), redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(),
+				citation.NewDummyUseCase(), sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+			e := domain.TestEntry(t)
+			*tc.field(e) = []*url.URL{{Scheme: "https", Host: "a.example", Path: "/1"}}
+
+			if _, err := entries.Create(context.Background(), *e); err != nil {
+				t.Fatal(err)
+			}
+
+			update := func(tb testing.TB, body string) []string {
+				tb.Helper()
+
+				req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(
+					`{"action": "update", "url": "`+e.URL.String()+`", `+body+`}`))
+				req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+
+				if resp := w.Result(); resp.StatusCode != http.StatusOK {
+					tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+				}
+
+				out, err := entries.Source(context.Background(), e.URL)
+				if err != nil {
+					tb.Fatal(err)
+				}
+
+				result := make([]string, 0)
+				for _, u := range *tc.field(out) {
+					result = append(result, u.String())
+				}
+
+				return result
+			}
+
+			if diff := cmp.Diff(update(t, `"replace": {"`+property+`": ["https://b.example/2"]}`),
+				[]string{tc.expect}); diff != "" {
+				t.Error(diff)
+			}
+
+			if diff := cmp.Diff(update(t, `"delete": ["`+property+`"]`), []string{}); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestHandler_Purge(t *testing.T) {
+	t.Parallel()
+
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository())
+	handler := delivery.NewHandler(entries, media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+		contact.NewDummyUseCase(), search.NewDummyUseCase(), revision.NewDummyUseCase(), redirect.NewDummyUseCase(),
+		shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	alive, deleted := domain.TestEntry(t), domain.TestEntry(t)
+	deleted.URL = &url.URL{Path: "/samples/deleted"}
+
+	for _, e := range []*domain.Entry{alive, deleted} {
+		if _, err := entries.Create(context.Background(), *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := entries.Delete(context.Background(), deleted.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(tb testing.TB, req *http.Request, status int) *http.Response {
+		tb.Helper()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		return resp
+	}
+	trash := func(tb testing.TB) []string {
+		tb.Helper()
+
+		out := new(delivery.ResponseSourceList)
+		if err := json.NewDecoder(do(tb, httptest.NewRequest(http.MethodGet,
+			"https://example.com/?q=source&deleted=true", nil), http.StatusOK).Body).Decode(out); err != nil {
+			tb.Fatal(err)
+		}
+
+		result := make([]string, 0, len(out.Items))
+		for i := range out.Items {
+			for j := range out.Items[i].Properties.URL {
+				result = append(result, out.Items[i].Properties.URL[j].Path)
+			}
+		}
+
+		return result
+	}
+	purge := func(u *url.URL) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(url.Values{
+			"action": {"purge"},
+			"url":    {u.String()},
+		}.Encode()))
+		req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+		return req
+	}
+
+	if diff := cmp.Diff([]string{deleted.URL.Path}, trash(t)); diff != "" {
+		t.Error(diff)
+	}
+
+	do(t, purge(alive.URL), http.StatusConflict)
+	do(t, purge(deleted.URL), http.StatusNoContent)
+
+	if diff := cmp.Diff([]string{}, trash(t)); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := entries.Source(context.Background(), deleted.URL); !errors.Is(err, entry.ErrNotExist) {
+		t.Errorf("expect purged entry removed, got %v", err)
+	}
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		citations citation.UseCase
+		body      string
+		expect    int
+	}{
+		"refresh": {
+			citations: citation.NewStubUseCase(nil, domain.TestEntry(t), nil),
+			body:      `{"action": "refresh", "url": "https://example.com/samples/lipsum"}`,
+			expect:    http.StatusNoContent,
+		},
+		"not found": {
+			citations: citation.NewStubUseCase(nil, nil, fmt.Errorf("cannot get entry: %w", entry.ErrNotExist)),
+			body:      `{"action": "refresh", "url": "https://example.com/samples/missing"}`,
+			expect:    http.StatusNotFound,
+		},
+		"no url": {
+			citations: citation.NewDummyUseCase(),
+			body:      `{"action": "refresh"}`,
+			expect:    http.StatusBadRequest,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(tc.body))
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationJSON)
+
+			w := httptest.NewRecorder()
+			delivery.NewHandler(entry.NewDummyUseCase(), media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+				contact.NewDummyUseCase(), search.NewDummyUseCase(), revision.NewDummyUseCase(),
+				redirect.NewDummyUseCase(), shortlink.NewDummyUseCase(), tc.citations,
+				sanitize.NewPolicy(domain.TestConfig(t).Sanitize)).ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != tc.expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+		})
+	}
+}
+
+func TestHandler_Redirect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	redirects := redirectmemoryrepo.NewMemoryRedirectRepository()
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(),
+		redirect.NewHook(redirects, log.New(io.Discard, "", 0)))
+	handler := delivery.NewHandler(entries, media.NewDummyUseCase(), syndication.NewDummyUseCase(),
+		contact.NewDummyUseCase(), search.NewDummyUseCase(), revision.NewDummyUseCase(),
+		redirectucase.NewRedirectUseCase(redirects), shortlink.NewDummyUseCase(), citation.NewDummyUseCase(),
+		sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+
+	e := domain.TestEntry(t)
+	if _, err := entries.Create(ctx, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	moved := *e
+	moved.URL = &url.URL{Path: "/samples/moved"}
+
+	if _, err := entries.Update(ctx, e.URL, entry.UpdateOptions{Replace: &moved}); err != nil {
+		t.Fatal(err)
+	}
+
+	source := func(tb testing.TB, status int) *http.Response {
+		tb.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/?q=source&url="+url.QueryEscape(e.URL.Path),
+			nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		return resp
+	}
+
+	out := new(delivery.ResponseSource)
+	if err := json.NewDecoder(source(t, http.StatusOK).Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Properties.URL) == 0 || out.Properties.URL[0].Path != moved.URL.Path {
+		t.Errorf("expect source of %s resolved to %s, got %v", e.URL, moved.URL, out.Properties.URL)
+	}
+
+	if _, err := entries.Delete(ctx, moved.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entries.Purge(ctx, moved.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	source(t, http.StatusGone)
+}
+
+func TestHandler_Shortlink(t *testing.T) {
+	t.Parallel()
+
+	short := &domain.Shortlink{
+		URL:   domain.TestEntry(t).URL,
+		Short: &url.URL{Scheme: "https", Host: "exam.pl", Path: "/5Ne1"},
+		Code:  "5Ne1",
+	}
+	handler := delivery.NewHandler(entry.NewStubUseCase(nil, domain.TestEntry(t), true), media.NewDummyUseCase(),
+		syndication.NewDummyUseCase(), contact.NewDummyUseCase(), search.NewDummyUseCase(),
+		revision.NewDummyUseCase(), redirect.NewDummyUseCase(), shortlink.NewStubUseCase(short, nil),
+		citation.NewDummyUseCase(), sanitize.NewPolicy(domain.TestConfig(t).Sanitize))
+	expect := `<https://exam.pl/5Ne1>; rel="shortlink"`
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader(url.Values{
+			"h":       {"entry"},
+			"content": {"Shortened"},
+		}.Encode()))
+		req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if link := w.Result().Header.Get(common.HeaderLink); link != expect {
+			t.Errorf("%s %s = %s, want %s", req.Method, req.RequestURI, link, expect)
+		}
+	})
+
+	t.Run("source", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/?q=source&url="+
+			url.QueryEscape(short.URL.String()), nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if link := resp.Header.Get(common.HeaderLink); link != expect {
+			t.Errorf("%s %s = %s, want %s", req.Method, req.RequestURI, link, expect)
+		}
+
+		out := new(delivery.ResponseSource)
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(out.Properties.Shortlink) != 1 || out.Properties.Shortlink[0].String() != short.Short.String() {
+			t.Errorf("expect shortlink property %s, got %v", short.Short, out.Properties.Shortlink)
+		}
+	})
+}
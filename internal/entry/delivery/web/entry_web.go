@@ -0,0 +1,348 @@
+// Package web provides a public HTML pages of entries with microformats2
+// markup: permalinks, paginated home page, year and month archives, tag pages
+// and search. The trash page of deleted entries is served separately next to
+// the editor.
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+	"source.toby3d.me/toby3d/pub/web/template"
+)
+
+type (
+	Handler struct {
+		entries     entry.UseCase
+		search      search.UseCase
+		redirects   redirect.UseCase
+		webmentions webmention.UseCase
+		matcher     language.Matcher
+		config      domain.Config
+	}
+
+	// TrashHandler provides a page of deleted entries which can be purged or
+	// undeleted.
+	TrashHandler struct {
+		entries entry.UseCase
+		matcher language.Matcher
+		config  domain.Config
+	}
+)
+
+// Limit is a number of entries per page of lists.
+const Limit int = 20
+
+func NewHandler(entries entry.UseCase, search search.UseCase, redirects redirect.UseCase,
+	webmentions webmention.UseCase, matcher language.Matcher, config domain.Config,
+) *Handler {
+	return &Handler{
+		entries:     entries,
+		search:      search,
+		redirects:   redirects,
+		webmentions: webmentions,
+		matcher:     matcher,
+		config:      config,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	base := template.NewBaseOf(Language(r, h.matcher))
+
+	if r.Method != "" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		WriteError(w, base, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.URL.Path == "/":
+		h.handleList(w, r, base, template.FeedTitle{Site: h.config.HTTP.Host}, entry.Query{},
+			h.config.HTTP.BaseURL(), feed.URL(h.config, feed.FormatAtom, feed.Options{}))
+
+		return
+	case r.URL.Path == "/search":
+		h.handleSearch(w, r, base)
+
+		return
+	case len(parts) == 2 && parts[0] == "tags" && parts[1] != "":
+		h.handleList(w, r, base, template.FeedTitle{Tag: parts[1]}, entry.Query{Tag: parts[1]},
+			h.config.HTTP.BaseURL().JoinPath("tags", parts[1]),
+			feed.URL(h.config, feed.FormatAtom, feed.Options{Tag: parts[1]}))
+
+		return
+	}
+
+	if from, to, ok := parsePeriod(parts); ok {
+		period := from.Format("2006")
+		if len(parts) > 1 {
+			period = from.Format("2006-01")
+		}
+
+		h.handleList(w, r, base, template.FeedTitle{Period: period}, entry.Query{From: from, To: to},
+			h.config.HTTP.BaseURL().JoinPath(parts...), nil)
+
+		return
+	}
+
+	h.handleEntry(w, r, base)
+}
+
+func (h *Handler) handleEntry(w http.ResponseWriter, r *http.Request, base *template.BaseOf) {
+	e, err := h.entries.Source(r.Context(), &url.URL{Path: r.URL.Path})
+	if err != nil {
+		if errors.Is(err, entry.ErrNotExist) {
+			h.handleRedirect(w, r, base)
+
+			return
+		}
+
+		WriteError(w, base, http.StatusInternalServerError)
+
+		return
+	}
+
+	if !e.DeletedAt.IsZero() {
+		WriteError(w, base, http.StatusGone)
+
+		return
+	}
+
+	// NOTE(toby3d): drafts are not public until published.
+	if !e.IsPublished() {
+		WriteError(w, base, http.StatusNotFound)
+
+		return
+	}
+
+	permalink := h.config.HTTP.BaseURL().ResolveReference(e.URL)
+
+	mentions, err := h.webmentions.Fetch(r.Context(), permalink, domain.MentionStatusApproved)
+	if err != nil {
+		WriteError(w, base, http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+	template.WriteTemplate(w, template.NewPageEntry(base, e, mentions, permalink,
+		h.config.HTTP.BaseURL().JoinPath("webmention")))
+}
+
+// handleRedirect moves client to the current URL of entry formerly available
+// on requested path.
+func (h *Handler) handleRedirect(w http.ResponseWriter, r *http.Request, base *template.BaseOf) {
+	target, err := h.redirects.Resolve(r.Context(), &url.URL{Path: r.URL.Path})
+	if err != nil {
+		switch {
+		case errors.Is(err, redirect.ErrGone):
+			WriteError(w, base, http.StatusGone)
+		case errors.Is(err, redirect.ErrNotExist):
+			WriteError(w, base, http.StatusNotFound)
+		default:
+			WriteError(w, base, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	http.Redirect(w, r, h.config.HTTP.BaseURL().ResolveReference(target).String(), http.StatusMovedPermanently)
+}
+
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request, base *template.BaseOf,
+	heading template.FeedTitle, query entry.Query, self, alternate *url.URL,
+) {
+	query.Published = true
+	query.Limit = Limit
+	query.After = r.URL.Query().Get("after")
+	query.Before = r.URL.Query().Get("before")
+
+	page, err := h.entries.Fetch(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, entry.ErrCursorSyntax) {
+			WriteError(w, base, http.StatusBadRequest)
+
+			return
+		}
+
+		WriteError(w, base, http.StatusInternalServerError)
+
+		return
+	}
+
+	var prev, next *url.URL
+
+	if page.Prev != "" {
+		prev = &url.URL{Path: r.URL.Path, RawQuery: url.Values{"before": {page.Prev}}.Encode()}
+	}
+
+	if page.Next != "" {
+		next = &url.URL{Path: r.URL.Path, RawQuery: url.Values{"after": {page.Next}}.Encode()}
+	}
+
+	// NOTE(toby3d): all pages of list are the same topic, hubs are pinged
+	// about it's first page.
+	websub.Advertise(w.Header(), self, websub.Endpoints(h.config)...)
+	w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+	template.WriteTemplate(w, template.NewPageFeed(base, heading, h.config.HTTP.BaseURL(), page.Entries, prev, next,
+		alternate))
+}
+
+func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request, base *template.BaseOf) {
+	term := strings.TrimSpace(r.URL.Query().Get("q"))
+	page := 1
+
+	if r.URL.Query().Has("page") {
+		var err error
+		if page, err = strconv.Atoi(r.URL.Query().Get("page")); err != nil || page < 1 {
+			WriteError(w, base, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	result, err := h.search.Search(r.Context(), search.Query{
+		Term:      term,
+		Limit:     Limit,
+		Offset:    (page - 1) * Limit,
+		Published: true,
+	})
+	if err != nil {
+		WriteError(w, base, http.StatusInternalServerError)
+
+		return
+	}
+
+	var prev, next *url.URL
+
+	if page > 1 {
+		prev = &url.URL{Path: r.URL.Path, RawQuery: url.Values{
+			"q":    {term},
+			"page": {strconv.Itoa(page - 1)},
+		}.Encode()}
+	}
+
+	if page*Limit < result.Total {
+		next = &url.URL{Path: r.URL.Path, RawQuery: url.Values{
+			"q":    {term},
+			"page": {strconv.Itoa(page + 1)},
+		}.Encode()}
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+	template.WriteTemplate(w, template.NewPageSearch(base, h.config.HTTP.BaseURL(), term, result.Entries, prev,
+		next))
+}
+
+func NewTrashHandler(entries entry.UseCase, matcher language.Matcher, config domain.Config) *TrashHandler {
+	return &TrashHandler{
+		entries: entries,
+		matcher: matcher,
+		config:  config,
+	}
+}
+
+func (h *TrashHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	base := template.NewBaseOf(Language(r, h.matcher))
+
+	if r.Method != "" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		WriteError(w, base, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	page, err := h.entries.Fetch(r.Context(), entry.Query{
+		Deleted: true,
+		Limit:   Limit,
+		After:   r.URL.Query().Get("after"),
+		Before:  r.URL.Query().Get("before"),
+	})
+	if err != nil {
+		if errors.Is(err, entry.ErrCursorSyntax) {
+			WriteError(w, base, http.StatusBadRequest)
+
+			return
+		}
+
+		WriteError(w, base, http.StatusInternalServerError)
+
+		return
+	}
+
+	var prev, next *url.URL
+
+	if page.Prev != "" {
+		prev = &url.URL{Path: r.URL.Path, RawQuery: url.Values{"before": {page.Prev}}.Encode()}
+	}
+
+	if page.Next != "" {
+		next = &url.URL{Path: r.URL.Path, RawQuery: url.Values{"after": {page.Next}}.Encode()}
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+	template.WriteTemplate(w, template.NewPageTrash(base, h.config.HTTP.BaseURL(), page.Entries, prev, next,
+		h.config.Trash.Retention))
+}
+
+// parsePeriod returns time range of /{year} and /{year}/{month} archive
+// paths.
+func parsePeriod(parts []string) (from, to time.Time, ok bool) {
+	if len(parts) == 0 || len(parts) > 2 || len(parts[0]) != 4 {
+		return from, to, false
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	if err != nil || year < 1 {
+		return from, to, false
+	}
+
+	if len(parts) == 1 {
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		return from, from.AddDate(1, 0, 0), true
+	}
+
+	month, err := strconv.Atoi(parts[1])
+	if err != nil || len(parts[1]) != 2 || month < 1 || month > 12 {
+		return from, to, false
+	}
+
+	from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+	return from, from.AddDate(0, 1, 0), true
+}
+
+// Language returns the most preferred language of request which is supported
+// by matcher.
+func Language(r *http.Request, matcher language.Matcher) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get(common.HeaderAcceptLanguage))
+	if err != nil {
+		tags = append(tags, language.English)
+	}
+
+	tag, _, _ := matcher.Match(tags...)
+
+	return tag
+}
+
+func WriteError(w http.ResponseWriter, base *template.BaseOf, status int) {
+	w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+	w.WriteHeader(status)
+	template.WriteTemplate(w, template.NewPageError(base, status))
+}
@@ -0,0 +1,441 @@
+package web_test
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/entry/delivery/web"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	searchmemoryrepo "source.toby3d.me/toby3d/pub/internal/search/repository/memory"
+	searchucase "source.toby3d.me/toby3d/pub/internal/search/usecase"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+	"source.toby3d.me/toby3d/pub/internal/websub/publisher"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	matcher := language.NewMatcher([]language.Tag{language.English, language.Russian})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		e := domain.TestEntry(t)
+		e.PublishedAt = time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+		e.Photo = append(e.Photo, domain.TestURL(t, "https://example.com/media/photo.jpg"))
+		e.Syndications = append(e.Syndications, domain.TestURL(t, "https://mastodon.example/@alice/42"))
+		c := domain.TestCitation(t)
+		e.InReplyTo = append(e.InReplyTo, c.URL)
+		e.Citations = append(e.Citations, c)
+
+		mentions := make([]domain.Mention, 0)
+
+		for _, mentionType := range []domain.MentionType{
+			domain.MentionTypeLike, domain.MentionTypeRepost, domain.MentionTypeReply, domain.MentionTypeMention,
+		} {
+			m := domain.TestMention(t)
+			m.Type = mentionType
+			m.Status = domain.MentionStatusApproved
+			mentions = append(mentions, *m)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/samples/lipsum", nil)
+
+		w := httptest.NewRecorder()
+		web.NewHandler(entry.NewStubUseCase(nil, e, true), search.NewDummyUseCase(), redirect.NewDummyUseCase(),
+			webmention.NewStubUseCase(mentions, nil, nil), matcher, *config).ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, expect := range []string{
+			`class="h-entry"`,
+			`class="p-name">Lorem ipsum</h1>`,
+			`class="e-content"`,
+			`datetime="2023-01-02T03:04:05Z"`,
+			`class="u-photo"`,
+			`class="p-category"`,
+			`class="u-syndication"`,
+			`Published 2023-01-02 03:04`,
+			`class="u-in-reply-to h-cite"`,
+			`class="p-content">Lorem ipsum dolor sit amet.</p>`,
+			`class="u-like h-cite"`,
+			`class="u-repost h-cite"`,
+			`class="p-comment h-cite"`,
+			`class="p-content">Nice post!</p>`,
+		} {
+			if !strings.Contains(string(body), expect) {
+				t.Errorf("expect %s in body, got:\n%s", expect, body)
+			}
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		entry     *domain.Entry
+		err       error
+		redirects redirect.UseCase
+		expect    int
+		location  string
+	}{
+		"not found": {err: entry.ErrNotExist, expect: http.StatusNotFound},
+		"gone":      {entry: &domain.Entry{DeletedAt: time.Now()}, expect: http.StatusGone},
+		"draft":     {entry: &domain.Entry{Status: domain.PostStatusDraft}, expect: http.StatusNotFound},
+		"moved": {
+			err:       entry.ErrNotExist,
+			redirects: redirect.NewStubUseCase(&url.URL{Path: "/samples/moved"}, nil),
+			expect:    http.StatusMovedPermanently,
+			location:  "https://example.com/samples/moved",
+		},
+		"purged": {
+			err:       entry.ErrNotExist,
+			redirects: redirect.NewStubUseCase(nil, redirect.ErrGone),
+			expect:    http.StatusGone,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.redirects == nil {
+				tc.redirects = redirect.NewDummyUseCase()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/samples/lipsum", nil)
+			w := httptest.NewRecorder()
+			web.NewHandler(entry.NewStubUseCase(tc.err, tc.entry, false), search.NewDummyUseCase(), tc.redirects,
+				webmention.NewDummyUseCase(), matcher, *config).ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tc.expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+
+			if location := resp.Header.Get(common.HeaderLocation); location != tc.location {
+				t.Errorf("expect Location '%s', got '%s'", tc.location, location)
+			}
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	matcher := language.NewMatcher([]language.Tag{language.English})
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	searcher := searchucase.NewSearchUseCase(searchmemoryrepo.NewMemorySearchRepository(), entries)
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < web.Limit+5; i++ {
+		e := domain.Entry{
+			URL:         &url.URL{Path: fmt.Sprintf("/notes/%d", i)},
+			PublishedAt: published.AddDate(0, 0, i),
+			Content:     domain.Content{Text: fmt.Sprintf("Note #%d", i)},
+			Tags:        []string{"note"},
+		}
+
+		if i%2 == 0 {
+			e.Tags = append(e.Tags, "even")
+		}
+
+		if err := entries.Create(context.Background(), e.URL.Path, e); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := searcher.Index(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	draft := domain.Entry{
+		URL:         &url.URL{Path: "/notes/draft"},
+		PublishedAt: published.AddDate(1, 0, 0),
+		Content:     domain.Content{Text: "Draft note"},
+		Status:      domain.PostStatusDraft,
+	}
+	if err := entries.Create(context.Background(), draft.URL.Path, draft); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := searcher.Index(context.Background(), draft); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted := domain.Entry{
+		URL:         &url.URL{Path: "/notes/deleted"},
+		PublishedAt: published,
+		DeletedAt:   published.AddDate(0, 1, 0),
+		Content:     domain.Content{Text: "Deleted note"},
+	}
+	if err := entries.Create(context.Background(), deleted.URL.Path, deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := web.NewHandler(entryucase.NewEntryUseCase(entries), searcher, redirect.NewDummyUseCase(),
+		webmention.NewDummyUseCase(), matcher, *config)
+
+	get := func(tb testing.TB, target string, status int) string {
+		tb.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		resp := w.Result()
+		if resp.StatusCode != status {
+			tb.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, status)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		return string(body)
+	}
+
+	t.Run("home", func(t *testing.T) {
+		t.Parallel()
+
+		body := get(t, "https://example.com/", http.StatusOK)
+
+		for _, expect := range []string{`class="h-feed"`, "Note #24", `rel="next"`} {
+			if !strings.Contains(body, expect) {
+				t.Errorf("expect %s in body, got:\n%s", expect, body)
+			}
+		}
+
+		for _, unexpect := range []string{"Draft note", "Note #4<", `rel="prev"`} {
+			if strings.Contains(body, unexpect) {
+				t.Errorf("unexpect %s in body, got:\n%s", unexpect, body)
+			}
+		}
+
+		next := regexp.MustCompile(`href="([^"]+)"\s+rel="next"`).FindStringSubmatch(body)
+		if next == nil {
+			t.Fatal("expect next page link")
+		}
+
+		body = get(t, "https://example.com"+html.UnescapeString(next[1]), http.StatusOK)
+
+		for _, expect := range []string{"Note #4<", "Note #0<", `rel="prev"`} {
+			if !strings.Contains(body, expect) {
+				t.Errorf("expect %s in next page body, got:\n%s", expect, body)
+			}
+		}
+
+		if strings.Contains(body, "Note #5<") || strings.Contains(body, `rel="next"`) {
+			t.Errorf("expect only the last entries on next page, got:\n%s", body)
+		}
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		t.Parallel()
+
+		body := get(t, "https://example.com/tags/even", http.StatusOK)
+		if strings.Contains(body, "Note #23<") || !strings.Contains(body, "Note #24<") {
+			t.Errorf("expect only tagged entries, got:\n%s", body)
+		}
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		t.Parallel()
+
+		body := get(t, "https://example.com/2023/01/", http.StatusOK)
+		if !strings.Contains(body, "Note #24<") || !strings.Contains(body, "Archive for 2023-01") {
+			t.Errorf("expect archive of month, got:\n%s", body)
+		}
+
+		if body = get(t, "https://example.com/2022", http.StatusOK); !strings.Contains(body, "Nothing here yet.") {
+			t.Errorf("expect empty archive, got:\n%s", body)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		t.Parallel()
+
+		body := get(t, "https://example.com/search?q=notes", http.StatusOK)
+		if !strings.Contains(body, `Search results for &quot;notes&quot;`) || !strings.Contains(body, `rel="next"`) ||
+			strings.Contains(body, "Draft note") {
+			t.Errorf("expect first page of published entries, got:\n%s", body)
+		}
+
+		body = get(t, "https://example.com/search?q=notes&page=2", http.StatusOK)
+		if !strings.Contains(body, `rel="prev"`) || strings.Contains(body, `rel="next"`) {
+			t.Errorf("expect the last page, got:\n%s", body)
+		}
+
+		if body = get(t, "https://example.com/search?q=unknown", http.StatusOK); !strings.Contains(body,
+			"Nothing found.") {
+			t.Errorf("expect empty results, got:\n%s", body)
+		}
+
+		get(t, "https://example.com/search?q=notes&page=0", http.StatusBadRequest)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+
+		get(t, "https://example.com/?after=%21", http.StatusBadRequest)
+	})
+}
+
+func TestHandler_ListHubs(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	config.WebSub.Hubs = []string{"https://hub.example/"}
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	e := domain.Entry{
+		URL:         &url.URL{Path: "/notes/1"},
+		PublishedAt: time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Content:     domain.Content{Text: "Note"},
+		Tags:        []string{"even"},
+	}
+
+	if err := entries.Create(context.Background(), e.URL.Path, e); err != nil {
+		t.Fatal(err)
+	}
+
+	topics := make(map[string]struct{})
+	for _, topic := range publisher.NewPublisher(*config, log.New(io.Discard, "", 0)).Topics(e) {
+		topics[topic.String()] = struct{}{}
+	}
+
+	handler := web.NewHandler(entryucase.NewEntryUseCase(entries), search.NewDummyUseCase(),
+		redirect.NewDummyUseCase(), webmention.NewDummyUseCase(), language.NewMatcher([]language.Tag{language.English}),
+		*config)
+
+	for _, target := range []string{
+		"https://example.com/",
+		"https://example.com/tags/even",
+		"https://example.com/2023",
+		"https://example.com/2023/01/",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		links := strings.Join(w.Result().Header.Values(common.HeaderLink), ", ")
+		if !strings.Contains(links, `<https://hub.example/>; rel="hub"`) {
+			t.Errorf("%s %s: expect hub in Link header, got %s", req.Method, target, links)
+		}
+
+		self := regexp.MustCompile(`<([^>]+)>; rel="self"`).FindStringSubmatch(links)
+		if self == nil {
+			t.Errorf("%s %s: expect self in Link header, got %s", req.Method, target, links)
+
+			continue
+		}
+
+		// NOTE(toby3d): subscribers of page must be notified about it's
+		// changes by publisher.
+		if _, ok := topics[self[1]]; !ok {
+			t.Errorf("%s %s: self %s is not a published topic", req.Method, target, self[1])
+		}
+	}
+}
+func TestTrashHandler(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	matcher := language.NewMatcher([]language.Tag{language.English})
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, e := range []domain.Entry{{
+		URL:         &url.URL{Path: "/trash"},
+		PublishedAt: published,
+		Content:     domain.Content{Text: "Note about trash"},
+	}, {
+		URL:         &url.URL{Path: "/notes/deleted"},
+		PublishedAt: published,
+		DeletedAt:   published.AddDate(0, 1, 0),
+		Content:     domain.Content{Text: "Deleted note"},
+	}} {
+		if err := entries.Create(context.Background(), e.URL.Path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entryUseCase := entryucase.NewEntryUseCase(entries)
+
+	for name, tc := range map[string]struct {
+		handler http.Handler
+		target  string
+		expect  []string
+		absent  []string
+	}{
+		"trash": {
+			handler: web.NewTrashHandler(entryUseCase, matcher, *config),
+			target:  "https://example.com/editor/trash",
+			expect:  []string{"/notes/deleted", `value="purge"`, `value="undelete"`},
+			absent:  []string{"Note about trash"},
+		},
+		"entry": {
+			handler: web.NewHandler(entryUseCase, search.NewDummyUseCase(), redirect.NewDummyUseCase(),
+				webmention.NewDummyUseCase(), matcher, *config),
+			target: "https://example.com/trash",
+			expect: []string{"Note about trash"},
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			w := httptest.NewRecorder()
+			tc.handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, expect := range tc.expect {
+				if !strings.Contains(string(body), expect) {
+					t.Errorf("expect %s in body, got:\n%s", expect, body)
+				}
+			}
+
+			for _, absent := range tc.absent {
+				if strings.Contains(string(body), absent) {
+					t.Errorf("expect no %s in body, got:\n%s", absent, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package entry
+
+import (
+	"context"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	// Hook receives entry changes after they was stored, like for sending
+	// notifications. Before is nil for created entries.
+	//
+	// Hook MUST NOT use ctx after return, because it is bound to the
+	// request lifetime.
+	Hook interface {
+		Handle(ctx context.Context, action domain.Action, before, after *domain.Entry)
+	}
+
+	// HookFunc is an adapter to allow the use of ordinary functions as a
+	// Hook.
+	HookFunc func(ctx context.Context, action domain.Action, before, after *domain.Entry)
+
+	spyHook struct {
+		mutex   *sync.Mutex
+		actions []domain.Action
+	}
+)
+
+func (f HookFunc) Handle(ctx context.Context, action domain.Action, before, after *domain.Entry) {
+	f(ctx, action, before, after)
+}
+
+// NewSpyHook creates a hook which records all received actions.
+func NewSpyHook() *spyHook {
+	return &spyHook{
+		mutex:   new(sync.Mutex),
+		actions: make([]domain.Action, 0),
+	}
+}
+
+func (h *spyHook) Handle(_ context.Context, action domain.Action, _, _ *domain.Entry) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.actions = append(h.actions, action)
+}
+
+// Actions returns copy of all received actions in order.
+func (h *spyHook) Actions() []domain.Action {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append(make([]domain.Action, 0, len(h.actions)), h.actions...)
+}
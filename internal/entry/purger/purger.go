@@ -0,0 +1,84 @@
+// Package purger provides a background purging of entries which stays deleted
+// longer than retention period.
+package purger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+)
+
+type Purger struct {
+	entries entry.UseCase
+	logger  *log.Logger
+	now     func() time.Time
+	config  domain.ConfigTrash
+}
+
+func NewPurger(entries entry.UseCase, config domain.ConfigTrash, logger *log.Logger) *Purger {
+	return &Purger{
+		entries: entries,
+		logger:  logger,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// Purge permanently removes all entries deleted earlier than retention period
+// and returns their number.
+func (p *Purger) Purge(ctx context.Context) (int, error) {
+	page, err := p.entries.Fetch(ctx, entry.Query{Deleted: true})
+	if err != nil {
+		return 0, fmt.Errorf("cannot fetch deleted entries: %w", err)
+	}
+
+	deadline := p.now().Add(-p.config.Retention)
+	count := 0
+
+	for i := range page.Entries {
+		if page.Entries[i].DeletedAt.After(deadline) {
+			continue
+		}
+
+		if err = p.entries.Purge(ctx, page.Entries[i].URL); err != nil {
+			return count, fmt.Errorf("cannot purge '%s': %w", page.Entries[i].URL, err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// Run purges expired entries every interval until ctx is done. Zero retention
+// keeps deleted entries forever.
+func (p *Purger) Run(ctx context.Context) {
+	if p.config.Retention <= 0 || p.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := p.Purge(ctx)
+			if err != nil {
+				p.logger.Println("cannot purge expired entries:", err)
+
+				continue
+			}
+
+			if count > 0 {
+				p.logger.Printf("purged %d entries deleted earlier than %s", count, p.config.Retention)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package purger_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/entry/purger"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	config := domain.TestConfig(t).Trash
+	repo := entrymemoryrepo.NewMemoryEntryRepository()
+
+	for name, deletedAt := range map[string]time.Time{
+		"expired": time.Now().UTC().Add(-2 * config.Retention),
+		"recent":  time.Now().UTC(),
+		"alive":   {},
+	} {
+		e := domain.TestEntry(t)
+		e.URL = &url.URL{Path: "/notes/" + name}
+		e.DeletedAt = deletedAt
+
+		if err := repo.Create(ctx, e.URL.Path, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := entryucase.NewEntryUseCase(repo)
+
+	count, err := purger.NewPurger(entries, config, log.New(io.Discard, "", 0)).Purge(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Errorf("expect 1 purged entry, got %d", count)
+	}
+
+	out, _, err := repo.Fetch(ctx, entry.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make(map[string]bool)
+	for i := range out {
+		paths[out[i].URL.Path] = true
+	}
+
+	if diff := cmp.Diff(map[string]bool{"/notes/recent": true, "/notes/alive": true}, paths); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,204 @@
+package entry
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	// Query filters, orders and limits fetched entries. Entries are ordered
+	// by publication date, then by path. Zero values means no filtering.
+	Query struct {
+		From   time.Time         // published at or after
+		To     time.Time         // published before
+		Type   domain.PostType   // discovered post type
+		Status domain.PostStatus // post-status
+		Order  Order             // newest first by default
+		Prefix string            // path prefix
+		Tag    string            // case-insensitive p-category
+		After  string            // cursor of entry, only entries after it are returned
+		Before string            // cursor of entry, only entries before it are returned
+		Limit  int
+		// Published skips drafts and deleted entries.
+		Published bool
+		// SkipDeleted skips deleted entries, but keeps drafts.
+		SkipDeleted bool
+		// Deleted returns only deleted entries, the trash.
+		Deleted bool
+	}
+
+	// Order describes a direction of entries ordering.
+	Order struct {
+		order string
+	}
+
+	// Page represents a single page of fetched entries with cursors of the
+	// neighbour pages. Empty cursor means there is no such page.
+	Page struct {
+		Prev    string
+		Next    string
+		Entries []domain.Entry
+	}
+
+	// Cursor points to a position of entry in ordered list of entries. It
+	// stays valid even if entry itself is deleted.
+	Cursor struct {
+		Date time.Time
+		Path string
+	}
+)
+
+var (
+	OrderUnd    = Order{}         // "und"
+	OrderNewest = Order{"newest"} // "newest"
+	OrderOldest = Order{"oldest"} // "oldest"
+)
+
+var ErrOrderSyntax error = domain.Error{
+	Description: fmt.Sprintf("got unsupported order, expect '%s' or '%s'", OrderNewest, OrderOldest),
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusBadRequest,
+}
+
+var stringsOrders = map[string]Order{
+	OrderNewest.order: OrderNewest,
+	OrderOldest.order: OrderOldest,
+}
+
+var ErrCursorSyntax error = domain.Error{
+	Description: "got invalid pagination cursor",
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusBadRequest,
+}
+
+func ParseOrder(v string) (Order, error) {
+	if out, ok := stringsOrders[strings.ToLower(v)]; ok {
+		return out, nil
+	}
+
+	return OrderUnd, fmt.Errorf("cannot parse '%s' as order: %w", v, ErrOrderSyntax)
+}
+
+func (o Order) String() string {
+	if o.order == "" {
+		return "und"
+	}
+
+	return o.order
+}
+
+func (o Order) GoString() string {
+	return "entry.Order(" + o.String() + ")"
+}
+
+// NewCursor creates a cursor of provided entry. Path is normalized the same
+// way as repositories key stored entries.
+func NewCursor(e domain.Entry) Cursor {
+	out := Cursor{Date: e.Date().UTC()}
+	if e.URL != nil {
+		out.Path = path.Clean(strings.ToLower(e.URL.Path))
+	}
+
+	return out
+}
+
+// ParseCursor decodes cursor from it's opaque string representation.
+func ParseCursor(v string) (*Cursor, error) {
+	src, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode cursor: %w", ErrCursorSyntax)
+	}
+
+	date, p, ok := strings.Cut(string(src), ":")
+	if !ok {
+		return nil, fmt.Errorf("cannot parse cursor: %w", ErrCursorSyntax)
+	}
+
+	nsec, err := strconv.ParseInt(date, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cursor date: %w", ErrCursorSyntax)
+	}
+
+	return &Cursor{Date: time.Unix(0, nsec).UTC(), Path: p}, nil
+}
+
+// Less reports whether entries with cursor c goes before entries with cursor
+// target in list ordered newest first.
+func (c Cursor) Less(target Cursor) bool {
+	if !c.Date.Equal(target.Date) {
+		return c.Date.After(target.Date)
+	}
+
+	return c.Path < target.Path
+}
+
+// String returns opaque representation of cursor for use in URLs.
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Date.UnixNano(), 10) + ":" + c.Path))
+}
+
+// Less reports whether entry with cursor a goes before entry with cursor b in
+// list ordered by query.
+func (q Query) Less(a, b Cursor) bool {
+	if q.Order == OrderOldest {
+		return b.Less(a)
+	}
+
+	return a.Less(b)
+}
+
+// Match reports whether entry satisfies query filters. Cursors and limit are
+// not checked.
+func (q Query) Match(e domain.Entry) bool {
+	if (q.Published && !e.IsPublished()) || (q.SkipDeleted && !e.DeletedAt.IsZero()) ||
+		(q.Deleted && e.DeletedAt.IsZero()) {
+		return false
+	}
+
+	if q.Status != domain.PostStatusUnd && status(e) != q.Status {
+		return false
+	}
+
+	if q.Type != domain.PostTypeUnd && e.Type() != q.Type {
+		return false
+	}
+
+	if q.Prefix != "" && (e.URL == nil || !strings.HasPrefix(strings.ToLower(e.URL.Path), strings.ToLower(q.Prefix))) {
+		return false
+	}
+
+	date := e.Date()
+	if (!q.From.IsZero() && date.Before(q.From)) || (!q.To.IsZero() && !date.Before(q.To)) {
+		return false
+	}
+
+	if q.Tag == "" {
+		return true
+	}
+
+	for _, tag := range e.Tags {
+		if strings.EqualFold(tag, q.Tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// status returns post status of entry. Entries without status are published.
+func status(e domain.Entry) domain.PostStatus {
+	if e.Status == domain.PostStatusUnd {
+		return domain.PostStatusPublished
+	}
+
+	return e.Status
+}
@@ -3,6 +3,9 @@ package entry
 import (
 	"context"
 	"errors"
+	"net/http"
+
+	"golang.org/x/xerrors"
 
 	"source.toby3d.me/toby3d/pub/internal/domain"
 )
@@ -13,7 +16,14 @@ type (
 	Repository interface {
 		Create(ctx context.Context, path string, e domain.Entry) error
 		Get(ctx context.Context, path string) (*domain.Entry, error)
-		Fetch(ctx context.Context, path string) ([]domain.Entry, int, error)
+		// Fetch returns ordered entries matched by query and total number
+		// of matched entries regardless of cursors and limit.
+		Fetch(ctx context.Context, query Query) ([]domain.Entry, int, error)
+		// Update applies update func to the stored entry and stores
+		// result with the next Version. Returns ErrConflict if entry was
+		// changed by someone else while update func is applied. Entry
+		// with changed URL is moved to the new path, ErrExist is
+		// returned if it is already taken.
 		Update(ctx context.Context, path string, update UpdateFunc) (*domain.Entry, error)
 		Delete(ctx context.Context, path string) (bool, error)
 	}
@@ -44,6 +54,12 @@ type (
 var (
 	ErrExist    error = errors.New("this entry already exist")
 	ErrNotExist error = errors.New("this entry is not exist")
+
+	ErrConflict error = domain.Error{
+		Description: "this entry was changed since it was requested",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusPreconditionFailed,
+	}
 )
 
 // NewDummyMediaRepository creates an empty repository to satisfy contracts.
@@ -56,7 +72,7 @@ func (dummyRepository) Create(_ context.Context, _ string, _ domain.Entry) error
 func (dummyRepository) Delete(_ context.Context, _ string) (bool, error)         { return false, nil }
 func (dummyRepository) Get(_ context.Context, _ string) (*domain.Entry, error)   { return nil, nil }
 
-func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.Entry, int, error) {
+func (dummyRepository) Fetch(_ context.Context, _ Query) ([]domain.Entry, int, error) {
 	return make([]domain.Entry, 0), 0, nil
 }
 
@@ -84,7 +100,7 @@ func (repo *stubRepository) Delete(ctx context.Context, path string) (bool, erro
 	return repo.ok, repo.err
 }
 
-func (repo *stubRepository) Fetch(ctx context.Context, path string) ([]domain.Entry, int, error) {
+func (repo *stubRepository) Fetch(ctx context.Context, query Query) ([]domain.Entry, int, error) {
 	return repo.outputs, len(repo.outputs), repo.err
 }
 
@@ -126,10 +142,10 @@ func (repo *spyRepository) Delete(ctx context.Context, path string) (bool, error
 	return repo.subRepository.Delete(ctx, path)
 }
 
-func (repo *spyRepository) Fetch(ctx context.Context, path string) ([]domain.Entry, int, error) {
+func (repo *spyRepository) Fetch(ctx context.Context, query Query) ([]domain.Entry, int, error) {
 	repo.Fetches++
 
-	return repo.subRepository.Fetch(ctx, path)
+	return repo.subRepository.Fetch(ctx, query)
 }
 
 func (repo *spyRepository) Get(ctx context.Context, path string) (*domain.Entry, error) {
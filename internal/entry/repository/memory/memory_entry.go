@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 
@@ -12,33 +13,46 @@ import (
 	"source.toby3d.me/toby3d/pub/internal/entry"
 )
 
-type memoryEntryRepository struct {
-	mutex   *sync.RWMutex
-	entries map[string]domain.Entry
-}
+type (
+	memoryEntryRepository struct {
+		mutex   *sync.RWMutex
+		entries map[string]domain.Entry
+
+		// NOTE(toby3d): secondary indexes, updated together with
+		// entries under the same lock.
+		ordered []string                  // paths, newest entries first
+		tags    map[string]paths          // lowercased tag → entries paths
+		types   map[domain.PostType]paths // post type → entries paths
+	}
+
+	paths map[string]struct{}
+)
 
 func NewMemoryEntryRepository() entry.Repository {
 	return &memoryEntryRepository{
 		mutex:   new(sync.RWMutex),
 		entries: make(map[string]domain.Entry),
+		ordered: make([]string, 0),
+		tags:    make(map[string]paths),
+		types:   make(map[domain.PostType]paths),
 	}
 }
 
 func (repo *memoryEntryRepository) Create(ctx context.Context, p string, e domain.Entry) error {
 	p = path.Clean(strings.ToLower(p))
 
-	_, err := repo.Get(ctx, p)
-	if err != nil && !errors.Is(err, entry.ErrNotExist) {
-		return fmt.Errorf("cannot save a new entry: %w", err)
-	}
-	if err == nil {
-		return entry.ErrExist
-	}
-
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
+	// NOTE(toby3d): path MUST be checked under the same lock as insert,
+	// otherwise concurrent creations duplicates it in indexes.
+	if _, ok := repo.entries[p]; ok {
+		return entry.ErrExist
+	}
+
+	e.Version = 1
 	repo.entries[p] = e
+	repo.index(p, e)
 
 	return nil
 }
@@ -54,21 +68,70 @@ func (repo *memoryEntryRepository) Get(ctx context.Context, p string) (*domain.E
 	return nil, entry.ErrNotExist
 }
 
-func (repo *memoryEntryRepository) Fetch(ctx context.Context, p string) ([]domain.Entry, int, error) {
+func (repo *memoryEntryRepository) Fetch(ctx context.Context, query entry.Query) ([]domain.Entry, int, error) {
+	var after, before *entry.Cursor
+
+	var err error
+	if query.After != "" {
+		if after, err = entry.ParseCursor(query.After); err != nil {
+			return nil, 0, fmt.Errorf("cannot fetch entries: %w", err)
+		}
+	}
+
+	if query.Before != "" {
+		if before, err = entry.ParseCursor(query.Before); err != nil {
+			return nil, 0, fmt.Errorf("cannot fetch entries: %w", err)
+		}
+	}
+
 	repo.mutex.RLock()
 	defer repo.mutex.RUnlock()
 
-	out := make([]domain.Entry, 0)
+	// NOTE(toby3d): cursors are compared by stored keys, the same ones
+	// paths are ordered by.
+	keys := make([]string, 0)
 
-	for entryPath, e := range repo.entries {
-		if matched, err := path.Match(p+"*", entryPath); err != nil || !matched {
-			continue
+	for _, p := range repo.candidates(query) {
+		if query.Match(repo.entries[p]) {
+			keys = append(keys, p)
 		}
+	}
 
-		out = append(out, e)
+	if query.Order == entry.OrderOldest {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
 	}
 
-	return out, len(out), nil
+	count := len(keys)
+
+	if after != nil {
+		keys = keys[sort.Search(len(keys), func(i int) bool {
+			return query.Less(*after, repo.cursor(keys[i]))
+		}):]
+	}
+
+	if before != nil {
+		keys = keys[:sort.Search(len(keys), func(i int) bool {
+			return !query.Less(repo.cursor(keys[i]), *before)
+		})]
+	}
+
+	if query.Limit > 0 && len(keys) > query.Limit {
+		// NOTE(toby3d): page before cursor is the closest to it.
+		if before != nil && after == nil {
+			keys = keys[len(keys)-query.Limit:]
+		} else {
+			keys = keys[:query.Limit]
+		}
+	}
+
+	out := make([]domain.Entry, 0, len(keys))
+	for _, p := range keys {
+		out = append(out, repo.entries[p])
+	}
+
+	return out, count, nil
 }
 
 func (repo *memoryEntryRepository) Update(ctx context.Context, p string, update entry.UpdateFunc) (*domain.Entry, error) {
@@ -77,18 +140,38 @@ func (repo *memoryEntryRepository) Update(ctx context.Context, p string, update
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
-	if in, ok := repo.entries[p]; ok {
-		out, err := update(ctx, &in)
-		if err != nil {
-			return nil, fmt.Errorf("cannot update entry: %w", err)
-		}
+	before, ok := repo.entries[p]
+	if !ok {
+		return nil, fmt.Errorf("cannot update entry: %w", entry.ErrNotExist)
+	}
 
-		repo.entries[p] = *out
+	// NOTE(toby3d): update func may change input in place, so it MUST NOT
+	// share slices with stored entry.
+	in := before.Clone()
 
-		return out, err
+	out, err := update(ctx, &in)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update entry: %w", err)
 	}
 
-	return nil, fmt.Errorf("cannot update entry: %w", entry.ErrNotExist)
+	// NOTE(toby3d): entry with replaced URL moves to the new path.
+	moved := p
+	if out.URL != nil {
+		moved = path.Clean(strings.ToLower(out.URL.RequestURI()))
+	}
+
+	if _, ok := repo.entries[moved]; ok && moved != p {
+		return nil, fmt.Errorf("cannot move entry: %w", entry.ErrExist)
+	}
+
+	out.Version = before.Version + 1
+
+	repo.unindex(p, before)
+	delete(repo.entries, p)
+	repo.entries[moved] = *out
+	repo.index(moved, *out)
+
+	return out, nil
 }
 
 func (repo *memoryEntryRepository) Delete(ctx context.Context, p string) (bool, error) {
@@ -103,7 +186,122 @@ func (repo *memoryEntryRepository) Delete(ctx context.Context, p string) (bool,
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
-	delete(repo.entries, path.Clean(strings.ToLower(p)))
+	p = path.Clean(strings.ToLower(p))
+	if e, ok := repo.entries[p]; ok {
+		repo.unindex(p, e)
+	}
+
+	delete(repo.entries, p)
 
 	return true, nil
 }
+
+// candidates returns paths of entries which may match query, newest first.
+// The most selective index is used, or the whole date range otherwise.
+func (repo *memoryEntryRepository) candidates(query entry.Query) []string {
+	var subset paths
+
+	if query.Tag != "" {
+		subset = repo.tags[strings.ToLower(query.Tag)]
+	}
+
+	if query.Type != domain.PostTypeUnd {
+		if typed := repo.types[query.Type]; subset == nil || len(typed) < len(subset) {
+			subset = typed
+		}
+	}
+
+	if query.Tag != "" || query.Type != domain.PostTypeUnd {
+		out := make([]string, 0, len(subset))
+		for p := range subset {
+			out = append(out, p)
+		}
+
+		sort.Slice(out, func(i, j int) bool {
+			return repo.cursor(out[i]).Less(repo.cursor(out[j]))
+		})
+
+		return out
+	}
+
+	start, end := 0, len(repo.ordered)
+
+	if !query.To.IsZero() {
+		start = sort.Search(len(repo.ordered), func(i int) bool {
+			return repo.entries[repo.ordered[i]].Date().Before(query.To)
+		})
+	}
+
+	if !query.From.IsZero() {
+		end = sort.Search(len(repo.ordered), func(i int) bool {
+			return repo.entries[repo.ordered[i]].Date().Before(query.From)
+		})
+	}
+
+	if start > end {
+		return nil
+	}
+
+	return repo.ordered[start:end]
+}
+
+// index adds entry on path p into secondary indexes. Caller MUST hold the
+// write lock.
+func (repo *memoryEntryRepository) index(p string, e domain.Entry) {
+	i := repo.search(repo.cursor(p))
+
+	repo.ordered = append(repo.ordered, "")
+	copy(repo.ordered[i+1:], repo.ordered[i:])
+	repo.ordered[i] = p
+
+	for _, tag := range e.Tags {
+		tag = strings.ToLower(tag)
+		if repo.tags[tag] == nil {
+			repo.tags[tag] = make(paths)
+		}
+
+		repo.tags[tag][p] = struct{}{}
+	}
+
+	postType := e.Type()
+	if repo.types[postType] == nil {
+		repo.types[postType] = make(paths)
+	}
+
+	repo.types[postType][p] = struct{}{}
+}
+
+// unindex removes entry on path p from secondary indexes. Caller MUST hold
+// the write lock.
+func (repo *memoryEntryRepository) unindex(p string, e domain.Entry) {
+	if i := repo.search(repo.cursor(p)); i < len(repo.ordered) && repo.ordered[i] == p {
+		repo.ordered = append(repo.ordered[:i], repo.ordered[i+1:]...)
+	}
+
+	for _, tag := range e.Tags {
+		tag = strings.ToLower(tag)
+		if delete(repo.tags[tag], p); len(repo.tags[tag]) == 0 {
+			delete(repo.tags, tag)
+		}
+	}
+
+	postType := e.Type()
+	if delete(repo.types[postType], p); len(repo.types[postType]) == 0 {
+		delete(repo.types, postType)
+	}
+}
+
+// search returns position of cursor in ordered paths.
+func (repo *memoryEntryRepository) search(cursor entry.Cursor) int {
+	return sort.Search(len(repo.ordered), func(i int) bool {
+		return !repo.cursor(repo.ordered[i]).Less(cursor)
+	})
+}
+
+// cursor returns cursor of stored entry on path p.
+func (repo *memoryEntryRepository) cursor(p string) entry.Cursor {
+	out := entry.NewCursor(repo.entries[p])
+	out.Path = p
+
+	return out
+}
@@ -0,0 +1,392 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		e := domain.Entry{
+			URL:         &url.URL{Path: "/notes/" + strconv.Itoa(i)},
+			PublishedAt: published.AddDate(0, i, 0),
+			Content:     domain.Content{Text: "Note"},
+		}
+
+		if i%2 == 0 {
+			e.Tags = []string{"Even"}
+		}
+
+		if i%3 == 0 {
+			e.Photo = []*url.URL{{Path: "/media/" + strconv.Itoa(i) + ".jpg"}}
+		}
+
+		if i == 5 {
+			e.Status = domain.PostStatusDraft
+		}
+
+		if err := repo.Create(context.Background(), e.URL.Path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// NOTE(toby3d): entry with the same date is ordered by path.
+	if err := repo.Create(context.Background(), "/articles/1", domain.Entry{
+		URL:         &url.URL{Path: "/articles/1"},
+		PublishedAt: published.AddDate(0, 1, 0),
+		Title:       "Article",
+		Content:     domain.Content{Text: "Text"},
+		Tags:        []string{"even"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		query  entry.Query
+		expect []string
+		count  int
+	}{
+		"all": {
+			query:  entry.Query{},
+			expect: []string{"/notes/5", "/notes/4", "/notes/3", "/notes/2", "/articles/1", "/notes/1", "/notes/0"},
+			count:  7,
+		},
+		"oldest": {
+			query:  entry.Query{Order: entry.OrderOldest, Limit: 3},
+			expect: []string{"/notes/0", "/notes/1", "/articles/1"},
+			count:  7,
+		},
+		"prefix": {
+			query:  entry.Query{Prefix: "/articles/"},
+			expect: []string{"/articles/1"},
+			count:  1,
+		},
+		"range": {
+			query:  entry.Query{From: published.AddDate(0, 1, 0), To: published.AddDate(0, 3, 0)},
+			expect: []string{"/notes/2", "/articles/1", "/notes/1"},
+			count:  3,
+		},
+		"tag": {
+			query:  entry.Query{Tag: "EVEN"},
+			expect: []string{"/notes/4", "/notes/2", "/articles/1", "/notes/0"},
+			count:  4,
+		},
+		"type": {
+			query:  entry.Query{Type: domain.PostTypePhoto},
+			expect: []string{"/notes/3", "/notes/0"},
+			count:  2,
+		},
+		"tag and type": {
+			query:  entry.Query{Tag: "even", Type: domain.PostTypeArticle},
+			expect: []string{"/articles/1"},
+			count:  1,
+		},
+		"status": {
+			query:  entry.Query{Status: domain.PostStatusDraft},
+			expect: []string{"/notes/5"},
+			count:  1,
+		},
+		"published": {
+			query:  entry.Query{Published: true, Limit: 2},
+			expect: []string{"/notes/4", "/notes/3"},
+			count:  6,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, count, err := repo.Fetch(context.Background(), tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != tc.count {
+				t.Errorf("expect %d matched entries, got %d", tc.count, count)
+			}
+
+			if diff := cmp.Diff(paths(out), tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFetch_Cursor(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 4; i++ {
+		e := domain.Entry{URL: &url.URL{Path: "/notes/" + strconv.Itoa(i)}, PublishedAt: published.AddDate(0, 0, i)}
+		if err := repo.Create(context.Background(), e.URL.Path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	third, err := repo.Get(context.Background(), "/notes/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor := entry.NewCursor(*third).String()
+
+	// NOTE(toby3d): cursor stays valid after entry deletion.
+	if _, err = repo.Delete(context.Background(), "/notes/2"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		query  entry.Query
+		expect []string
+	}{
+		"after":         {query: entry.Query{After: cursor}, expect: []string{"/notes/1", "/notes/0"}},
+		"before":        {query: entry.Query{Before: cursor, Limit: 1}, expect: []string{"/notes/3"}},
+		"oldest after":  {query: entry.Query{After: cursor, Order: entry.OrderOldest}, expect: []string{"/notes/3"}},
+		"oldest before": {query: entry.Query{Before: cursor, Order: entry.OrderOldest}, expect: []string{"/notes/0", "/notes/1"}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, _, err := repo.Fetch(context.Background(), tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(paths(out), tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, _, err = repo.Fetch(context.Background(), entry.Query{After: "!"}); !errors.Is(err, entry.ErrCursorSyntax) {
+		t.Errorf("expect %v error, got %v", entry.ErrCursorSyntax, err)
+	}
+}
+
+func TestFetch_CursorMixedCase(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// NOTE(toby3d): same date, so entries are ordered by path only.
+	for _, p := range []string{"/Notes/A/", "/notes/A-b", "/NOTES/c"} {
+		e := domain.Entry{URL: &url.URL{Path: p}, PublishedAt: published}
+		if err := repo.Create(context.Background(), e.URL.Path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages := make([][]string, 0)
+
+	for query := (entry.Query{Limit: 1}); len(pages) <= 3; {
+		out, _, err := repo.Fetch(context.Background(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(out) == 0 {
+			break
+		}
+
+		pages = append(pages, paths(out))
+		query.After = entry.NewCursor(out[len(out)-1]).String()
+	}
+
+	if diff := cmp.Diff(pages, [][]string{{"/Notes/A/"}, {"/notes/A-b"}, {"/NOTES/c"}}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdate_Index(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+
+	if err := repo.Create(context.Background(), e.URL.Path, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Update(context.Background(), e.URL.Path, func(_ context.Context, in *domain.Entry) (
+		*domain.Entry, error,
+	) {
+		in.Tags = []string{"updated"}
+
+		return in, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for tag, expect := range map[string]int{e.Tags[0]: 0, "updated": 1} {
+		if out, _, err := repo.Fetch(context.Background(), entry.Query{Tag: tag}); err != nil {
+			t.Error(err)
+		} else if len(out) != expect {
+			t.Errorf("expect %d entries tagged by %s, got %d", expect, tag, len(out))
+		}
+	}
+}
+
+func TestUpdate_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+	e.Tags = nil
+
+	if err := repo.Create(context.Background(), e.URL.Path, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	const updates int = 8
+
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+
+		go func(tag string) {
+			defer wg.Done()
+
+			if _, err := repo.Update(context.Background(), e.URL.Path, func(_ context.Context, in *domain.Entry) (
+				*domain.Entry, error,
+			) {
+				in.Tags = append(in.Tags, tag)
+
+				return in, nil
+			}); err != nil {
+				t.Error(err)
+			}
+		}(strconv.Itoa(i))
+	}
+
+	wg.Wait()
+
+	out, err := repo.Get(context.Background(), e.URL.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE(toby3d): no one of concurrent updates is lost.
+	if len(out.Tags) != updates || out.Version != uint64(updates)+1 {
+		t.Errorf("expect %d tags on version %d, got %v on version %d", updates, updates+1, out.Tags, out.Version)
+	}
+}
+
+func TestUpdate_Move(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	e, taken := domain.TestEntry(t), domain.TestEntry(t)
+	taken.URL = &url.URL{Path: "/samples/taken"}
+
+	for _, in := range []*domain.Entry{e, taken} {
+		if err := repo.Create(context.Background(), in.URL.Path, *in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	move := func(p string) entry.UpdateFunc {
+		return func(_ context.Context, in *domain.Entry) (*domain.Entry, error) {
+			in.URL = &url.URL{Path: p}
+
+			return in, nil
+		}
+	}
+
+	if _, err := repo.Update(context.Background(), e.URL.Path, move(taken.URL.Path)); !errors.Is(err,
+		entry.ErrExist) {
+		t.Errorf("expect %v for taken path, got %v", entry.ErrExist, err)
+	}
+
+	if _, err := repo.Update(context.Background(), e.URL.Path, move("/samples/moved")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Get(context.Background(), e.URL.Path); !errors.Is(err, entry.ErrNotExist) {
+		t.Errorf("expect %v on the old path, got %v", entry.ErrNotExist, err)
+	}
+
+	out, _, err := repo.Fetch(context.Background(), entry.Query{Tag: e.Tags[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(out), []string{"/samples/moved", taken.URL.Path}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCreate_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+	created := make(chan bool, 8)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < cap(created); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			created <- repo.Create(context.Background(), e.URL.Path, *e) == nil
+		}()
+	}
+
+	wg.Wait()
+	close(created)
+
+	count := 0
+
+	for ok := range created {
+		if ok {
+			count++
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expect only one created entry, got %d", count)
+	}
+
+	out, total, err := repo.Fetch(context.Background(), entry.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(out), []string{e.URL.Path}); diff != "" || total != 1 {
+		t.Errorf("%s, total: %d", diff, total)
+	}
+}
+
+func paths(entries []domain.Entry) []string {
+	out := make([]string, 0, len(entries))
+	for i := range entries {
+		out = append(out, entries[i].URL.Path)
+	}
+
+	return out
+}
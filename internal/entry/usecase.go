@@ -2,12 +2,19 @@ package entry
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 
+	"golang.org/x/xerrors"
+
 	"source.toby3d.me/toby3d/pub/internal/domain"
 )
 
 type (
+	// UpdateOptions describes changes of entry. Replace is a whole new
+	// state of entry, except of it's creation time. Add appends values to
+	// multi-valued properties and sets empty single-valued ones. Delete
+	// removes provided values. Options applies in this order.
 	UpdateOptions struct {
 		Add     *domain.Entry
 		Replace *domain.Entry
@@ -19,9 +26,9 @@ type (
 		// or created post, shortcode and syndication.
 		Create(ctx context.Context, e domain.Entry) (*domain.Entry, error)
 
-		// Update updates exist entry properties on provided u.
-		//
-		// TODO(toby3d): return Location header if entry updates their URL.
+		// Update updates exist entry properties on provided u. Entry
+		// with replaced URL is moved to it, so result URL MAY differ
+		// from provided one.
 		Update(ctx context.Context, u *url.URL, options UpdateOptions) (*domain.Entry, error)
 
 		// Delete destroy entry on provided URL.
@@ -30,8 +37,27 @@ type (
 		// Undelete recover deleted entry on provided URL.
 		Undelete(ctx context.Context, u *url.URL) (*domain.Entry, error)
 
+		// Purge permanently removes deleted entry on provided URL.
+		// Returns ErrNotDeleted if entry is not deleted yet.
+		Purge(ctx context.Context, u *url.URL) error
+
 		// Source returns properties of entry on provided URL.
 		Source(ctx context.Context, u *url.URL) (*domain.Entry, error)
+
+		// Fetch returns a page of entries matched by query with cursors
+		// of the neighbour pages. Deleted entries are returned only by
+		// query of the trash.
+		Fetch(ctx context.Context, query Query) (*Page, error)
+
+		// Categories returns tags of all not deleted entries which starts
+		// with filter prefix, the most used first.
+		Categories(ctx context.Context, filter string) ([]Category, error)
+	}
+
+	// Category represent a single tag with number of entries tagged by it.
+	Category struct {
+		Name  string
+		Count int
 	}
 
 	dummyUseCase struct{}
@@ -43,11 +69,17 @@ type (
 	}
 )
 
+var ErrNotDeleted error = domain.Error{
+	Description: "entry MUST be deleted before purging",
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusConflict,
+}
+
 func NewDummyUseCase() *dummyUseCase {
 	return &dummyUseCase{}
 }
 
-func (dummyUseCase) Create(_ context.Context, _ domain.Entry) (map[string]*url.URL, error) {
+func (dummyUseCase) Create(_ context.Context, _ domain.Entry) (*domain.Entry, error) {
 	return nil, nil
 }
 
@@ -58,6 +90,15 @@ func (dummyUseCase) Update(_ context.Context, _ *url.URL, _ UpdateOptions) (*dom
 func (dummyUseCase) Delete(_ context.Context, _ *url.URL) (bool, error)            { return false, nil }
 func (dummyUseCase) Undelete(_ context.Context, _ *url.URL) (*domain.Entry, error) { return nil, nil }
 func (dummyUseCase) Source(_ context.Context, _ *url.URL) (*domain.Entry, error)   { return nil, nil }
+func (dummyUseCase) Purge(_ context.Context, _ *url.URL) error                     { return nil }
+
+func (dummyUseCase) Fetch(_ context.Context, _ Query) (*Page, error) {
+	return &Page{Entries: make([]domain.Entry, 0)}, nil
+}
+
+func (dummyUseCase) Categories(_ context.Context, _ string) ([]Category, error) {
+	return make([]Category, 0), nil
+}
 
 func NewStubUseCase(err error, e *domain.Entry, ok bool) *stubUseCase {
 	return &stubUseCase{
@@ -86,3 +127,27 @@ func (ucase *stubUseCase) Undelete(_ context.Context, _ *url.URL) (*domain.Entry
 func (ucase *stubUseCase) Source(_ context.Context, _ *url.URL) (*domain.Entry, error) {
 	return ucase.entry, ucase.err
 }
+
+func (ucase *stubUseCase) Purge(_ context.Context, _ *url.URL) error {
+	return ucase.err
+}
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ Query) (*Page, error) {
+	out := &Page{Entries: make([]domain.Entry, 0, 1)}
+	if ucase.entry != nil {
+		out.Entries = append(out.Entries, *ucase.entry)
+	}
+
+	return out, ucase.err
+}
+
+func (ucase *stubUseCase) Categories(_ context.Context, _ string) ([]Category, error) {
+	out := make([]Category, 0)
+	if ucase.entry != nil {
+		for _, tag := range ucase.entry.Tags {
+			out = append(out, Category{Name: tag, Count: 1})
+		}
+	}
+
+	return out, ucase.err
+}
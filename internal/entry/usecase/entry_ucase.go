@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"source.toby3d.me/toby3d/pub/internal/domain"
@@ -12,6 +14,7 @@ import (
 
 type entryUseCase struct {
 	entries entry.Repository
+	hooks   []entry.Hook
 }
 
 // Create implements entry.UseCase.
@@ -35,23 +38,35 @@ func (ucase *entryUseCase) Create(ctx context.Context, e domain.Entry) (*domain.
 		return nil, fmt.Errorf("cannot source created entry: %w", err)
 	}
 
+	ucase.notify(ctx, domain.ActionCreate, nil, result)
+
 	return result, nil
 }
 
 // Delete implements entry.UseCase.
 func (ucase *entryUseCase) Delete(ctx context.Context, u *url.URL) (bool, error) {
-	if _, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
+	var before domain.Entry
+
+	result, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
 		*domain.Entry, error,
 	) {
+		if err := match(ctx, *e); err != nil {
+			return nil, err
+		}
+
+		before = *e
 		now := time.Now().UTC()
 		e.DeletedAt = now
 		e.UpdatedAt = now
 
 		return e, nil
-	}); err != nil {
-		return false, fmt.Errorf("cannot undelete entry: %w", err)
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot delete entry: %w", err)
 	}
 
+	ucase.notify(ctx, domain.ActionDelete, &before, result)
+
 	return true, nil
 }
 
@@ -65,46 +80,306 @@ func (ucase *entryUseCase) Source(ctx context.Context, u *url.URL) (*domain.Entr
 	return result, nil
 }
 
+// Purge implements entry.UseCase.
+func (ucase *entryUseCase) Purge(ctx context.Context, u *url.URL) error {
+	before, err := ucase.entries.Get(ctx, u.RequestURI())
+	if err != nil {
+		return fmt.Errorf("cannot purge entry: %w", err)
+	}
+
+	if before.DeletedAt.IsZero() {
+		return fmt.Errorf("cannot purge entry: %w", entry.ErrNotDeleted)
+	}
+
+	if err = match(ctx, *before); err != nil {
+		return fmt.Errorf("cannot purge entry: %w", err)
+	}
+
+	if _, err = ucase.entries.Delete(ctx, u.RequestURI()); err != nil {
+		return fmt.Errorf("cannot purge entry: %w", err)
+	}
+
+	ucase.notify(ctx, domain.ActionPurge, before, nil)
+
+	return nil
+}
+
+// Fetch implements entry.UseCase.
+func (ucase *entryUseCase) Fetch(ctx context.Context, query entry.Query) (*entry.Page, error) {
+	// NOTE(toby3d): deleted entries are available only in the trash.
+	query.SkipDeleted = query.SkipDeleted || !query.Deleted
+
+	limit := query.Limit
+	if limit > 0 {
+		// NOTE(toby3d): one more entry tells that the next page exists.
+		query.Limit++
+	}
+
+	entries, _, err := ucase.entries.Fetch(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch entries: %w", err)
+	}
+
+	out := &entry.Page{Entries: entries}
+	more := limit > 0 && len(entries) > limit
+
+	if query.Before != "" && query.After == "" {
+		if more {
+			out.Entries = out.Entries[1:]
+		}
+
+		if len(out.Entries) > 0 {
+			out.Next = entry.NewCursor(out.Entries[len(out.Entries)-1]).String()
+		}
+
+		if more {
+			out.Prev = entry.NewCursor(out.Entries[0]).String()
+		}
+
+		return out, nil
+	}
+
+	if more {
+		out.Entries = out.Entries[:limit]
+		out.Next = entry.NewCursor(out.Entries[len(out.Entries)-1]).String()
+	}
+
+	if query.After != "" && len(out.Entries) > 0 {
+		out.Prev = entry.NewCursor(out.Entries[0]).String()
+	}
+
+	return out, nil
+}
+
+// Categories implements entry.UseCase.
+func (ucase *entryUseCase) Categories(ctx context.Context, filter string) ([]entry.Category, error) {
+	entries, _, err := ucase.entries.Fetch(ctx, entry.Query{SkipDeleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch categories: %w", err)
+	}
+
+	filter = strings.ToLower(filter)
+	out := make([]entry.Category, 0)
+	indexes := make(map[string]int)
+
+	// NOTE(toby3d): tags are case-insensitive, the spelling of the newest
+	// entry wins.
+	for i := range entries {
+		for _, tag := range entries[i].Tags {
+			key := strings.ToLower(tag)
+			if !strings.HasPrefix(key, filter) {
+				continue
+			}
+
+			if j, ok := indexes[key]; ok {
+				out[j].Count++
+
+				continue
+			}
+
+			indexes[key] = len(out)
+			out = append(out, entry.Category{Name: tag, Count: 1})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+
+	return out, nil
+}
+
 // Undelete implements entry.UseCase.
 func (ucase *entryUseCase) Undelete(ctx context.Context, u *url.URL) (*domain.Entry, error) {
+	var before domain.Entry
+
 	result, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
 		*domain.Entry, error,
 	) {
+		if err := match(ctx, *e); err != nil {
+			return nil, err
+		}
+
+		before = *e
 		e.DeletedAt = time.Time{}
 		e.UpdatedAt = time.Now().UTC()
 
-		return nil, nil
+		return e, nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot undelete entry: %w", err)
 	}
 
+	ucase.notify(ctx, domain.ActionUndelete, &before, result)
+
 	return result, nil
 }
 
 // Update implements entry.UseCase.
 func (ucase *entryUseCase) Update(ctx context.Context, u *url.URL, opts entry.UpdateOptions) (*domain.Entry, error) {
+	var before domain.Entry
+
 	result, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
 		*domain.Entry, error,
 	) {
+		if err := match(ctx, *e); err != nil {
+			return nil, err
+		}
+
+		before = *e
+
+		if opts.Replace != nil {
+			createdAt, version := e.CreatedAt, e.Version
+			*e = *opts.Replace
+			e.CreatedAt, e.Version = createdAt, version
+		}
+
+		if opts.Add != nil {
+			add(e, *opts.Add)
+		}
+
+		if opts.Delete != nil {
+			remove(e, *opts.Delete)
+		}
+
 		e.DeletedAt = time.Time{}
 		e.UpdatedAt = time.Now().UTC()
 
-		// TODO(toby3d): add
-		// TODO(toby3d): update
-		// TODO(toby3d): delete
-
 		return e, nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot update entry: %w", err)
 	}
 
+	ucase.notify(ctx, domain.ActionUpdate, &before, result)
+
 	return result, nil
 }
 
-func NewEntryUseCase(entries entry.Repository) entry.UseCase {
+// NewEntryUseCase creates a new entry use case. Provided hooks are called in
+// order after each successful change.
+func NewEntryUseCase(entries entry.Repository, hooks ...entry.Hook) entry.UseCase {
 	return &entryUseCase{
 		entries: entries,
+		hooks:   hooks,
+	}
+}
+
+func (ucase *entryUseCase) notify(ctx context.Context, action domain.Action, before, after *domain.Entry) {
+	for i := range ucase.hooks {
+		ucase.hooks[i].Handle(ctx, action, before, after)
+	}
+}
+
+// match returns ErrConflict if version of e is not the same as expected one
+// in ctx.
+func match(ctx context.Context, e domain.Entry) error {
+	if version, ok := entry.VersionFromContext(ctx); ok && e.Version != version {
+		return entry.ErrConflict
+	}
+
+	return nil
+}
+
+// add appends values of src to multi-valued properties of dst and sets empty
+// single-valued ones.
+func add(dst *domain.Entry, src domain.Entry) {
+	if dst.Title == "" {
+		dst.Title = src.Title
+	}
+
+	if dst.Description == "" {
+		dst.Description = src.Description
+	}
+
+	if dst.Content.HTML == nil && dst.Content.Text == "" {
+		dst.Content = src.Content
+	}
+
+	if dst.PublishedAt.IsZero() {
+		dst.PublishedAt = src.PublishedAt
+	}
+
+	dst.Tags = append(dst.Tags, src.Tags...)
+	dst.Syndications = append(dst.Syndications, src.Syndications...)
+	dst.InReplyTo = append(dst.InReplyTo, src.InReplyTo...)
+	dst.LikeOf = append(dst.LikeOf, src.LikeOf...)
+	dst.RepostOf = append(dst.RepostOf, src.RepostOf...)
+	dst.BookmarkOf = append(dst.BookmarkOf, src.BookmarkOf...)
+	dst.Photo = append(dst.Photo, src.Photo...)
+	dst.Video = append(dst.Video, src.Video...)
+	dst.Audio = append(dst.Audio, src.Audio...)
+}
+
+// remove deletes values of src from multi-valued properties of dst.
+func remove(dst *domain.Entry, src domain.Entry) {
+	if len(src.Tags) > 0 {
+		dst.Tags = removeTags(dst.Tags, src.Tags)
 	}
+
+	for _, urls := range []struct {
+		dst *[]*url.URL
+		src []*url.URL
+	}{
+		{&dst.Syndications, src.Syndications},
+		{&dst.InReplyTo, src.InReplyTo},
+		{&dst.LikeOf, src.LikeOf},
+		{&dst.RepostOf, src.RepostOf},
+		{&dst.BookmarkOf, src.BookmarkOf},
+		{&dst.Photo, src.Photo},
+		{&dst.Video, src.Video},
+		{&dst.Audio, src.Audio},
+	} {
+		if len(urls.src) > 0 {
+			*urls.dst = removeURLs(*urls.dst, urls.src)
+		}
+	}
+}
+
+func removeTags(tags, deleted []string) []string {
+	out := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		keep := true
+
+		for i := range deleted {
+			if strings.EqualFold(tag, deleted[i]) {
+				keep = false
+
+				break
+			}
+		}
+
+		if keep {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
+
+func removeURLs(urls, deleted []*url.URL) []*url.URL {
+	out := make([]*url.URL, 0, len(urls))
+
+	for _, u := range urls {
+		keep := true
+
+		for i := range deleted {
+			if u.String() == deleted[i].String() {
+				keep = false
+
+				break
+			}
+		}
+
+		if keep {
+			out = append(out, u)
+		}
+	}
+
+	return out
 }
@@ -2,6 +2,9 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 
 	"source.toby3d.me/toby3d/pub/internal/domain"
 	"source.toby3d.me/toby3d/pub/internal/entry"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
 	"source.toby3d.me/toby3d/pub/internal/entry/usecase"
 )
 
@@ -47,8 +51,25 @@ func TestUpdate(t *testing.T) {
 				return &updated
 			},
 		},
-		// TODO(toby3d): "update": {},
-		// TODO(toby3d): "delete": {},
+		"replace": {
+			options: entry.UpdateOptions{
+				Replace: &domain.Entry{URL: e.URL, Title: "Replaced", Tags: []string{"indieweb"}},
+			},
+			expect: func() *domain.Entry {
+				return &domain.Entry{URL: e.URL, Title: "Replaced", Tags: []string{"indieweb"}}
+			},
+		},
+		"delete": {
+			options: entry.UpdateOptions{
+				Delete: &domain.Entry{Tags: []string{"LOREM"}},
+			},
+			expect: func() *domain.Entry {
+				updated := *e
+				updated.Tags = []string{"ipsum", "dor"}
+
+				return &updated
+			},
+		},
 	} {
 		name, tc := name, tc
 
@@ -56,15 +77,178 @@ func TestUpdate(t *testing.T) {
 			t.Parallel()
 
 			expect := tc.expect()
-			repo := entry.NewSpyEntryRepository(entry.NewStubEntryRepository(nil, expect, nil, false))
-			ucase := usecase.NewEntryUseCase(repo)
+			repo := entrymemoryrepo.NewMemoryEntryRepository()
+
+			if err := repo.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := usecase.NewEntryUseCase(repo).Update(context.Background(), e.URL, tc.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// NOTE(toby3d): update time is always changed.
+			expect.UpdatedAt = out.UpdatedAt
+			expect.Version = 2
+
+			if diff := cmp.Diff(out, expect, cmp.AllowUnexported(e.RSVP, e.Status)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestUpdate_Version(t *testing.T) {
+	t.Parallel()
+
+	e := domain.TestEntry(t)
+	repo := entrymemoryrepo.NewMemoryEntryRepository()
+
+	if err := repo.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	ucase := usecase.NewEntryUseCase(repo)
+	options := entry.UpdateOptions{Add: &domain.Entry{Tags: []string{"indieweb"}}}
+
+	if _, err := ucase.Update(entry.NewVersionContext(context.Background(), 42), e.URL,
+		options); !errors.Is(err, entry.ErrConflict) {
+		t.Errorf("expect %v for outdated version, got %v", entry.ErrConflict, err)
+	}
+
+	out, err := ucase.Update(entry.NewVersionContext(context.Background(), 1), e.URL, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Version != 2 {
+		t.Errorf("expect version 2 after update, got %d", out.Version)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	repo := entrymemoryrepo.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		e := domain.TestEntry(t)
+		e.URL = &url.URL{Path: "/notes/" + strconv.Itoa(i)}
+		e.PublishedAt = published.AddDate(0, 0, i)
+
+		if err := repo.Create(context.Background(), e.URL.Path, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ucase := usecase.NewEntryUseCase(repo)
+	paths := func(page *entry.Page) []string {
+		out := make([]string, 0, len(page.Entries))
+		for i := range page.Entries {
+			out = append(out, page.Entries[i].URL.Path)
+		}
+
+		return out
+	}
+
+	first, err := ucase.Fetch(context.Background(), entry.Query{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(first), []string{"/notes/4", "/notes/3"}); diff != "" || first.Prev != "" {
+		t.Errorf("first page: %s, prev: %s", diff, first.Prev)
+	}
 
-			out, err := ucase.Update(context.Background(), e.URL, tc.options)
+	second, err := ucase.Fetch(context.Background(), entry.Query{Limit: 2, After: first.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(second), []string{"/notes/2", "/notes/1"}); diff != "" {
+		t.Error(diff)
+	}
+
+	last, err := ucase.Fetch(context.Background(), entry.Query{Limit: 2, After: second.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(last), []string{"/notes/0"}); diff != "" || last.Next != "" {
+		t.Errorf("last page: %s, next: %s", diff, last.Next)
+	}
+
+	back, err := ucase.Fetch(context.Background(), entry.Query{Limit: 2, Before: second.Prev})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(paths(back), paths(first)); diff != "" || back.Prev != "" || back.Next != first.Next {
+		t.Errorf("previous page: %s, prev: %s, next: %s", diff, back.Prev, back.Next)
+	}
+}
+
+func TestCategories(t *testing.T) {
+	t.Parallel()
+
+	repo := entrymemoryrepo.NewMemoryEntryRepository()
+	published := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, tags := range [][]string{
+		{"indieweb", "golang"},
+		{"IndieWeb", "micropub"},
+		{"indieweb", "gopher"},
+	} {
+		e := domain.TestEntry(t)
+		e.URL = &url.URL{Path: "/notes/" + strconv.Itoa(i)}
+		e.PublishedAt = published.AddDate(0, 0, i)
+		e.Tags = tags
+
+		if err := repo.Create(context.Background(), e.URL.Path, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted := domain.TestEntry(t)
+	deleted.URL = &url.URL{Path: "/notes/deleted"}
+	deleted.DeletedAt = published
+	deleted.Tags = []string{"golang"}
+
+	if err := repo.Create(context.Background(), deleted.URL.Path, *deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	ucase := usecase.NewEntryUseCase(repo)
+
+	for name, tc := range map[string]struct {
+		filter string
+		expect []entry.Category
+	}{
+		"all": {filter: "", expect: []entry.Category{
+			{Name: "indieweb", Count: 3},
+			{Name: "golang", Count: 1},
+			{Name: "gopher", Count: 1},
+			{Name: "micropub", Count: 1},
+		}},
+		"prefix": {filter: "Go", expect: []entry.Category{
+			{Name: "golang", Count: 1},
+			{Name: "gopher", Count: 1},
+		}},
+		"none": {filter: "rust", expect: []entry.Category{}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ucase.Categories(context.Background(), tc.filter)
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(out, expect, cmp.AllowUnexported(e.RSVP)); diff != "" {
+			if diff := cmp.Diff(out, tc.expect); diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -122,3 +306,35 @@ func TestSource(t *testing.T) {
 		t.Error("expect getting call")
 	}
 }
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e := domain.TestEntry(t)
+	hook := entry.NewSpyHook()
+	ucase := usecase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(), hook)
+
+	if _, err := ucase.Create(ctx, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ucase.Update(ctx, e.URL, entry.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ucase.Delete(ctx, e.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ucase.Undelete(ctx, e.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []domain.Action{
+		domain.ActionCreate, domain.ActionUpdate, domain.ActionDelete, domain.ActionUndelete,
+	}
+	if diff := cmp.Diff(hook.Actions(), expect, cmp.AllowUnexported(domain.Action{})); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,7 @@
+// Package provides a feeds HTTP endpoints.
+//
+// Feeds contains the latest published entries, optionally filtered by tag or
+// post type, in Atom, RSS or JSON Feed format depending on the last path
+// segment. Responses are supporting conditional requests by ETag and
+// Last-Modified validators.
+package http
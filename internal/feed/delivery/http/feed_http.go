@@ -0,0 +1,512 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+)
+
+type (
+	Handler struct {
+		feeds  feed.UseCase
+		config domain.Config
+	}
+
+	// Channel represents a feed metadata common for all formats.
+	Channel struct {
+		Updated time.Time
+		Self    *url.URL
+		Home    *url.URL
+		Hubs    []*url.URL
+		Title   string
+		Entries []domain.Entry
+	}
+
+	AtomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Author  *AtomAuthor `xml:"author,omitempty"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Links   []AtomLink  `xml:"link"`
+		Entries []AtomEntry `xml:"entry"`
+	}
+
+	AtomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	AtomLink struct {
+		Href   string `xml:"href,attr"`
+		Rel    string `xml:"rel,attr,omitempty"`
+		Type   string `xml:"type,attr,omitempty"`
+		Length uint64 `xml:"length,attr,omitempty"`
+	}
+
+	AtomEntry struct {
+		Content    *AtomText      `xml:"content,omitempty"`
+		Summary    *AtomText      `xml:"summary,omitempty"`
+		ID         string         `xml:"id"`
+		Title      string         `xml:"title"`
+		Published  string         `xml:"published,omitempty"`
+		Updated    string         `xml:"updated"`
+		Links      []AtomLink     `xml:"link"`
+		Categories []AtomCategory `xml:"category"`
+	}
+
+	AtomText struct {
+		Type string `xml:"type,attr"`
+		Body string `xml:",chardata"`
+	}
+
+	AtomCategory struct {
+		Term string `xml:"term,attr"`
+	}
+
+	RSSFeed struct {
+		XMLName xml.Name   `xml:"rss"`
+		Atom    string     `xml:"xmlns:atom,attr"`
+		Version string     `xml:"version,attr"`
+		Channel RSSChannel `xml:"channel"`
+	}
+
+	RSSChannel struct {
+		Title         string     `xml:"title"`
+		Link          string     `xml:"link"`
+		Description   string     `xml:"description"`
+		LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+		Links         []AtomLink `xml:"atom:link"`
+		Items         []RSSItem  `xml:"item"`
+	}
+
+	RSSItem struct {
+		Enclosure   *RSSEnclosure `xml:"enclosure,omitempty"`
+		GUID        RSSGUID       `xml:"guid"`
+		Title       string        `xml:"title,omitempty"`
+		Link        string        `xml:"link"`
+		Description string        `xml:"description,omitempty"`
+		PubDate     string        `xml:"pubDate,omitempty"`
+		Categories  []string      `xml:"category"`
+	}
+
+	RSSGUID struct {
+		Value       string `xml:",chardata"`
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+	}
+
+	RSSEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length uint64 `xml:"length,attr"`
+	}
+
+	JSONFeed struct {
+		Version     string     `json:"version"`
+		Title       string     `json:"title"`
+		HomePageURL string     `json:"home_page_url"`
+		FeedURL     string     `json:"feed_url"`
+		Hubs        []JSONHub  `json:"hubs,omitempty"`
+		Items       []JSONItem `json:"items"`
+	}
+
+	JSONHub struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+
+	JSONItem struct {
+		ID            string           `json:"id"`
+		URL           string           `json:"url"`
+		Title         string           `json:"title,omitempty"`
+		ContentHTML   string           `json:"content_html,omitempty"`
+		ContentText   string           `json:"content_text,omitempty"`
+		Summary       string           `json:"summary,omitempty"`
+		DatePublished string           `json:"date_published,omitempty"`
+		DateModified  string           `json:"date_modified,omitempty"`
+		Tags          []string         `json:"tags,omitempty"`
+		Attachments   []JSONAttachment `json:"attachments,omitempty"`
+	}
+
+	JSONAttachment struct {
+		URL               string `json:"url"`
+		MimeType          string `json:"mime_type"`
+		SizeInBytes       uint64 `json:"size_in_bytes,omitempty"`
+		DurationInSeconds uint64 `json:"duration_in_seconds,omitempty"`
+	}
+
+	// enclosure is a single audio or video attachment of entry.
+	enclosure struct {
+		url      *url.URL
+		kind     string
+		size     uint64
+		duration time.Duration
+	}
+)
+
+// Limit is a maximum number of entries in feed.
+const Limit int = 20
+
+const jsonFeedVersion string = "https://jsonfeed.org/version/1.1"
+
+func NewHandler(feeds feed.UseCase, config domain.Config) *Handler {
+	return &Handler{
+		feeds:  feeds,
+		config: config,
+	}
+}
+
+// ServeHTTP serves /feed/{format}, /feed/tags/{tag}/{format} and
+// /feed/types/{type}/{format} feeds.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	format, options, err := parsePath(r.URL.Path)
+	if err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	options.Limit = Limit
+
+	entries, err := h.feeds.Fetch(r.Context(), options)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	channel := NewChannel(h.config, format, options, entries)
+
+	var (
+		body        []byte
+		contentType string
+	)
+
+	switch format {
+	case feed.FormatAtom:
+		contentType = common.MIMEApplicationAtomXMLCharsetUTF8
+		body, err = marshalXML(NewAtomFeed(h.config, *channel))
+	case feed.FormatRSS:
+		contentType = common.MIMEApplicationRSSXMLCharsetUTF8
+		body, err = marshalXML(NewRSSFeed(h.config, *channel))
+	case feed.FormatJSON:
+		contentType = common.MIMEApplicationFeedJSONCharsetUTF8
+		body, err = json.Marshal(NewJSONFeed(h.config, *channel))
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	hash := sha256.Sum256(body)
+
+	w.Header().Set(common.HeaderContentType, contentType)
+	w.Header().Set(common.HeaderETag, `"`+hex.EncodeToString(hash[:16])+`"`)
+	websub.Advertise(w.Header(), channel.Self, channel.Hubs...)
+
+	// NOTE(toby3d): ServeContent checks If-None-Match and
+	// If-Modified-Since conditions by itself.
+	http.ServeContent(w, r, "", channel.Updated, bytes.NewReader(body))
+}
+
+// NewChannel creates feed metadata of provided entries.
+func NewChannel(config domain.Config, format feed.Format, options feed.Options, entries []domain.Entry) *Channel {
+	out := &Channel{
+		Title:   config.HTTP.Host,
+		Self:    feed.URL(config, format, options),
+		Home:    config.HTTP.BaseURL(),
+		Hubs:    websub.Endpoints(config),
+		Entries: make([]domain.Entry, 0, len(entries)),
+	}
+
+	switch {
+	case options.Tag != "":
+		out.Title = "#" + options.Tag + " — " + out.Title
+		out.Home = out.Home.JoinPath("tags", options.Tag)
+	case options.Type != domain.PostTypeUnd:
+		out.Title = options.Type.String() + " — " + out.Title
+	}
+
+	for i := range entries {
+		if entries[i].URL == nil {
+			continue
+		}
+
+		out.Entries = append(out.Entries, entries[i])
+
+		if updated := feed.Updated(entries[i]); updated.After(out.Updated) {
+			out.Updated = updated
+		}
+	}
+
+	return out
+}
+
+func NewAtomFeed(config domain.Config, src Channel) *AtomFeed {
+	out := &AtomFeed{
+		ID:      src.Self.String(),
+		Title:   src.Title,
+		Updated: formatTime(src.Updated, time.RFC3339),
+		Author:  &AtomAuthor{Name: config.HTTP.Host},
+		Links: []AtomLink{
+			{Href: src.Self.String(), Rel: "self", Type: common.MIMEApplicationAtomXML},
+			{Href: src.Home.String(), Rel: "alternate", Type: common.MIMETextHTML},
+		},
+		Entries: make([]AtomEntry, 0, len(src.Entries)),
+	}
+
+	for _, hub := range src.Hubs {
+		out.Links = append(out.Links, AtomLink{Href: hub.String(), Rel: "hub"})
+	}
+
+	// NOTE(toby3d): updated element is required even for empty feeds.
+	if out.Updated == "" {
+		out.Updated = time.Unix(0, 0).UTC().Format(time.RFC3339)
+	}
+
+	for _, e := range src.Entries {
+		permalink := config.HTTP.BaseURL().ResolveReference(e.URL).String()
+		item := AtomEntry{
+			ID:         permalink,
+			Title:      e.Title,
+			Published:  formatTime(e.Date(), time.RFC3339),
+			Updated:    formatTime(feed.Updated(e), time.RFC3339),
+			Links:      []AtomLink{{Href: permalink, Rel: "alternate", Type: common.MIMETextHTML}},
+			Categories: make([]AtomCategory, 0, len(e.Tags)),
+		}
+
+		if item.Updated == "" {
+			item.Updated = out.Updated
+		}
+
+		switch {
+		case e.Content.HTML != nil:
+			item.Content = &AtomText{Type: "html", Body: e.Content.RenderHTML()}
+		case e.Content.Text != "":
+			item.Content = &AtomText{Type: "text", Body: e.Content.Text}
+		}
+
+		if e.Description != "" {
+			item.Summary = &AtomText{Type: "text", Body: e.Description}
+		}
+
+		for _, enc := range enclosures(config, e) {
+			item.Links = append(item.Links, AtomLink{
+				Href:   enc.url.String(),
+				Rel:    "enclosure",
+				Type:   enc.kind,
+				Length: enc.size,
+			})
+		}
+
+		for _, tag := range e.Tags {
+			item.Categories = append(item.Categories, AtomCategory{Term: tag})
+		}
+
+		out.Entries = append(out.Entries, item)
+	}
+
+	return out
+}
+
+func NewRSSFeed(config domain.Config, src Channel) *RSSFeed {
+	out := &RSSFeed{
+		Atom:    "http://www.w3.org/2005/Atom",
+		Version: "2.0",
+		Channel: RSSChannel{
+			Title:         src.Title,
+			Link:          src.Home.String(),
+			Description:   src.Title,
+			LastBuildDate: formatTime(src.Updated, time.RFC1123Z),
+			Links: []AtomLink{
+				{Href: src.Self.String(), Rel: "self", Type: common.MIMEApplicationRSSXML},
+			},
+			Items: make([]RSSItem, 0, len(src.Entries)),
+		},
+	}
+
+	for _, hub := range src.Hubs {
+		out.Channel.Links = append(out.Channel.Links, AtomLink{Href: hub.String(), Rel: "hub"})
+	}
+
+	for _, e := range src.Entries {
+		permalink := config.HTTP.BaseURL().ResolveReference(e.URL).String()
+		item := RSSItem{
+			GUID:       RSSGUID{Value: permalink, IsPermaLink: true},
+			Title:      e.Title,
+			Link:       permalink,
+			PubDate:    formatTime(e.Date(), time.RFC1123Z),
+			Categories: e.Tags,
+		}
+
+		switch {
+		case e.Content.HTML != nil, e.Content.Text != "":
+			item.Description = e.Content.RenderHTML()
+		default:
+			item.Description = e.Description
+		}
+
+		// NOTE(toby3d): RSS supports only one enclosure per item.
+		if encs := enclosures(config, e); len(encs) > 0 {
+			item.Enclosure = &RSSEnclosure{
+				URL:    encs[0].url.String(),
+				Type:   encs[0].kind,
+				Length: encs[0].size,
+			}
+		}
+
+		out.Channel.Items = append(out.Channel.Items, item)
+	}
+
+	return out
+}
+
+func NewJSONFeed(config domain.Config, src Channel) *JSONFeed {
+	out := &JSONFeed{
+		Version:     jsonFeedVersion,
+		Title:       src.Title,
+		HomePageURL: src.Home.String(),
+		FeedURL:     src.Self.String(),
+		Hubs:        make([]JSONHub, 0, len(src.Hubs)),
+		Items:       make([]JSONItem, 0, len(src.Entries)),
+	}
+
+	for _, hub := range src.Hubs {
+		out.Hubs = append(out.Hubs, JSONHub{Type: "WebSub", URL: hub.String()})
+	}
+
+	for _, e := range src.Entries {
+		permalink := config.HTTP.BaseURL().ResolveReference(e.URL).String()
+		item := JSONItem{
+			ID:            permalink,
+			URL:           permalink,
+			Title:         e.Title,
+			Summary:       e.Description,
+			DatePublished: formatTime(e.Date(), time.RFC3339),
+			DateModified:  formatTime(e.UpdatedAt, time.RFC3339),
+			Tags:          e.Tags,
+		}
+
+		if e.Content.HTML != nil {
+			item.ContentHTML = e.Content.RenderHTML()
+		} else {
+			item.ContentText = e.Content.Text
+		}
+
+		// NOTE(toby3d): JSON Feed requires any content of item.
+		if item.ContentHTML == "" && item.ContentText == "" {
+			item.ContentText = permalink
+		}
+
+		for _, enc := range enclosures(config, e) {
+			item.Attachments = append(item.Attachments, JSONAttachment{
+				URL:               enc.url.String(),
+				MimeType:          enc.kind,
+				SizeInBytes:       enc.size,
+				DurationInSeconds: uint64(enc.duration.Round(time.Second).Seconds()),
+			})
+		}
+
+		out.Items = append(out.Items, item)
+	}
+
+	return out
+}
+
+func parsePath(p string) (feed.Format, feed.Options, error) {
+	var options feed.Options
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) > 0 && parts[0] == "feed" {
+		parts = parts[1:]
+	}
+
+	switch len(parts) {
+	case 1:
+	case 3:
+		switch parts[0] {
+		default:
+			return feed.FormatUnd, options, errors.New("unknown feed filter")
+		case "tags":
+			options.Tag = parts[1]
+		case "types":
+			postType, err := domain.ParsePostType(parts[1])
+			if err != nil {
+				return feed.FormatUnd, options, err
+			}
+
+			options.Type = postType
+		}
+	default:
+		return feed.FormatUnd, options, errors.New("unknown feed path")
+	}
+
+	format, err := feed.ParseFormat(parts[len(parts)-1])
+	if err != nil {
+		return feed.FormatUnd, options, err
+	}
+
+	return format, options, nil
+}
+
+// enclosures returns audio and video attachments of entry. Entry size and
+// duration properties describes the first one.
+func enclosures(config domain.Config, e domain.Entry) []enclosure {
+	out := make([]enclosure, 0, len(e.Audio)+len(e.Video))
+
+	for _, u := range append(append(make([]*url.URL, 0, len(e.Audio)+len(e.Video)), e.Audio...), e.Video...) {
+		if u == nil {
+			continue
+		}
+
+		kind := domain.File{Path: u.Path}.MediaType()
+		if kind == "" {
+			kind = "application/octet-stream"
+		}
+
+		enc := enclosure{url: config.HTTP.BaseURL().ResolveReference(u), kind: kind}
+		if len(out) == 0 {
+			enc.size, enc.duration = e.Size, e.Duration
+		}
+
+		out = append(out, enc)
+	}
+
+	return out
+}
+
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func formatTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(layout)
+}
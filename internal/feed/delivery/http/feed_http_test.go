@@ -0,0 +1,223 @@
+package http_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	delivery "source.toby3d.me/toby3d/pub/internal/feed/delivery/http"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := testEntry(t)
+
+	for name, tc := range map[string]struct {
+		target      string
+		contentType string
+		expect      int
+	}{
+		"atom":    {target: "/feed/atom", contentType: common.MIMEApplicationAtomXMLCharsetUTF8, expect: http.StatusOK},
+		"rss":     {target: "/feed/rss", contentType: common.MIMEApplicationRSSXMLCharsetUTF8, expect: http.StatusOK},
+		"json":    {target: "/feed/json", contentType: common.MIMEApplicationFeedJSONCharsetUTF8, expect: http.StatusOK},
+		"tag":     {target: "/feed/tags/podcast/atom", contentType: common.MIMEApplicationAtomXMLCharsetUTF8, expect: http.StatusOK},
+		"type":    {target: "/feed/types/audio/rss", contentType: common.MIMEApplicationRSSXMLCharsetUTF8, expect: http.StatusOK},
+		"unknown": {target: "/feed/html", expect: http.StatusNotFound},
+		"filter":  {target: "/feed/authors/alice/atom", expect: http.StatusNotFound},
+		"ptype":   {target: "/feed/types/unknown/atom", expect: http.StatusNotFound},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com"+tc.target, nil)
+			w := httptest.NewRecorder()
+			delivery.NewHandler(feed.NewStubUseCase([]domain.Entry{*e}, nil), *config).ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tc.expect {
+				t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+
+			if tc.contentType == "" {
+				return
+			}
+
+			if contentType := resp.Header.Get(common.HeaderContentType); contentType != tc.contentType {
+				t.Errorf("expect %s Content-Type, got %s", tc.contentType, contentType)
+			}
+
+			if resp.Header.Get(common.HeaderETag) == "" {
+				t.Error("expect ETag header")
+			}
+
+			if lastModified := resp.Header.Get("Last-Modified"); lastModified != e.UpdatedAt.Format(http.TimeFormat) {
+				t.Errorf("expect %s Last-Modified, got %s", e.UpdatedAt.Format(http.TimeFormat), lastModified)
+			}
+		})
+	}
+}
+
+func TestHandler_Atom(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := testEntry(t)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/feed/atom", nil)
+	w := httptest.NewRecorder()
+	delivery.NewHandler(feed.NewStubUseCase([]domain.Entry{*e}, nil), *config).ServeHTTP(w, req)
+
+	out := new(delivery.AtomFeed)
+	if err := xml.NewDecoder(w.Result().Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Entries) != 1 {
+		t.Fatalf("expect 1 entry, got %d", len(out.Entries))
+	}
+
+	if expect := e.UpdatedAt.Format(time.RFC3339); out.Updated != expect || out.Entries[0].Updated != expect {
+		t.Errorf("expect %s updated, got %s feed and %s entry", expect, out.Updated, out.Entries[0].Updated)
+	}
+
+	var enclosure *delivery.AtomLink
+
+	for i := range out.Entries[0].Links {
+		if out.Entries[0].Links[i].Rel == "enclosure" {
+			enclosure = &out.Entries[0].Links[i]
+		}
+	}
+
+	if enclosure == nil {
+		t.Fatal("expect enclosure link")
+	}
+
+	if expect := "https://example.com/media/episode.mp3"; enclosure.Href != expect {
+		t.Errorf("expect %s enclosure, got %s", expect, enclosure.Href)
+	}
+
+	if enclosure.Length != e.Size {
+		t.Errorf("expect %d enclosure length, got %d", e.Size, enclosure.Length)
+	}
+}
+
+func TestHandler_RSS(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := testEntry(t)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/feed/rss", nil)
+	w := httptest.NewRecorder()
+	delivery.NewHandler(feed.NewStubUseCase([]domain.Entry{*e}, nil), *config).ServeHTTP(w, req)
+
+	out := new(delivery.RSSFeed)
+	if err := xml.NewDecoder(w.Result().Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Channel.Items) != 1 {
+		t.Fatalf("expect 1 item, got %d", len(out.Channel.Items))
+	}
+
+	item := out.Channel.Items[0]
+	if item.Enclosure == nil || item.Enclosure.Length != e.Size {
+		t.Errorf("expect enclosure with %d length, got %+v", e.Size, item.Enclosure)
+	}
+
+	if expect := e.PublishedAt.Format(time.RFC1123Z); item.PubDate != expect {
+		t.Errorf("expect %s pubDate, got %s", expect, item.PubDate)
+	}
+}
+
+func TestHandler_JSON(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	config.WebSub.Hub = true
+	e := testEntry(t)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/feed/json", nil)
+	w := httptest.NewRecorder()
+	delivery.NewHandler(feed.NewStubUseCase([]domain.Entry{*e}, nil), *config).ServeHTTP(w, req)
+
+	out := new(delivery.JSONFeed)
+	if err := json.NewDecoder(w.Result().Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Version != "https://jsonfeed.org/version/1.1" {
+		t.Errorf("expect JSON Feed 1.1, got %s", out.Version)
+	}
+
+	if len(out.Hubs) != 1 || out.Hubs[0].URL != "https://example.com/websub" {
+		t.Errorf("expect built-in hub, got %+v", out.Hubs)
+	}
+
+	if len(out.Items) != 1 || len(out.Items[0].Attachments) != 1 {
+		t.Fatalf("expect 1 item with attachment, got %+v", out.Items)
+	}
+
+	if expect := e.UpdatedAt.Format(time.RFC3339); out.Items[0].DateModified != expect {
+		t.Errorf("expect %s date_modified, got %s", expect, out.Items[0].DateModified)
+	}
+}
+
+func TestHandler_Conditional(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := testEntry(t)
+	handler := delivery.NewHandler(feed.NewStubUseCase([]domain.Entry{*e}, nil), *config)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/feed/atom", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	etag := w.Result().Header.Get(common.HeaderETag)
+
+	for name, header := range map[string]http.Header{
+		"etag":     {"If-None-Match": {etag}},
+		"modified": {"If-Modified-Since": {e.UpdatedAt.Add(time.Minute).Format(http.TimeFormat)}},
+	} {
+		name, header := name, header
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/feed/atom", nil)
+			req.Header = header
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != http.StatusNotModified {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode,
+					http.StatusNotModified)
+			}
+		})
+	}
+}
+
+func testEntry(tb testing.TB) *domain.Entry {
+	tb.Helper()
+
+	e := domain.TestEntry(tb)
+	e.PublishedAt = time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+	e.UpdatedAt = e.PublishedAt.Add(time.Hour)
+	e.Tags = append(e.Tags, "podcast")
+	e.Audio = []*url.URL{{Path: "/media/episode.mp3"}}
+	e.Size = 42
+
+	return e
+}
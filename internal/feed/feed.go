@@ -0,0 +1,134 @@
+// Package feed provides subscription feeds of published entries in Atom 1.0,
+// RSS 2.0 and JSON Feed 1.1 formats.
+//
+// See: https://www.rfc-editor.org/rfc/rfc4287
+// See: https://www.rssboard.org/rss-specification
+// See: https://www.jsonfeed.org/version/1.1/
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// Format describes a serialization of feed.
+type Format struct {
+	format string
+}
+
+var (
+	FormatUnd  = Format{}       // "und"
+	FormatAtom = Format{"atom"} // "atom"
+	FormatRSS  = Format{"rss"}  // "rss"
+	FormatJSON = Format{"json"} // "json"
+)
+
+var ErrFormatSyntax error = domain.Error{
+	Description: fmt.Sprintf("got unsupported feed format, expect '%s', '%s' or '%s'", FormatAtom, FormatRSS,
+		FormatJSON),
+	Frame: xerrors.Caller(1),
+	Code:  http.StatusNotFound,
+}
+
+var stringsFormats = map[string]Format{
+	FormatAtom.format: FormatAtom,
+	FormatRSS.format:  FormatRSS,
+	FormatJSON.format: FormatJSON,
+}
+
+// Formats contains all supported feed formats.
+var Formats = []Format{FormatAtom, FormatRSS, FormatJSON}
+
+func ParseFormat(v string) (Format, error) {
+	if out, ok := stringsFormats[strings.ToLower(v)]; ok {
+		return out, nil
+	}
+
+	return FormatUnd, fmt.Errorf("cannot parse '%s' as feed format: %w", v, ErrFormatSyntax)
+}
+
+func (f Format) String() string {
+	if f.format == "" {
+		return "und"
+	}
+
+	return f.format
+}
+
+func (f Format) GoString() string {
+	return "feed.Format(" + f.String() + ")"
+}
+
+// Options filters entries of feed. Zero values means no filtering.
+type Options struct {
+	Tag   string
+	Type  domain.PostType
+	Limit int
+}
+
+// URL returns absolute URL of feed in provided format filtered by options.
+func URL(config domain.Config, f Format, options Options) *url.URL {
+	out := config.HTTP.BaseURL().JoinPath("feed")
+
+	switch {
+	case options.Tag != "":
+		out = out.JoinPath("tags", options.Tag)
+	case options.Type != domain.PostTypeUnd:
+		out = out.JoinPath("types", options.Type.String())
+	}
+
+	return out.JoinPath(f.String())
+}
+
+// URLs returns URLs of all feeds in all formats which contains provided
+// entry.
+func URLs(config domain.Config, e domain.Entry) []*url.URL {
+	options := make([]Options, 0, len(e.Tags)+2)
+	options = append(options, Options{}, Options{Type: e.Type()})
+
+	for _, tag := range e.Tags {
+		options = append(options, Options{Tag: tag})
+	}
+
+	out := make([]*url.URL, 0, len(options)*len(Formats))
+
+	for i := range options {
+		for _, f := range Formats {
+			out = append(out, URL(config, f, options[i]))
+		}
+	}
+
+	return out
+}
+
+// Pages returns URLs of all h-feed pages which lists provided entry: the home
+// page, tag pages and year and month archives.
+func Pages(config domain.Config, e domain.Entry) []*url.URL {
+	date := e.Date().UTC()
+	out := make([]*url.URL, 0, len(e.Tags)+3)
+	out = append(out, config.HTTP.BaseURL(), config.HTTP.BaseURL().JoinPath(date.Format("2006")),
+		config.HTTP.BaseURL().JoinPath(date.Format("2006"), date.Format("01")))
+
+	for _, tag := range e.Tags {
+		out = append(out, config.HTTP.BaseURL().JoinPath("tags", tag))
+	}
+
+	return out
+}
+
+// Updated returns date of last entry change, fallbacks to it's publication
+// date.
+func Updated(e domain.Entry) time.Time {
+	if !e.UpdatedAt.IsZero() {
+		return e.UpdatedAt
+	}
+
+	return e.Date()
+}
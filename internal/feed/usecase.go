@@ -0,0 +1,43 @@
+package feed
+
+import (
+	"context"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Fetch returns published entries filtered by options, newest
+		// first. Drafts and deleted entries are never returned.
+		Fetch(ctx context.Context, options Options) ([]domain.Entry, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		err     error
+		entries []domain.Entry
+	}
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Fetch(_ context.Context, _ Options) ([]domain.Entry, error) {
+	return make([]domain.Entry, 0), nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(entries []domain.Entry, err error) UseCase {
+	return &stubUseCase{
+		entries: entries,
+		err:     err,
+	}
+}
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ Options) ([]domain.Entry, error) {
+	return ucase.entries, ucase.err
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+)
+
+type feedUseCase struct {
+	entries entry.Repository
+}
+
+func NewFeedUseCase(entries entry.Repository) feed.UseCase {
+	return &feedUseCase{
+		entries: entries,
+	}
+}
+
+// Fetch implements feed.UseCase.
+func (ucase *feedUseCase) Fetch(ctx context.Context, options feed.Options) ([]domain.Entry, error) {
+	out, _, err := ucase.entries.Fetch(ctx, entry.Query{
+		Tag:       options.Tag,
+		Type:      options.Type,
+		Limit:     options.Limit,
+		Published: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch feed entries: %w", err)
+	}
+
+	return out, nil
+}
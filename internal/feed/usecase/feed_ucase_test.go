@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	"source.toby3d.me/toby3d/pub/internal/feed/usecase"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+
+	for p, e := range map[string]domain.Entry{
+		"/old": {
+			URL:         &url.URL{Path: "/old"},
+			PublishedAt: now.Add(-time.Hour),
+			Tags:        []string{"go"},
+		},
+		"/new": {
+			URL:         &url.URL{Path: "/new"},
+			PublishedAt: now,
+			Tags:        []string{"Go", "web"},
+		},
+		"/photo": {
+			URL:         &url.URL{Path: "/photo"},
+			PublishedAt: now.Add(-2 * time.Hour),
+			Photo:       []*url.URL{{Path: "/media/sunset.jpg"}},
+		},
+		"/draft": {
+			URL:         &url.URL{Path: "/draft"},
+			PublishedAt: now.Add(time.Hour),
+			Status:      domain.PostStatusDraft,
+			Tags:        []string{"go"},
+		},
+		"/deleted": {
+			URL:         &url.URL{Path: "/deleted"},
+			PublishedAt: now.Add(time.Hour),
+			DeletedAt:   now,
+			Tags:        []string{"go"},
+		},
+	} {
+		if err := entries.Create(context.Background(), p, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ucase := usecase.NewFeedUseCase(entries)
+
+	for name, tc := range map[string]struct {
+		options feed.Options
+		expect  []string
+	}{
+		"all":   {options: feed.Options{}, expect: []string{"/new", "/old", "/photo"}},
+		"limit": {options: feed.Options{Limit: 1}, expect: []string{"/new"}},
+		"tag":   {options: feed.Options{Tag: "go"}, expect: []string{"/new", "/old"}},
+		"type":  {options: feed.Options{Type: domain.PostTypePhoto}, expect: []string{"/photo"}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ucase.Fetch(context.Background(), tc.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			paths := make([]string, 0, len(out))
+			for i := range out {
+				paths = append(paths, out[i].URL.Path)
+			}
+
+			if diff := cmp.Diff(paths, tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package httputil provides helpers for requesting untrusted remote resources.
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const maxRedirects int = 10
+
+var (
+	ErrForbiddenAddress error = errors.New("requested address is not public")
+	ErrForbiddenScheme  error = errors.New("requested URL scheme is not supported")
+	ErrTooLarge         error = errors.New("response body is too large")
+)
+
+// NOTE(toby3d): IANA special-purpose address blocks which are not covered by
+// net.IP methods and are not reachable from public internet, with IPv6 blocks
+// of translation mechanisms which embeds IPv4 addresses: NAT64, 6to4 and
+// Teredo can be used to reach private IPv4 hosts.
+//
+// See: https://www.iana.org/assignments/iana-ipv4-special-registry/
+// See: https://www.iana.org/assignments/iana-ipv6-special-registry/
+var specialPurpose = parseCIDRs(
+	"0.0.0.0/8",       // "this" network
+	"100.64.0.0/10",   // shared address space of carrier-grade NAT
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // TEST-NET-1 documentation
+	"192.88.99.0/24",  // deprecated 6to4 relay anycast
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // TEST-NET-2 documentation
+	"203.0.113.0/24",  // TEST-NET-3 documentation
+	"240.0.0.0/4",     // reserved, including limited broadcast
+	"::/96",           // deprecated IPv4-compatible addresses
+	"64:ff9b::/96",    // NAT64 well-known prefix
+	"64:ff9b:1::/48",  // NAT64 local-use prefix
+	"100::/64",        // discard-only
+	"2001::/23",       // IETF protocol assignments, including Teredo
+	"2001:db8::/32",   // documentation
+	"2002::/16",       // 6to4
+	"3fff::/20",       // documentation
+	"5f00::/16",       // segment routing SIDs
+)
+
+// NewClient creates a HTTP client which refuses to connect to loopback,
+// private, link-local and other non-public addresses after DNS resolving, so
+// user provided URLs cannot be used for server-side request forgery.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("cannot parse dial address: %w", err)
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !IsPublic(ip) {
+				return fmt.Errorf("cannot dial %s: %w", host, ErrForbiddenAddress)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // NOTE(toby3d): proxy address will bypass dial checks
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("cannot follow redirect to '%s': %w", req.URL, ErrForbiddenScheme)
+			}
+
+			return nil
+		},
+	}
+}
+
+// IsPublic reports whether ip is a global unicast address which is reachable
+// from public internet.
+func IsPublic(ip net.IP) bool {
+	// NOTE(toby3d): IPv4-mapped IPv6 addresses are checked as IPv4 ones.
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, block := range specialPurpose {
+		if block.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseCIDRs(blocks ...string) []*net.IPNet {
+	out := make([]*net.IPNet, 0, len(blocks))
+
+	for _, block := range blocks {
+		_, ipNet, err := net.ParseCIDR(block)
+		if err != nil {
+			panic(err)
+		}
+
+		out = append(out, ipNet)
+	}
+
+	return out
+}
+
+// ReadAll reads r until EOF, but no more than limit bytes. Returns ErrTooLarge
+// if r contains more data.
+func ReadAll(r io.Reader, limit int64) ([]byte, error) {
+	out, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read body: %w", err)
+	}
+
+	if int64(len(out)) > limit {
+		return nil, fmt.Errorf("cannot read more than %d bytes: %w", limit, ErrTooLarge)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package httputil_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	resp, err := httputil.NewClient(time.Second).Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	if !errors.Is(err, httputil.ErrForbiddenAddress) {
+		t.Errorf("GET %s = %v, want %v", srv.URL, err, httputil.ErrForbiddenAddress)
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::248": true,
+		"127.0.0.1":            false,
+		"10.0.0.1":             false,
+		"172.16.5.4":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fd00::1":              false,
+		"fe80::1":              false,
+		"224.0.0.1":            false,
+		"0.1.2.3":              false,
+		"192.0.0.170":          false,
+		"192.0.2.1":            false,
+		"198.18.0.1":           false,
+		"198.19.255.254":       false,
+		"240.0.0.1":            false,
+		"255.255.255.255":      false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:10.0.0.1":      false,
+		"::ffff:93.184.216.34": true,
+		"::127.0.0.1":          false,
+		"64:ff9b::a00:1":       false,
+		"64:ff9b::5db8:d822":   false,
+		"2001:db8::1":          false,
+		"2001::1":              false,
+		"2002:7f00:1::1":       false,
+	} {
+		input, expect := input, expect
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			if out := httputil.IsPublic(net.ParseIP(input)); out != expect {
+				t.Errorf("IsPublic(%s) = %t, want %t", input, out, expect)
+			}
+		})
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	t.Parallel()
+
+	if _, err := httputil.ReadAll(strings.NewReader("Hello, World!"), 5); !errors.Is(err, httputil.ErrTooLarge) {
+		t.Errorf("ReadAll() = %v, want %v", err, httputil.ErrTooLarge)
+	}
+
+	out, err := httputil.ReadAll(strings.NewReader("Hello"), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != "Hello" {
+		t.Errorf("ReadAll() = '%s', want '%s'", out, "Hello")
+	}
+}
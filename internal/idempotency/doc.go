@@ -0,0 +1,6 @@
+// Package idempotency provides safe retries of non-idempotent requests made
+// with Idempotency-Key header: the first response is stored and replayed for
+// retries with the same key and request body.
+//
+// See: https://datatracker.ietf.org/doc/draft-ietf-httpapi-idempotency-key-header/
+package idempotency
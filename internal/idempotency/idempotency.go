@@ -0,0 +1,285 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Middleware struct {
+		responses Repository
+		logger    *log.Logger
+		now       func() time.Time
+		config    domain.ConfigIdempotency
+	}
+
+	// recorder copies response of wrapped handler for replaying.
+	recorder struct {
+		http.ResponseWriter
+		body   *bytes.Buffer
+		status int
+	}
+)
+
+// MaxBodySize is a size of request body buffered for fingerprinting.
+const MaxBodySize int64 = 100 * 1024 * 1024 // 100mb
+
+var (
+	ErrMismatch error = domain.Error{
+		Description: "this idempotency key was already used for another request",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusConflict,
+	}
+
+	ErrInProgress error = domain.Error{
+		Description: "request with this idempotency key is still in progress",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusConflict,
+	}
+)
+
+func NewMiddleware(responses Repository, config domain.ConfigIdempotency, logger *log.Logger) *Middleware {
+	return &Middleware{
+		responses: responses,
+		logger:    logger,
+		config:    config,
+		now:       time.Now,
+	}
+}
+
+// Handle wraps next handler by storing responses of POST requests made with
+// Idempotency-Key header. Retries with the same key and body within window
+// gets the stored response instead of repeated handling. Server errors are not
+// stored, so such requests can be retried.
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// NOTE(toby3d): key is a Structured Field string, but most of
+		// clients sends it without quotes.
+		key := strings.Trim(strings.TrimSpace(r.Header.Get(common.HeaderIdempotencyKey)), `"`)
+		if r.Method != http.MethodPost || key == "" || m.config.Window <= 0 {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		key = r.URL.Path + " " + key // NOTE(toby3d): keys are unique per endpoint
+		in := domain.IdempotentResponse{
+			CreatedAt:   m.now().UTC(),
+			Fingerprint: fingerprint(r, body),
+		}
+
+		stored, err := m.reserve(r.Context(), key, in)
+		if err != nil {
+			var domainErr domain.Error
+			if errors.As(err, &domainErr) {
+				http.Error(w, err.Error(), domainErr.Code)
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if stored != nil {
+			replay(w, *stored)
+
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, body: bytes.NewBuffer(nil)}
+		next.ServeHTTP(rec, r)
+
+		ctx := context.WithoutCancel(r.Context())
+
+		// NOTE(toby3d): handler which writes nothing responds with 200 OK.
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		if rec.status >= http.StatusInternalServerError {
+			if err = m.responses.Delete(ctx, key); err != nil {
+				m.logger.Printf("cannot release idempotency key %s: %s", key, err)
+			}
+
+			return
+		}
+
+		in.StatusCode = rec.status
+		in.Header = w.Header().Clone()
+		in.Body = rec.body.Bytes()
+
+		if err = m.responses.Update(ctx, key, in); err != nil {
+			m.logger.Printf("cannot store response of idempotency key %s: %s", key, err)
+		}
+	})
+}
+
+// Run periodically purges responses stored for longer than window.
+func (m *Middleware) Run(ctx context.Context) {
+	if m.config.Window <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.config.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.responses.Purge(ctx, m.now().UTC().Add(-m.config.Window)); err != nil {
+				m.logger.Println("cannot purge expired idempotent responses:", err)
+			}
+		}
+	}
+}
+
+// reserve stores in as in progress response on key. Returns response stored
+// early for the same request, if any.
+func (m *Middleware) reserve(ctx context.Context, key string, in domain.IdempotentResponse) (
+	*domain.IdempotentResponse, error,
+) {
+	err := m.responses.Create(ctx, key, in)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !errors.Is(err, ErrExist) {
+		return nil, fmt.Errorf("cannot reserve idempotency key: %w", err)
+	}
+
+	out, err := m.responses.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get stored response: %w", err)
+	}
+
+	// NOTE(toby3d): expired key can be used again as a new one.
+	if out.CreatedAt.Before(in.CreatedAt.Add(-m.config.Window)) {
+		if err = m.responses.Delete(ctx, key); err != nil {
+			return nil, fmt.Errorf("cannot release expired idempotency key: %w", err)
+		}
+
+		if err = m.responses.Create(ctx, key, in); err != nil {
+			if errors.Is(err, ErrExist) {
+				return nil, ErrInProgress
+			}
+
+			return nil, fmt.Errorf("cannot reserve idempotency key: %w", err)
+		}
+
+		return nil, nil
+	}
+
+	switch {
+	case out.Fingerprint != in.Fingerprint:
+		return nil, ErrMismatch
+	case out.StatusCode == 0:
+		return nil, ErrInProgress
+	}
+
+	return out, nil
+}
+
+// replay writes stored response.
+func replay(w http.ResponseWriter, r domain.IdempotentResponse) {
+	for k, v := range r.Header {
+		w.Header()[k] = append([]string(nil), v...)
+	}
+
+	w.WriteHeader(r.StatusCode)
+	_, _ = w.Write(r.Body)
+}
+
+// fingerprint returns a hash of request which identifies it's retries.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+
+	// NOTE(toby3d): boundary of multipart form is random on each attempt
+	// of most of clients, so such forms are identified by their parts.
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get(common.HeaderContentType))
+	if err == nil && mediaType == common.MIMEMultipartForm && params["boundary"] != "" {
+		if sum, err := fingerprintParts(multipart.NewReader(bytes.NewReader(body), params["boundary"])); err == nil {
+			hash.Write([]byte(mediaType + "\n" + sum))
+
+			return hex.EncodeToString(hash.Sum(nil))
+		}
+	}
+
+	hash.Write([]byte(r.Header.Get(common.HeaderContentType) + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fingerprintParts returns a hash of form names, file names, media types and
+// contents of multipart form parts in the order of sending.
+func fingerprintParts(r *multipart.Reader) (string, error) {
+	hash := sha256.New()
+
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return hex.EncodeToString(hash.Sum(nil)), nil
+			}
+
+			return "", fmt.Errorf("cannot read multipart form part: %w", err)
+		}
+
+		content := sha256.New()
+		if _, err = io.Copy(content, part); err != nil {
+			return "", fmt.Errorf("cannot read multipart form part: %w", err)
+		}
+
+		hash.Write([]byte(strconv.Quote(part.FormName()) + " " + strconv.Quote(part.FileName()) + " " +
+			strconv.Quote(part.Header.Get(common.HeaderContentType)) + " " + hex.EncodeToString(content.Sum(nil)) +
+			"\n"))
+	}
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	r.body.Write(p)
+
+	return r.ResponseWriter.Write(p)
+}
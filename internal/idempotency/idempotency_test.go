@@ -0,0 +1,168 @@
+package idempotency_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/idempotency"
+	"source.toby3d.me/toby3d/pub/internal/idempotency/repository/memory"
+)
+
+func TestMiddleware_Handle(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		key     string
+		retry   string
+		status  int
+		expect  int
+		handled int32
+	}{
+		"replay": {
+			key: "abc", retry: "content=hello", status: http.StatusCreated,
+			expect: http.StatusCreated, handled: 1,
+		},
+		"mismatch": {
+			key: "abc", retry: "content=bye", status: http.StatusCreated,
+			expect: http.StatusConflict, handled: 1,
+		},
+		"without key": {
+			key: "", retry: "content=hello", status: http.StatusCreated,
+			expect: http.StatusCreated, handled: 2,
+		},
+		"server error": {
+			key: "abc", retry: "content=hello", status: http.StatusInternalServerError,
+			expect: http.StatusInternalServerError, handled: 2,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var handled int32
+
+			handler := idempotency.NewMiddleware(memory.NewMemoryIdempotencyRepository(),
+				domain.TestConfig(t).Idempotency, log.New(io.Discard, "", 0)).Handle(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					n := atomic.AddInt32(&handled, 1)
+					_, _ = io.ReadAll(r.Body)
+
+					w.Header().Set(common.HeaderLocation, "https://example.com/notes/"+strconv.Itoa(int(n)))
+					w.WriteHeader(tc.status)
+				}))
+			do := func(body string) *http.Response {
+				req := httptest.NewRequest(http.MethodPost, "https://example.com/api", strings.NewReader(body))
+				req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+				if tc.key != "" {
+					req.Header.Set(common.HeaderIdempotencyKey, `"`+tc.key+`"`)
+				}
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+
+				return w.Result()
+			}
+
+			first := do("content=hello")
+			retry := do(tc.retry)
+
+			if retry.StatusCode != tc.expect {
+				t.Errorf("retry = %d, want %d", retry.StatusCode, tc.expect)
+			}
+
+			if handled != tc.handled {
+				t.Errorf("expect %d handled requests, got %d", tc.handled, handled)
+			}
+
+			if tc.handled == 1 && tc.expect == tc.status {
+				if location := retry.Header.Get(common.HeaderLocation); location !=
+					first.Header.Get(common.HeaderLocation) {
+					t.Errorf("expect replayed Location %s, got %s", first.Header.Get(common.HeaderLocation),
+						location)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_HandleMultipart(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		retry   string
+		expect  int
+		handled int32
+	}{
+		"another boundary": {retry: "hello", expect: http.StatusCreated, handled: 1},
+		"another file":     {retry: "bye", expect: http.StatusConflict, handled: 1},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var handled int32
+
+			handler := idempotency.NewMiddleware(memory.NewMemoryIdempotencyRepository(),
+				domain.TestConfig(t).Idempotency, log.New(io.Discard, "", 0)).Handle(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					atomic.AddInt32(&handled, 1)
+					_, _ = io.ReadAll(r.Body)
+
+					w.WriteHeader(http.StatusCreated)
+				}))
+			do := func(content string) *http.Response {
+				body := bytes.NewBuffer(nil)
+				form := multipart.NewWriter(body) // NOTE(toby3d): boundary is random on each call
+
+				if err := form.WriteField("alt", "photo"); err != nil {
+					t.Fatal(err)
+				}
+
+				file, err := form.CreateFormFile("file", "photo.txt")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err = file.Write([]byte(content)); err != nil {
+					t.Fatal(err)
+				}
+
+				if err = form.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				req := httptest.NewRequest(http.MethodPost, "https://example.com/media", body)
+				req.Header.Set(common.HeaderContentType, form.FormDataContentType())
+				req.Header.Set(common.HeaderIdempotencyKey, `"abc"`)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+
+				return w.Result()
+			}
+
+			_ = do("hello")
+
+			if retry := do(tc.retry); retry.StatusCode != tc.expect {
+				t.Errorf("retry = %d, want %d", retry.StatusCode, tc.expect)
+			}
+
+			if handled != tc.handled {
+				t.Errorf("expect %d handled requests, got %d", tc.handled, handled)
+			}
+		})
+	}
+}
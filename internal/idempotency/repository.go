@@ -0,0 +1,61 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Repository interface {
+		// Create stores response on provided key. Returns ErrExist if
+		// key is already used.
+		Create(ctx context.Context, key string, r domain.IdempotentResponse) error
+
+		// Get returns response stored on provided key.
+		Get(ctx context.Context, key string) (*domain.IdempotentResponse, error)
+
+		// Update replaces response stored on provided key.
+		Update(ctx context.Context, key string, r domain.IdempotentResponse) error
+
+		// Delete removes response stored on provided key.
+		Delete(ctx context.Context, key string) error
+
+		// Purge removes all responses created before provided time and
+		// returns number of removed ones.
+		Purge(ctx context.Context, before time.Time) (int, error)
+	}
+
+	dummyRepository struct{}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+)
+
+var (
+	ErrExist    error = errors.New("this idempotency key already used")
+	ErrNotExist error = errors.New("this idempotency key is not used")
+)
+
+// NewDummyIdempotencyRepository creates an empty repository to satisfy
+// contracts. It is used in tests where repository working is not important.
+func NewDummyIdempotencyRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, _ domain.IdempotentResponse) error {
+	return nil
+}
+
+func (dummyRepository) Get(_ context.Context, _ string) (*domain.IdempotentResponse, error) {
+	return nil, ErrNotExist
+}
+
+func (dummyRepository) Update(_ context.Context, _ string, _ domain.IdempotentResponse) error {
+	return nil
+}
+
+func (dummyRepository) Delete(_ context.Context, _ string) error { return nil }
+
+func (dummyRepository) Purge(_ context.Context, _ time.Time) (int, error) { return 0, nil }
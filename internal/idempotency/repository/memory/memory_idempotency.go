@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/idempotency"
+)
+
+type memoryIdempotencyRepository struct {
+	mutex     *sync.RWMutex
+	responses map[string]domain.IdempotentResponse
+}
+
+func NewMemoryIdempotencyRepository() idempotency.Repository {
+	return &memoryIdempotencyRepository{
+		mutex:     new(sync.RWMutex),
+		responses: make(map[string]domain.IdempotentResponse),
+	}
+}
+
+func (repo *memoryIdempotencyRepository) Create(_ context.Context, key string, r domain.IdempotentResponse) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.responses[key]; ok {
+		return idempotency.ErrExist
+	}
+
+	repo.responses[key] = r
+
+	return nil
+}
+
+func (repo *memoryIdempotencyRepository) Get(_ context.Context, key string) (*domain.IdempotentResponse, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out, ok := repo.responses[key]
+	if !ok {
+		return nil, idempotency.ErrNotExist
+	}
+
+	return &out, nil
+}
+
+func (repo *memoryIdempotencyRepository) Update(_ context.Context, key string, r domain.IdempotentResponse) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.responses[key]; !ok {
+		return fmt.Errorf("cannot update response: %w", idempotency.ErrNotExist)
+	}
+
+	repo.responses[key] = r
+
+	return nil
+}
+
+func (repo *memoryIdempotencyRepository) Delete(_ context.Context, key string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	delete(repo.responses, key)
+
+	return nil
+}
+
+func (repo *memoryIdempotencyRepository) Purge(_ context.Context, before time.Time) (int, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	count := 0
+
+	for key, r := range repo.responses {
+		if r.CreatedAt.Before(before) {
+			delete(repo.responses, key)
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,3 @@
+// Package linkify provides conversion of plain text notes into HTML with linked
+// URLs, #hashtags and @mentions of known contacts.
+package linkify
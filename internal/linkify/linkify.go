@@ -0,0 +1,143 @@
+package linkify
+
+import (
+	"context"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"source.toby3d.me/toby3d/pub/internal/contact"
+)
+
+// Result represents linkified plain text.
+type Result struct {
+	HTML string
+	Tags []string // unique hashtags without '#' in order of appearance
+}
+
+var (
+	reToken     = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s<>"]+|[#@][\p{L}\p{N}_][\p{L}\p{N}_.-]*`)
+	reParagraph = regexp.MustCompile(`\n[ \t]*\n\s*`)
+)
+
+// Linkify returns HTML of text where URLs are linked, hashtags are linked to
+// their tag pages and @mentions of contacts are linked as h-card. Mentions of
+// unknown nicknames stays as is. Paragraphs are separated by blank lines.
+func Linkify(ctx context.Context, text string, contacts contact.UseCase) Result {
+	out := Result{Tags: make([]string, 0)}
+	tags := make(map[string]struct{})
+	paragraphs := make([]string, 0)
+
+	for _, paragraph := range reParagraph.Split(strings.TrimSpace(text), -1) {
+		if paragraph == "" {
+			continue
+		}
+
+		buf := new(strings.Builder)
+		last := 0
+
+		for _, match := range reToken.FindAllStringIndex(paragraph, -1) {
+			start, end := match[0], match[1]
+			if start < last {
+				continue
+			}
+
+			token := paragraph[start:end]
+
+			var link string
+
+			switch token[0] {
+			case '#':
+				token = trimToken(token)
+				if !boundary(paragraph, start) || !strings.ContainsFunc(token[1:], unicode.IsLetter) {
+					continue
+				}
+
+				name := token[1:]
+				link = `<a href="/tags/` + url.PathEscape(name) + `" rel="tag">` + template.HTMLEscapeString(token) +
+					`</a>`
+
+				if _, ok := tags[strings.ToLower(name)]; !ok {
+					tags[strings.ToLower(name)] = struct{}{}
+					out.Tags = append(out.Tags, name)
+				}
+			case '@':
+				token = trimToken(token)
+				if !boundary(paragraph, start) || start+len(token) < len(paragraph) &&
+					paragraph[start+len(token)] == '@' {
+					continue
+				}
+
+				c, err := contacts.Get(ctx, token[1:])
+				if err != nil || c == nil || c.URL == nil {
+					continue
+				}
+
+				link = `<a class="h-card" href="` + template.HTMLEscapeString(c.URL.String()) + `">` +
+					template.HTMLEscapeString(token) + `</a>`
+			default:
+				if token = trimURL(token); token == "" || !boundary(paragraph, start) {
+					continue
+				}
+
+				href := token
+				if strings.HasPrefix(strings.ToLower(href), "www.") {
+					href = "http://" + href
+				}
+
+				link = `<a href="` + template.HTMLEscapeString(href) + `">` + template.HTMLEscapeString(token) + `</a>`
+			}
+
+			buf.WriteString(template.HTMLEscapeString(paragraph[last:start]))
+			buf.WriteString(link)
+			last = start + len(token)
+		}
+
+		buf.WriteString(template.HTMLEscapeString(paragraph[last:]))
+		paragraphs = append(paragraphs, "<p>"+strings.ReplaceAll(buf.String(), "\n", "<br />\n")+"</p>")
+	}
+
+	out.HTML = strings.Join(paragraphs, "\n")
+
+	return out
+}
+
+// boundary reports whether token started at i is not a part of a word, like
+// an email or an URL fragment.
+func boundary(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	r := rune(s[i-1])
+
+	return unicode.IsSpace(r) || strings.ContainsRune(`([{"'`, r)
+}
+
+// trimToken removes trailing punctuation of hashtag or mention.
+func trimToken(token string) string {
+	return strings.TrimRight(token, ".-")
+}
+
+// trimURL removes trailing punctuation and unbalanced closing parentheses of
+// URL.
+func trimURL(token string) string {
+	for token != "" {
+		switch token[len(token)-1] {
+		case '?', '!', '.', ',', ':', ';', '*', '_', '~', '\'':
+			token = token[:len(token)-1]
+		case ')':
+			if strings.Count(token, "(") >= strings.Count(token, ")") {
+				return token
+			}
+
+			token = token[:len(token)-1]
+		default:
+			return token
+		}
+	}
+
+	return token
+}
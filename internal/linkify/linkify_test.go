@@ -0,0 +1,63 @@
+package linkify_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/contact"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/linkify"
+)
+
+func TestLinkify(t *testing.T) {
+	t.Parallel()
+
+	contacts := contact.NewStubUseCase([]domain.Contact{*domain.TestContact(t)}, nil)
+
+	for name, tc := range map[string]struct {
+		input  string
+		expect linkify.Result
+	}{
+		"plain": {
+			input: "Hello, <World> & friends!\nSecond line\n\nNext paragraph",
+			expect: linkify.Result{
+				HTML: "<p>Hello, &lt;World&gt; &amp; friends!<br />\nSecond line</p>\n<p>Next paragraph</p>",
+				Tags: []string{},
+			},
+		},
+		"urls": {
+			input: "See https://example.com/a_(b). Or (www.example.org/c)?",
+			expect: linkify.Result{HTML: `<p>See <a href="https://example.com/a_(b)">https://example.com/a_(b)</a>. ` +
+				`Or (<a href="http://www.example.org/c">www.example.org/c</a>)?</p>`, Tags: []string{}},
+		},
+		"hashtags": {
+			input: "#IndieWeb is #cool, #indieweb again, #100 and https://example.com/#anchor",
+			expect: linkify.Result{
+				HTML: `<p><a href="/tags/IndieWeb" rel="tag">#IndieWeb</a> is ` +
+					`<a href="/tags/cool" rel="tag">#cool</a>, <a href="/tags/indieweb" rel="tag">#indieweb</a> ` +
+					`again, #100 and <a href="https://example.com/#anchor">https://example.com/#anchor</a></p>`,
+				Tags: []string{"IndieWeb", "cool"},
+			},
+		},
+		"mentions": {
+			input: "Hi @jane. Not @john, jane@example.com or @jane@mastodon.example",
+			expect: linkify.Result{
+				HTML: `<p>Hi <a class="h-card" href="https://example.net/">@jane</a>. Not @john, ` +
+					`jane@example.com or @jane@mastodon.example</p>`,
+				Tags: []string{},
+			},
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(linkify.Linkify(context.Background(), tc.input, contacts), tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
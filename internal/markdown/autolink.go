@@ -0,0 +1,170 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	reExtendedAutolink = regexp.MustCompile(`(?:https?://|www\.)[^\s<]*|` +
+		`[a-zA-Z0-9.+_-]+@[a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)+`)
+	reTrailingEntity = regexp.MustCompile(`&[a-zA-Z0-9]+;$`)
+)
+
+// autolink replaces bare URLs, www. domains and emails in text nodes of tree
+// outside links and images by links as GFM extended autolinks.
+func autolink(n *inline) {
+	for c := n.first; c != nil; {
+		next := c.next
+
+		switch c.kind {
+		case inlineLink, inlineImage:
+		case inlineText:
+			autolinkText(c)
+		default:
+			autolink(c)
+		}
+
+		c = next
+	}
+}
+
+func autolinkText(n *inline) {
+	s := n.literal
+	last, current := 0, n
+
+	for _, match := range reExtendedAutolink.FindAllStringIndex(s, -1) {
+		start, end := match[0], match[1]
+		if start < last {
+			continue
+		}
+
+		var destination string
+
+		if strings.Contains(s[start:end], "@") && !strings.HasPrefix(s[start:end], "http") &&
+			!strings.HasPrefix(s[start:end], "www.") {
+			if end = trimEmail(s, start, end); end < 0 {
+				continue
+			}
+
+			destination = "mailto:" + s[start:end]
+		} else {
+			if start > 0 && !strings.ContainsRune(" \t\n*_~(", rune(s[start-1])) {
+				continue
+			}
+
+			if end = trimURL(s, start, end); end < 0 {
+				continue
+			}
+
+			destination = s[start:end]
+			if strings.HasPrefix(destination, "www.") {
+				destination = "http://" + destination
+			}
+		}
+
+		if start > last {
+			before := text(s[last:start])
+			current.insertAfter(before)
+			current = before
+		}
+
+		link := &inline{kind: inlineLink, destination: destination}
+		link.appendChild(text(s[start:end]))
+		current.insertAfter(link)
+		current, last = link, end
+	}
+
+	if current == n {
+		return
+	}
+
+	if last < len(s) {
+		current.insertAfter(text(s[last:]))
+	}
+
+	n.unlink()
+}
+
+// trimURL returns end of URL autolink started at start without trailing
+// punctuation, or -1 if it has no valid domain.
+func trimURL(s string, start, end int) int {
+	link := s[start:end]
+	allowShort := !strings.HasPrefix(link, "www.")
+
+	domain := link
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+3:]
+	}
+
+	if i := strings.IndexFunc(domain, func(r rune) bool {
+		return !(r == '.' || r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}); i >= 0 {
+		domain = domain[:i]
+	}
+
+	if !validDomain(domain, allowShort) {
+		return -1
+	}
+
+	for end > start {
+		switch c := s[end-1]; c {
+		case '?', '!', '.', ',', ':', '*', '_', '~', '\'', '"':
+			end--
+		case ';':
+			if match := reTrailingEntity.FindString(s[start:end]); match != "" {
+				end -= len(match)
+			} else {
+				end--
+			}
+		case ')':
+			if strings.Count(s[start:end], "(") >= strings.Count(s[start:end], ")") {
+				return end
+			}
+
+			end--
+		default:
+			return end
+		}
+	}
+
+	return -1
+}
+
+// trimEmail returns end of email autolink started at start, or -1 if it is
+// not a valid email.
+func trimEmail(s string, start, end int) int {
+	if start > 0 && (isAlnum(s[start-1]) || s[start-1] == '/') {
+		return -1
+	}
+
+	switch s[end-1] {
+	case '-', '_':
+		return -1
+	}
+
+	if end < len(s) && (s[end] == '-' || s[end] == '_' || s[end] == '@') {
+		return -1
+	}
+
+	return end
+}
+
+func validDomain(domain string, allowShort bool) bool {
+	segments := strings.Split(domain, ".")
+	if len(segments) < 2 && !allowShort || segments[0] == "" {
+		return false
+	}
+
+	for i := len(segments) - 1; i >= 0 && i >= len(segments)-2; i-- {
+		if strings.Contains(segments[i], "_") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
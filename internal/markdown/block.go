@@ -0,0 +1,928 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	blockKind int
+
+	block struct {
+		parent   *block
+		children []*block
+		content  strings.Builder // raw lines of leaf blocks
+		literal  string          // content of finalized code and HTML blocks
+		info     string          // info string of fenced code block
+		aligns   []string        // columns alignment of table
+		rows     [][]string      // raw cells of table, the header first
+		list     listData
+		kind     blockKind
+		level    int // heading level
+		htmlType int // HTML block start condition
+		line     int // number of start line
+		open     bool
+		blank    bool // last line is blank
+		tight    bool // list items are not separated by blank lines
+
+		// fenced code blocks
+		fenced      bool
+		fenceChar   byte
+		fenceLength int
+		fenceOffset int
+	}
+
+	listData struct {
+		delimiter    byte // bullet char or delimiter of ordered list
+		start        int
+		markerOffset int
+		padding      int
+		ordered      bool
+	}
+
+	blockParser struct {
+		doc                  *block
+		tip                  *block
+		oldTip               *block
+		lastMatchedContainer *block
+		refs                 map[string]reference
+		line                 string
+		lineNumber           int
+		offset               int
+		column               int
+		nextNonspace         int
+		nextNonspaceColumn   int
+		indent               int
+		indented             bool
+		blank                bool
+		partiallyConsumedTab bool
+		allClosed            bool
+	}
+
+	reference struct {
+		destination string
+		title       string
+	}
+)
+
+const (
+	kindDocument blockKind = iota
+	kindBlockQuote
+	kindList
+	kindItem
+	kindParagraph
+	kindHeading
+	kindThematicBreak
+	kindCodeBlock
+	kindHTMLBlock
+	kindTable
+)
+
+const codeIndent int = 4
+
+var (
+	reATXHeadingMarker  = regexp.MustCompile(`^#{1,6}(?:[ \t]+|$)`)
+	reATXClosingEmpty   = regexp.MustCompile(`^[ \t]*#+[ \t]*$`)
+	reATXClosing        = regexp.MustCompile(`[ \t]+#+[ \t]*$`)
+	reCodeFence         = regexp.MustCompile("^(?:`{3,}|~{3,})")
+	reClosingCodeFence  = regexp.MustCompile("^(?:`{3,}|~{3,})[ \t]*$")
+	reSetextHeadingLine = regexp.MustCompile(`^(?:=+|-+)[ \t]*$`)
+	reThematicBreak     = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:_[ \t]*){3,}|(?:-[ \t]*){3,})$`)
+	reOrderedListMarker = regexp.MustCompile(`^(\d{1,9})([.)])`)
+	reHTMLBlockOpen     = []*regexp.Regexp{
+		nil,
+		regexp.MustCompile(`(?i)^<(?:script|pre|textarea|style)(?:\s|>|$)`),
+		regexp.MustCompile(`^<!--`),
+		regexp.MustCompile(`^<[?]`),
+		regexp.MustCompile(`^<![A-Za-z]`),
+		regexp.MustCompile(`^<!\[CDATA\[`),
+		regexp.MustCompile(`(?i)^<[/]?(?:address|article|aside|base|basefont|blockquote|body|caption|center|col|` +
+			`colgroup|dd|details|dialog|dir|div|dl|dt|fieldset|figcaption|figure|footer|form|frame|frameset|h[123456]|` +
+			`head|header|hr|html|iframe|legend|li|link|main|menu|menuitem|nav|noframes|ol|optgroup|option|p|param|` +
+			`search|section|summary|table|tbody|td|tfoot|th|thead|title|tr|track|ul)(?:\s|[/]?[>]|$)`),
+		regexp.MustCompile(`(?i)^(?:` + openTag + `|` + closeTag + `)\s*$`),
+	}
+	reHTMLBlockClose = []*regexp.Regexp{
+		nil,
+		regexp.MustCompile(`(?i)</(?:script|pre|textarea|style)>`),
+		regexp.MustCompile(`-->`),
+		regexp.MustCompile(`\?>`),
+		regexp.MustCompile(`>`),
+		regexp.MustCompile(`\]\]>`),
+	}
+)
+
+// parseBlocks parses document structure of src. Inline contents of leaf blocks
+// stays raw.
+func parseBlocks(src string) (*block, map[string]reference) {
+	doc := &block{kind: kindDocument, open: true}
+	p := &blockParser{
+		doc:                  doc,
+		tip:                  doc,
+		oldTip:               doc,
+		lastMatchedContainer: doc,
+		refs:                 make(map[string]reference),
+	}
+
+	src = strings.ReplaceAll(src, "\x00", "�")
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(src, "\n")
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for _, line := range lines {
+		p.incorporateLine(line)
+	}
+
+	for p.tip != nil {
+		p.finalize(p.tip)
+	}
+
+	return doc, p.refs
+}
+
+func (b *block) lastChild() *block {
+	if len(b.children) == 0 {
+		return nil
+	}
+
+	return b.children[len(b.children)-1]
+}
+
+func (b *block) unlink() {
+	if b.parent == nil {
+		return
+	}
+
+	for i := range b.parent.children {
+		if b.parent.children[i] == b {
+			b.parent.children = append(b.parent.children[:i], b.parent.children[i+1:]...)
+
+			break
+		}
+	}
+
+	b.parent = nil
+}
+
+func (b *block) acceptsLines() bool {
+	switch b.kind {
+	case kindParagraph, kindCodeBlock, kindHTMLBlock, kindTable:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *block) canContain(kind blockKind) bool {
+	switch b.kind {
+	case kindDocument, kindBlockQuote, kindItem:
+		return kind != kindItem
+	case kindList:
+		return kind == kindItem
+	default:
+		return false
+	}
+}
+
+// endsWithBlankLine reports whether block or it's last descendant list item
+// ends with blank line.
+func (b *block) endsWithBlankLine() bool {
+	for b != nil {
+		if b.blank {
+			return true
+		}
+
+		if b.kind != kindList && b.kind != kindItem {
+			return false
+		}
+
+		b = b.lastChild()
+	}
+
+	return false
+}
+
+func (p *blockParser) incorporateLine(line string) {
+	container := p.doc
+	p.oldTip = p.tip
+	p.offset, p.column = 0, 0
+	p.blank, p.partiallyConsumedTab = false, false
+	p.lineNumber++
+	p.line = line
+	allMatched := true
+
+	for {
+		last := container.lastChild()
+		if last == nil || !last.open {
+			break
+		}
+
+		container = last
+		p.findNextNonspace()
+
+		switch p.continueBlock(container) {
+		case 1:
+			allMatched = false
+		case 2:
+			return
+		}
+
+		if !allMatched {
+			container = container.parent
+
+			break
+		}
+	}
+
+	p.allClosed = container == p.oldTip
+	p.lastMatchedContainer = container
+	matchedLeaf := container.kind != kindParagraph && container.kind != kindTable && container.acceptsLines()
+
+	for !matchedLeaf {
+		p.findNextNonspace()
+
+		result := 0
+
+		for _, start := range []func(*block) int{
+			p.startBlockQuote, p.startATXHeading, p.startFencedCode, p.startHTMLBlock, p.startTable,
+			p.startSetextHeading, p.startThematicBreak, p.startListItem, p.startIndentedCode,
+		} {
+			if result = start(container); result != 0 {
+				break
+			}
+		}
+
+		if result == 0 {
+			p.advanceNextNonspace()
+
+			break
+		}
+
+		container = p.tip
+		matchedLeaf = result == 2
+	}
+
+	// NOTE(toby3d): what remains at the offset is a text line.
+	if !p.allClosed && !p.blank && p.tip.kind == kindParagraph {
+		// NOTE(toby3d): lazy paragraph continuation.
+		p.addLine()
+
+		return
+	}
+
+	p.closeUnmatchedBlocks()
+
+	if last := container.lastChild(); p.blank && last != nil {
+		last.blank = true
+	}
+
+	lastLineBlank := p.blank && !(container.kind == kindBlockQuote ||
+		(container.kind == kindCodeBlock && container.fenced) ||
+		(container.kind == kindItem && len(container.children) == 0 && container.line == p.lineNumber))
+
+	for b := container; b != nil; b = b.parent {
+		b.blank = lastLineBlank
+	}
+
+	switch {
+	case container.kind == kindTable:
+		if container.line == p.lineNumber {
+			// NOTE(toby3d): delimiter row is already consumed.
+			break
+		}
+
+		container.rows = append(container.rows, splitRow(p.line[p.offset:]))
+	case container.acceptsLines():
+		p.addLine()
+
+		if container.kind == kindHTMLBlock && container.htmlType >= 1 && container.htmlType <= 5 &&
+			reHTMLBlockClose[container.htmlType].MatchString(p.line[p.offset:]) {
+			p.finalize(container)
+		}
+	case p.offset < len(p.line) && !p.blank:
+		p.addChild(kindParagraph)
+		p.advanceNextNonspace()
+		p.addLine()
+	}
+}
+
+// continueBlock returns 0 if container matches line, 1 if not, or 2 if line
+// is completely consumed by container.
+func (p *blockParser) continueBlock(container *block) int {
+	switch container.kind {
+	case kindBlockQuote:
+		if p.indented || p.peek(p.nextNonspace) != '>' {
+			return 1
+		}
+
+		p.advanceNextNonspace()
+		p.advanceOffset(1, false)
+
+		if isSpaceOrTab(p.peek(p.offset)) {
+			p.advanceOffset(1, true)
+		}
+	case kindItem:
+		switch {
+		case p.blank:
+			if len(container.children) == 0 {
+				return 1
+			}
+
+			p.advanceNextNonspace()
+		case p.indent >= container.list.markerOffset+container.list.padding:
+			p.advanceOffset(container.list.markerOffset+container.list.padding, true)
+		default:
+			return 1
+		}
+	case kindHeading, kindThematicBreak:
+		return 1
+	case kindCodeBlock:
+		if container.fenced {
+			rest := p.line[p.nextNonspace:]
+			if p.indent <= 3 && p.peek(p.nextNonspace) == container.fenceChar {
+				if match := reClosingCodeFence.FindString(rest); match != "" &&
+					len(strings.TrimRight(match, " \t")) >= container.fenceLength {
+					p.finalize(container)
+
+					return 2
+				}
+			}
+
+			for i := container.fenceOffset; i > 0 && isSpaceOrTab(p.peek(p.offset)); i-- {
+				p.advanceOffset(1, true)
+			}
+
+			return 0
+		}
+
+		switch {
+		case p.indent >= codeIndent:
+			p.advanceOffset(codeIndent, true)
+		case p.blank:
+			p.advanceNextNonspace()
+		default:
+			return 1
+		}
+	case kindHTMLBlock:
+		if p.blank && (container.htmlType == 6 || container.htmlType == 7) {
+			return 1
+		}
+	case kindParagraph, kindTable:
+		if p.blank {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (p *blockParser) startBlockQuote(_ *block) int {
+	if p.indented || p.peek(p.nextNonspace) != '>' {
+		return 0
+	}
+
+	p.advanceNextNonspace()
+	p.advanceOffset(1, false)
+
+	if isSpaceOrTab(p.peek(p.offset)) {
+		p.advanceOffset(1, true)
+	}
+
+	p.closeUnmatchedBlocks()
+	p.addChild(kindBlockQuote)
+
+	return 1
+}
+
+func (p *blockParser) startATXHeading(_ *block) int {
+	if p.indented {
+		return 0
+	}
+
+	match := reATXHeadingMarker.FindString(p.line[p.nextNonspace:])
+	if match == "" {
+		return 0
+	}
+
+	p.advanceNextNonspace()
+	p.advanceOffset(len(match), false)
+	p.closeUnmatchedBlocks()
+
+	heading := p.addChild(kindHeading)
+	heading.level = len(strings.TrimRight(match, " \t"))
+
+	// NOTE(toby3d): remove optional closing sequence.
+	content := reATXClosingEmpty.ReplaceAllString(p.line[p.offset:], "")
+	content = reATXClosing.ReplaceAllString(content, "")
+
+	heading.content.WriteString(content)
+	p.advanceOffset(len(p.line)-p.offset, false)
+
+	return 2
+}
+
+func (p *blockParser) startFencedCode(_ *block) int {
+	if p.indented {
+		return 0
+	}
+
+	rest := p.line[p.nextNonspace:]
+
+	match := reCodeFence.FindString(rest)
+	if match == "" || (match[0] == '`' && strings.Contains(rest[len(match):], "`")) {
+		return 0
+	}
+
+	p.closeUnmatchedBlocks()
+
+	code := p.addChild(kindCodeBlock)
+	code.fenced = true
+	code.fenceLength = len(match)
+	code.fenceChar = match[0]
+	code.fenceOffset = p.indent
+
+	p.advanceNextNonspace()
+	p.advanceOffset(len(match), false)
+
+	return 2
+}
+
+func (p *blockParser) startHTMLBlock(container *block) int {
+	if p.indented || p.peek(p.nextNonspace) != '<' {
+		return 0
+	}
+
+	rest := p.line[p.nextNonspace:]
+
+	for htmlType := 1; htmlType <= 7; htmlType++ {
+		if !reHTMLBlockOpen[htmlType].MatchString(rest) {
+			continue
+		}
+
+		// NOTE(toby3d): the last type cannot interrupt a paragraph.
+		if htmlType == 7 && (container.kind == kindParagraph ||
+			(!p.allClosed && !p.blank && p.tip.kind == kindParagraph)) {
+			continue
+		}
+
+		p.closeUnmatchedBlocks()
+
+		// NOTE(toby3d): leading spaces are preserved.
+		b := p.addChild(kindHTMLBlock)
+		b.htmlType = htmlType
+
+		return 2
+	}
+
+	return 0
+}
+
+// startTable converts the last line of paragraph into the header of GFM table
+// if current line is a delimiter row with the same number of cells.
+func (p *blockParser) startTable(container *block) int {
+	if p.indented || container.kind != kindParagraph {
+		return 0
+	}
+
+	aligns, ok := parseDelimiterRow(p.line[p.nextNonspace:])
+	if !ok {
+		return 0
+	}
+
+	lines := strings.Split(strings.TrimSuffix(container.content.String(), "\n"), "\n")
+
+	header := splitRow(lines[len(lines)-1])
+	if len(header) != len(aligns) {
+		return 0
+	}
+
+	p.closeUnmatchedBlocks()
+
+	if len(lines) > 1 {
+		container.content.Reset()
+		container.content.WriteString(strings.Join(lines[:len(lines)-1], "\n") + "\n")
+		p.finalize(container)
+	} else {
+		p.tip = container.parent
+		container.unlink()
+	}
+
+	table := p.addChild(kindTable)
+	table.aligns = aligns
+	table.rows = [][]string{header}
+
+	p.advanceOffset(len(p.line)-p.offset, false)
+
+	return 2
+}
+
+func (p *blockParser) startSetextHeading(container *block) int {
+	if p.indented || container.kind != kindParagraph || !reSetextHeadingLine.MatchString(p.line[p.nextNonspace:]) {
+		return 0
+	}
+
+	p.closeUnmatchedBlocks()
+
+	content := container.content.String()
+	for strings.HasPrefix(content, "[") {
+		n := parseReference(content, p.refs)
+		if n == 0 {
+			break
+		}
+
+		content = content[n:]
+	}
+
+	if content == "" {
+		return 0
+	}
+
+	heading := &block{kind: kindHeading, open: true, line: container.line, parent: container.parent}
+	heading.content.WriteString(content)
+
+	if p.line[p.nextNonspace] == '=' {
+		heading.level = 1
+	} else {
+		heading.level = 2
+	}
+
+	parent := container.parent
+	container.unlink()
+	parent.children = append(parent.children, heading)
+	p.tip = heading
+	p.advanceOffset(len(p.line)-p.offset, false)
+
+	return 2
+}
+
+func (p *blockParser) startThematicBreak(_ *block) int {
+	if p.indented || !reThematicBreak.MatchString(p.line[p.nextNonspace:]) {
+		return 0
+	}
+
+	p.closeUnmatchedBlocks()
+	p.addChild(kindThematicBreak)
+	p.advanceOffset(len(p.line)-p.offset, false)
+
+	return 2
+}
+
+func (p *blockParser) startListItem(container *block) int {
+	if p.indented && container.kind != kindList {
+		return 0
+	}
+
+	data, ok := p.parseListMarker(container)
+	if !ok {
+		return 0
+	}
+
+	p.closeUnmatchedBlocks()
+
+	if p.tip.kind != kindList || p.tip.list.ordered != data.ordered || p.tip.list.delimiter != data.delimiter {
+		list := p.addChild(kindList)
+		list.list = data
+		list.tight = true
+	}
+
+	item := p.addChild(kindItem)
+	item.list = data
+
+	return 1
+}
+
+func (p *blockParser) startIndentedCode(_ *block) int {
+	if !p.indented || p.blank || p.tip.kind == kindParagraph || p.tip.kind == kindTable {
+		return 0
+	}
+
+	p.advanceOffset(codeIndent, true)
+	p.closeUnmatchedBlocks()
+	p.addChild(kindCodeBlock)
+
+	return 2
+}
+
+func (p *blockParser) parseListMarker(container *block) (listData, bool) {
+	data := listData{markerOffset: p.indent}
+	if p.indent >= codeIndent {
+		return data, false
+	}
+
+	rest := p.line[p.nextNonspace:]
+
+	var markerLength int
+
+	if c := p.peek(p.nextNonspace); c == '*' || c == '+' || c == '-' {
+		data.delimiter = c
+		markerLength = 1
+	} else if match := reOrderedListMarker.FindStringSubmatch(rest); match != nil &&
+		(container.kind != kindParagraph || match[1] == "1") {
+		data.ordered = true
+		data.start, _ = strconv.Atoi(match[1])
+		data.delimiter = match[2][0]
+		markerLength = len(match[0])
+	} else {
+		return data, false
+	}
+
+	// NOTE(toby3d): marker must be followed by whitespace and list item
+	// cannot interrupt paragraph by empty line.
+	if next := p.peek(p.nextNonspace + markerLength); next != 0 && !isSpaceOrTab(next) {
+		return data, false
+	}
+
+	if container.kind == kindParagraph && strings.Trim(rest[markerLength:], " \t") == "" {
+		return data, false
+	}
+
+	p.advanceNextNonspace()
+	p.advanceOffset(markerLength, true)
+
+	spacesStartColumn, spacesStartOffset := p.column, p.offset
+
+	for {
+		p.advanceOffset(1, true)
+
+		if p.column-spacesStartColumn >= 5 || !isSpaceOrTab(p.peek(p.offset)) {
+			break
+		}
+	}
+
+	blankItem := p.offset >= len(p.line)
+	spacesAfterMarker := p.column - spacesStartColumn
+
+	if spacesAfterMarker >= 5 || spacesAfterMarker < 1 || blankItem {
+		data.padding = markerLength + 1
+		p.column, p.offset = spacesStartColumn, spacesStartOffset
+
+		if isSpaceOrTab(p.peek(p.offset)) {
+			p.advanceOffset(1, true)
+		}
+	} else {
+		data.padding = markerLength + spacesAfterMarker
+	}
+
+	return data, true
+}
+
+func (p *blockParser) addChild(kind blockKind) *block {
+	for !p.tip.canContain(kind) {
+		p.finalize(p.tip)
+	}
+
+	child := &block{kind: kind, parent: p.tip, open: true, line: p.lineNumber}
+	p.tip.children = append(p.tip.children, child)
+	p.tip = child
+
+	return child
+}
+
+func (p *blockParser) addLine() {
+	if p.partiallyConsumedTab {
+		p.offset++
+		p.tip.content.WriteString(strings.Repeat(" ", 4-p.column%4))
+	}
+
+	p.tip.content.WriteString(p.line[p.offset:])
+	p.tip.content.WriteByte('\n')
+}
+
+func (p *blockParser) closeUnmatchedBlocks() {
+	if p.allClosed {
+		return
+	}
+
+	for p.oldTip != p.lastMatchedContainer {
+		parent := p.oldTip.parent
+		p.finalize(p.oldTip)
+		p.oldTip = parent
+	}
+
+	p.allClosed = true
+}
+
+func (p *blockParser) finalize(b *block) {
+	b.open = false
+	p.tip = b.parent
+
+	switch b.kind {
+	case kindParagraph:
+		content := b.content.String()
+
+		var hasReferences bool
+
+		for strings.HasPrefix(content, "[") {
+			n := parseReference(content, p.refs)
+			if n == 0 {
+				break
+			}
+
+			content, hasReferences = content[n:], true
+		}
+
+		b.content.Reset()
+		b.content.WriteString(content)
+
+		if hasReferences && strings.Trim(content, " \t\n") == "" {
+			b.unlink()
+		}
+	case kindCodeBlock:
+		content := b.content.String()
+
+		if b.fenced {
+			first, rest, _ := strings.Cut(content, "\n")
+			b.info = unescapeString(strings.Trim(first, " \t"))
+			b.literal = rest
+
+			break
+		}
+
+		lines := strings.Split(content, "\n")
+		for len(lines) > 0 && strings.Trim(lines[len(lines)-1], " \t") == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		b.literal = strings.Join(lines, "\n") + "\n"
+	case kindHTMLBlock:
+		lines := strings.Split(b.content.String(), "\n")
+		for len(lines) > 0 && strings.Trim(lines[len(lines)-1], " ") == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		b.literal = strings.Join(lines, "\n")
+	case kindList:
+		b.tight = true
+
+		for i, item := range b.children {
+			last := i == len(b.children)-1
+			if item.endsWithBlankLine() && !last {
+				b.tight = false
+
+				break
+			}
+
+			for j, child := range item.children {
+				if child.endsWithBlankLine() && (!last || j < len(item.children)-1) {
+					b.tight = false
+
+					break
+				}
+			}
+
+			if !b.tight {
+				break
+			}
+		}
+	}
+}
+
+func (p *blockParser) findNextNonspace() {
+	i, column := p.offset, p.column
+
+loop:
+	for i < len(p.line) {
+		switch p.line[i] {
+		case ' ':
+			i++
+			column++
+		case '\t':
+			i++
+			column += 4 - column%4
+		default:
+			break loop
+		}
+	}
+
+	p.blank = i >= len(p.line)
+	p.nextNonspace = i
+	p.nextNonspaceColumn = column
+	p.indent = column - p.column
+	p.indented = p.indent >= codeIndent
+}
+
+func (p *blockParser) advanceNextNonspace() {
+	p.offset = p.nextNonspace
+	p.column = p.nextNonspaceColumn
+	p.partiallyConsumedTab = false
+}
+
+// advanceOffset moves offset by count bytes or, if columns is true, by count
+// columns with partial consuming of tabs.
+func (p *blockParser) advanceOffset(count int, columns bool) {
+	for count > 0 && p.offset < len(p.line) {
+		if p.line[p.offset] != '\t' {
+			p.partiallyConsumedTab = false
+			p.offset++
+			p.column++
+			count--
+
+			continue
+		}
+
+		charsToTab := 4 - p.column%4
+
+		if !columns {
+			p.partiallyConsumedTab = false
+			p.column += charsToTab
+			p.offset++
+			count--
+
+			continue
+		}
+
+		p.partiallyConsumedTab = charsToTab > count
+		charsToAdvance := charsToTab
+
+		if count < charsToAdvance {
+			charsToAdvance = count
+		}
+
+		p.column += charsToAdvance
+
+		if !p.partiallyConsumedTab {
+			p.offset++
+		}
+
+		count -= charsToAdvance
+	}
+}
+
+func (p *blockParser) peek(i int) byte {
+	if i < len(p.line) {
+		return p.line[i]
+	}
+
+	return 0
+}
+
+// parseDelimiterRow returns alignments of GFM table columns.
+func parseDelimiterRow(line string) ([]string, bool) {
+	if !strings.Contains(line, "|") {
+		return nil, false
+	}
+
+	cells := splitRow(line)
+	out := make([]string, 0, len(cells))
+
+	for _, cell := range cells {
+		cell = strings.Trim(cell, " \t")
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+
+		if dashes := strings.Trim(cell, ":"); dashes == "" || strings.Trim(dashes, "-") != "" ||
+			strings.Count(cell, ":") > 2 {
+			return nil, false
+		}
+
+		switch {
+		case left && right:
+			out = append(out, "center")
+		case left:
+			out = append(out, "left")
+		case right:
+			out = append(out, "right")
+		default:
+			out = append(out, "")
+		}
+	}
+
+	return out, len(out) > 0
+}
+
+// splitRow splits GFM table row into raw cells by unescaped pipes.
+func splitRow(line string) []string {
+	line = strings.Trim(line, " \t")
+	if strings.HasPrefix(line, "|") {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	out := make([]string, 0)
+	cell := new(strings.Builder)
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			out = append(out, strings.Trim(cell.String(), " \t"))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(out, strings.Trim(cell.String(), " \t"))
+}
+
+func isSpaceOrTab(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,7 @@
+// Package markdown provides rendering of CommonMark documents into HTML with
+// GitHub Flavored Markdown tables, strikethrough and extended autolinks.
+//
+// See: https://spec.commonmark.org/
+//
+// See: https://github.github.com/gfm/
+package markdown
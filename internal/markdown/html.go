@@ -0,0 +1,240 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+type renderer struct {
+	*strings.Builder
+	refs map[string]reference
+}
+
+var htmlEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;")
+
+func (r renderer) renderBlock(b *block, tight bool) {
+	switch b.kind {
+	case kindDocument:
+		for _, child := range b.children {
+			r.renderBlock(child, false)
+		}
+	case kindBlockQuote:
+		r.cr()
+		r.WriteString("<blockquote>\n")
+
+		for _, child := range b.children {
+			r.renderBlock(child, false)
+		}
+
+		r.cr()
+		r.WriteString("</blockquote>\n")
+	case kindList:
+		tag := "ul"
+		if b.list.ordered {
+			tag = "ol"
+		}
+
+		r.cr()
+		r.WriteString("<" + tag)
+
+		if b.list.ordered && b.list.start != 1 {
+			r.WriteString(` start="` + strconv.Itoa(b.list.start) + `"`)
+		}
+
+		r.WriteString(">\n")
+
+		for _, child := range b.children {
+			r.renderBlock(child, b.tight)
+		}
+
+		r.cr()
+		r.WriteString("</" + tag + ">\n")
+	case kindItem:
+		r.WriteString("<li>")
+
+		for _, child := range b.children {
+			r.renderBlock(child, tight)
+		}
+
+		r.WriteString("</li>\n")
+	case kindParagraph:
+		if tight {
+			r.renderInlines(parseInlines(b.content.String(), r.refs))
+
+			break
+		}
+
+		r.cr()
+		r.WriteString("<p>")
+		r.renderInlines(parseInlines(b.content.String(), r.refs))
+		r.WriteString("</p>\n")
+	case kindHeading:
+		tag := "h" + strconv.Itoa(b.level)
+
+		r.cr()
+		r.WriteString("<" + tag + ">")
+		r.renderInlines(parseInlines(b.content.String(), r.refs))
+		r.WriteString("</" + tag + ">\n")
+	case kindThematicBreak:
+		r.cr()
+		r.WriteString("<hr />\n")
+	case kindCodeBlock:
+		r.cr()
+		r.WriteString("<pre><code")
+
+		if lang, _, _ := strings.Cut(b.info, " "); lang != "" {
+			r.WriteString(` class="language-` + htmlEscaper.Replace(lang) + `"`)
+		}
+
+		r.WriteString(">" + htmlEscaper.Replace(b.literal) + "</code></pre>\n")
+	case kindHTMLBlock:
+		r.cr()
+		r.WriteString(b.literal)
+		r.cr()
+	case kindTable:
+		r.renderTable(b)
+	}
+}
+
+func (r renderer) renderTable(b *block) {
+	r.cr()
+	r.WriteString("<table>\n<thead>\n")
+	r.renderRow(b.rows[0], b.aligns, "th")
+	r.WriteString("</thead>\n")
+
+	if len(b.rows) > 1 {
+		r.WriteString("<tbody>\n")
+
+		for _, row := range b.rows[1:] {
+			r.renderRow(row, b.aligns, "td")
+		}
+
+		r.WriteString("</tbody>\n")
+	}
+
+	r.WriteString("</table>\n")
+}
+
+func (r renderer) renderRow(row, aligns []string, tag string) {
+	r.WriteString("<tr>\n")
+
+	for i, align := range aligns {
+		r.WriteString("<" + tag)
+
+		if align != "" {
+			r.WriteString(` align="` + align + `"`)
+		}
+
+		r.WriteString(">")
+
+		if i < len(row) {
+			r.renderInlines(parseInlines(row[i], r.refs))
+		}
+
+		r.WriteString("</" + tag + ">\n")
+	}
+
+	r.WriteString("</tr>\n")
+}
+
+func (r renderer) renderInlines(n *inline) {
+	for c := n.first; c != nil; c = c.next {
+		switch c.kind {
+		case inlineText:
+			r.WriteString(htmlEscaper.Replace(c.literal))
+		case inlineSoftBreak:
+			r.WriteString("\n")
+		case inlineHardBreak:
+			r.WriteString("<br />\n")
+		case inlineCode:
+			r.WriteString("<code>" + htmlEscaper.Replace(c.literal) + "</code>")
+		case inlineHTML:
+			r.WriteString(c.literal)
+		case inlineEmph:
+			r.WriteString("<em>")
+			r.renderInlines(c)
+			r.WriteString("</em>")
+		case inlineStrong:
+			r.WriteString("<strong>")
+			r.renderInlines(c)
+			r.WriteString("</strong>")
+		case inlineDel:
+			r.WriteString("<del>")
+			r.renderInlines(c)
+			r.WriteString("</del>")
+		case inlineLink:
+			r.WriteString(`<a href="` + htmlEscaper.Replace(normalizeURI(c.destination)) + `"`)
+
+			if c.title != "" {
+				r.WriteString(` title="` + htmlEscaper.Replace(c.title) + `"`)
+			}
+
+			r.WriteString(">")
+			r.renderInlines(c)
+			r.WriteString("</a>")
+		case inlineImage:
+			r.WriteString(`<img src="` + htmlEscaper.Replace(normalizeURI(c.destination)) + `" alt="`)
+			r.renderPlainText(c)
+			r.WriteString(`"`)
+
+			if c.title != "" {
+				r.WriteString(` title="` + htmlEscaper.Replace(c.title) + `"`)
+			}
+
+			r.WriteString(" />")
+		}
+	}
+}
+
+// renderPlainText writes text content of n without markup, as image
+// descriptions does.
+func (r renderer) renderPlainText(n *inline) {
+	for c := n.first; c != nil; c = c.next {
+		switch c.kind {
+		case inlineText, inlineCode:
+			r.WriteString(htmlEscaper.Replace(c.literal))
+		case inlineSoftBreak, inlineHardBreak:
+			r.WriteString("\n")
+		default:
+			r.renderPlainText(c)
+		}
+	}
+}
+
+// cr writes a newline if output is not empty and not ends with newline.
+func (r renderer) cr() {
+	if s := r.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		r.WriteString("\n")
+	}
+}
+
+// normalizeURI percent-encodes characters of uri which are not allowed in URI
+// keeping already encoded sequences as is.
+func normalizeURI(uri string) string {
+	const (
+		safe = ";/?:@&=+$,-_.!~*'()#"
+		hex  = "0123456789ABCDEF"
+	)
+
+	out := new(strings.Builder)
+
+	for i := 0; i < len(uri); i++ {
+		switch c := uri[i]; {
+		case c == '%' && i+2 < len(uri) && isHex(uri[i+1]) && isHex(uri[i+2]):
+			out.WriteString(uri[i : i+3])
+			i += 2
+		case isAlnum(c) || strings.IndexByte(safe, c) >= 0:
+			out.WriteByte(c)
+		default:
+			out.WriteByte('%')
+			out.WriteByte(hex[c>>4])
+			out.WriteByte(hex[c&0xF])
+		}
+	}
+
+	return out.String()
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
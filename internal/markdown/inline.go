@@ -0,0 +1,840 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	inlineKind int
+
+	inline struct {
+		parent, first, last, prev, next *inline
+		literal                         string
+		destination                     string
+		title                           string
+		kind                            inlineKind
+	}
+
+	delimiter struct {
+		previous, next *delimiter
+		node           *inline
+		numDelims      int
+		origDelims     int
+		char           byte
+		canOpen        bool
+		canClose       bool
+	}
+
+	bracket struct {
+		previous          *bracket
+		previousDelimiter *delimiter
+		node              *inline
+		index             int
+		image             bool
+		active            bool
+		bracketAfter      bool
+	}
+
+	inlineParser struct {
+		refs       map[string]reference
+		delimiters *delimiter
+		brackets   *bracket
+		subject    string
+		pos        int
+	}
+)
+
+const (
+	inlineRoot inlineKind = iota
+	inlineText
+	inlineSoftBreak
+	inlineHardBreak
+	inlineCode
+	inlineHTML
+	inlineEmph
+	inlineStrong
+	inlineDel
+	inlineLink
+	inlineImage
+)
+
+const (
+	tagName              = `[A-Za-z][A-Za-z0-9-]*`
+	attributeName        = `[a-zA-Z_:][a-zA-Z0-9:._-]*`
+	attributeValue       = `(?:[^"'=<>` + "`" + `\x00-\x20]+|'[^']*'|"[^"]*")`
+	attribute            = `(?:\s+` + attributeName + `(?:\s*=\s*` + attributeValue + `)?)`
+	openTag              = `<` + tagName + attribute + `*\s*/?>`
+	closeTag             = `</` + tagName + `\s*[>]`
+	htmlComment          = `<!-->|<!--->|<!--(?s:.*?)-->`
+	processingInstuction = `[<][?](?s:.*?)[?][>]`
+	declaration          = `<![A-Za-z]+[^>]*>`
+	cdata                = `<!\[CDATA\[(?s:.*?)\]\]>`
+)
+
+var (
+	reHTMLTag = regexp.MustCompile(`^(?:` + openTag + `|` + closeTag + `|` + htmlComment + `|` +
+		processingInstuction + `|` + declaration + `|` + cdata + `)`)
+	reEmailAutolink = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_` + "`" +
+		`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)>`)
+	reAutolink          = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9.+-]{1,31}:[^<>\x00-\x20]*)>`)
+	reEntity            = regexp.MustCompile(`^&(?:#[xX][a-fA-F0-9]{1,6}|#[0-9]{1,7}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	reEntityOrEscape    = regexp.MustCompile(`\\[!"#$%&'()*+,./:;<=>?@[\\\]^_` + "`" + `{|}~-]|` + reEntity.String()[1:])
+	reLinkTitle         = regexp.MustCompile(`^(?:"(?:\\[\s\S]|[^\\"\x00])*"|'(?:\\[\s\S]|[^\\'\x00])*'|\((?:\\[\s\S]|[^\\()\x00])*\))`)
+	reLinkDestBraces    = regexp.MustCompile(`^<(?:[^<>\n\\\x00]|\\.)*>`)
+	reLinkLabel         = regexp.MustCompile(`^\[(?:[^\\\[\]]|\\.){0,1000}\]`)
+	reSpaceAtEndOfLine  = regexp.MustCompile(`^ *(?:\n|$)`)
+	reUnicodeWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// parseInlines parses inline content of leaf block.
+func parseInlines(content string, refs map[string]reference) *inline {
+	p := &inlineParser{
+		subject: strings.Trim(content, " \t\n"),
+		refs:    refs,
+	}
+	root := &inline{kind: inlineRoot}
+
+	for p.pos < len(p.subject) {
+		p.parseInline(root)
+	}
+
+	p.processEmphasis(nil)
+	mergeText(root)
+	autolink(root)
+
+	return root
+}
+
+func (p *inlineParser) parseInline(block *inline) {
+	switch c := p.subject[p.pos]; c {
+	case '\n':
+		p.parseNewline(block)
+	case '\\':
+		p.parseBackslash(block)
+	case '`':
+		p.parseBackticks(block)
+	case '*', '_', '~':
+		p.handleDelim(c, block)
+	case '[':
+		block.appendChild(text("["))
+		p.addBracket(block.last, p.pos, false)
+		p.pos++
+	case '!':
+		p.parseBang(block)
+	case ']':
+		p.parseCloseBracket(block)
+	case '<':
+		if !p.parseAutolink(block) && !p.parseHTMLTag(block) {
+			block.appendChild(text("<"))
+			p.pos++
+		}
+	case '&':
+		p.parseEntity(block)
+	default:
+		end := strings.IndexAny(p.subject[p.pos:], "\n\\`*_~[]!<&")
+		if end < 0 {
+			end = len(p.subject) - p.pos
+		}
+
+		block.appendChild(text(p.subject[p.pos : p.pos+end]))
+		p.pos += end
+	}
+}
+
+func (p *inlineParser) parseNewline(block *inline) {
+	p.pos++
+
+	kind := inlineSoftBreak
+
+	if last := block.last; last != nil && last.kind == inlineText && strings.HasSuffix(last.literal, " ") {
+		if strings.HasSuffix(last.literal, "  ") {
+			kind = inlineHardBreak
+		}
+
+		last.literal = strings.TrimRight(last.literal, " ")
+	}
+
+	block.appendChild(&inline{kind: kind})
+
+	// NOTE(toby3d): skip spaces at the beginning of the next line.
+	for p.pos < len(p.subject) && p.subject[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *inlineParser) parseBackslash(block *inline) {
+	p.pos++
+
+	switch {
+	case p.peek() == '\n':
+		p.pos++
+		block.appendChild(&inline{kind: inlineHardBreak})
+	case isASCIIPunct(p.peek()):
+		block.appendChild(text(p.subject[p.pos : p.pos+1]))
+		p.pos++
+	default:
+		block.appendChild(text(`\`))
+	}
+}
+
+func (p *inlineParser) parseBackticks(block *inline) {
+	start := p.pos
+	for p.pos < len(p.subject) && p.subject[p.pos] == '`' {
+		p.pos++
+	}
+
+	ticks := p.pos - start
+	afterOpenTicks := p.pos
+
+	for p.pos < len(p.subject) {
+		i := strings.IndexByte(p.subject[p.pos:], '`')
+		if i < 0 {
+			break
+		}
+
+		closeStart := p.pos + i
+		p.pos = closeStart
+
+		for p.pos < len(p.subject) && p.subject[p.pos] == '`' {
+			p.pos++
+		}
+
+		if p.pos-closeStart != ticks {
+			continue
+		}
+
+		contents := strings.ReplaceAll(p.subject[afterOpenTicks:closeStart], "\n", " ")
+		if len(contents) > 2 && contents[0] == ' ' && contents[len(contents)-1] == ' ' &&
+			strings.Trim(contents, " ") != "" {
+			contents = contents[1 : len(contents)-1]
+		}
+
+		block.appendChild(&inline{kind: inlineCode, literal: contents})
+
+		return
+	}
+
+	// NOTE(toby3d): closing backtick sequence not found.
+	p.pos = afterOpenTicks
+	block.appendChild(text(p.subject[start:afterOpenTicks]))
+}
+
+func (p *inlineParser) handleDelim(c byte, block *inline) {
+	numDelims, canOpen, canClose := p.scanDelims(c)
+	node := text(p.subject[p.pos : p.pos+numDelims])
+	p.pos += numDelims
+
+	block.appendChild(node)
+
+	if !canOpen && !canClose {
+		return
+	}
+
+	p.delimiters = &delimiter{
+		char:       c,
+		numDelims:  numDelims,
+		origDelims: numDelims,
+		node:       node,
+		previous:   p.delimiters,
+		canOpen:    canOpen,
+		canClose:   canClose,
+	}
+
+	if p.delimiters.previous != nil {
+		p.delimiters.previous.next = p.delimiters
+	}
+}
+
+func (p *inlineParser) scanDelims(c byte) (int, bool, bool) {
+	numDelims := 0
+	for p.pos+numDelims < len(p.subject) && p.subject[p.pos+numDelims] == c {
+		numDelims++
+	}
+
+	before, after := '\n', '\n'
+	if p.pos > 0 {
+		before, _ = utf8.DecodeLastRuneInString(p.subject[:p.pos])
+	}
+
+	if p.pos+numDelims < len(p.subject) {
+		after, _ = utf8.DecodeRuneInString(p.subject[p.pos+numDelims:])
+	}
+
+	afterIsWhitespace, afterIsPunct := unicode.IsSpace(after), isPunct(after)
+	beforeIsWhitespace, beforeIsPunct := unicode.IsSpace(before), isPunct(before)
+	leftFlanking := !afterIsWhitespace && (!afterIsPunct || beforeIsWhitespace || beforeIsPunct)
+	rightFlanking := !beforeIsWhitespace && (!beforeIsPunct || afterIsWhitespace || afterIsPunct)
+
+	switch c {
+	case '_':
+		return numDelims, leftFlanking && (!rightFlanking || beforeIsPunct),
+			rightFlanking && (!leftFlanking || afterIsPunct)
+	case '~':
+		// NOTE(toby3d): GFM strikethrough uses one or two tildes.
+		if numDelims > 2 {
+			return numDelims, false, false
+		}
+	}
+
+	return numDelims, leftFlanking, rightFlanking
+}
+
+// processEmphasis matches emphasis delimiters above bottom of the stack into
+// emphasis, strong emphasis and strikethrough nodes.
+func (p *inlineParser) processEmphasis(bottom *delimiter) {
+	openersBottom := make(map[byte]*[6]*delimiter)
+	for _, c := range []byte{'*', '_', '~'} {
+		openersBottom[c] = &[6]*delimiter{bottom, bottom, bottom, bottom, bottom, bottom}
+	}
+
+	closer := p.delimiters
+	for closer != nil && closer.previous != bottom {
+		closer = closer.previous
+	}
+
+	for closer != nil {
+		if !closer.canClose {
+			closer = closer.next
+
+			continue
+		}
+
+		index := closer.origDelims % 3
+		if closer.canOpen {
+			index += 3
+		}
+
+		opener := closer.previous
+		openerFound := false
+
+		for opener != nil && opener != bottom && opener != openersBottom[closer.char][index] {
+			oddMatch := (closer.canOpen || opener.canClose) && closer.origDelims%3 != 0 &&
+				(opener.origDelims+closer.origDelims)%3 == 0
+			if closer.char == '~' {
+				oddMatch = opener.numDelims != closer.numDelims
+			}
+
+			if opener.char == closer.char && opener.canOpen && !oddMatch {
+				openerFound = true
+
+				break
+			}
+
+			opener = opener.previous
+		}
+
+		oldCloser := closer
+
+		if !openerFound {
+			closer = closer.next
+			openersBottom[oldCloser.char][index] = oldCloser.previous
+
+			if !oldCloser.canOpen {
+				p.removeDelimiter(oldCloser)
+			}
+
+			continue
+		}
+
+		useDelims := 1
+		kind := inlineEmph
+
+		switch {
+		case closer.char == '~':
+			useDelims, kind = closer.numDelims, inlineDel
+		case closer.numDelims >= 2 && opener.numDelims >= 2:
+			useDelims, kind = 2, inlineStrong
+		}
+
+		openerNode, closerNode := opener.node, closer.node
+		opener.numDelims -= useDelims
+		closer.numDelims -= useDelims
+		openerNode.literal = openerNode.literal[:len(openerNode.literal)-useDelims]
+		closerNode.literal = closerNode.literal[:len(closerNode.literal)-useDelims]
+
+		emph := &inline{kind: kind}
+
+		for tmp := openerNode.next; tmp != nil && tmp != closerNode; {
+			next := tmp.next
+			tmp.unlink()
+			emph.appendChild(tmp)
+			tmp = next
+		}
+
+		openerNode.insertAfter(emph)
+
+		// NOTE(toby3d): remove delimiters between opener and closer.
+		if opener.next != closer {
+			opener.next = closer
+			closer.previous = opener
+		}
+
+		if opener.numDelims == 0 {
+			openerNode.unlink()
+			p.removeDelimiter(opener)
+		}
+
+		if closer.numDelims == 0 {
+			closerNode.unlink()
+			next := closer.next
+			p.removeDelimiter(closer)
+			closer = next
+		}
+	}
+
+	for p.delimiters != nil && p.delimiters != bottom {
+		p.removeDelimiter(p.delimiters)
+	}
+}
+
+func (p *inlineParser) removeDelimiter(d *delimiter) {
+	if d.previous != nil {
+		d.previous.next = d.next
+	}
+
+	if d.next == nil {
+		p.delimiters = d.previous
+	} else {
+		d.next.previous = d.previous
+	}
+}
+
+func (p *inlineParser) addBracket(node *inline, index int, image bool) {
+	if p.brackets != nil {
+		p.brackets.bracketAfter = true
+	}
+
+	p.brackets = &bracket{
+		node:              node,
+		previous:          p.brackets,
+		previousDelimiter: p.delimiters,
+		index:             index,
+		image:             image,
+		active:            true,
+	}
+}
+
+func (p *inlineParser) parseBang(block *inline) {
+	start := p.pos
+	p.pos++
+
+	if p.peek() != '[' {
+		block.appendChild(text("!"))
+
+		return
+	}
+
+	p.pos++
+	block.appendChild(text("!["))
+	p.addBracket(block.last, start+1, true)
+}
+
+func (p *inlineParser) parseCloseBracket(block *inline) {
+	p.pos++
+	start := p.pos
+
+	opener := p.brackets
+	if opener == nil {
+		block.appendChild(text("]"))
+
+		return
+	}
+
+	if !opener.active {
+		block.appendChild(text("]"))
+		p.brackets = opener.previous
+
+		return
+	}
+
+	var (
+		destination, title string
+		matched            bool
+	)
+
+	savePos := p.pos
+
+	// NOTE(toby3d): inline link.
+	if p.peek() == '(' {
+		p.pos++
+		p.spnl()
+
+		if dest, ok := p.parseLinkDestination(); ok {
+			p.spnl()
+
+			if isASCIISpace(p.subject[p.pos-1]) {
+				title, _ = p.parseLinkTitle()
+			}
+
+			p.spnl()
+
+			if p.peek() == ')' {
+				p.pos++
+				destination, matched = dest, true
+			}
+		}
+
+		if !matched {
+			p.pos = savePos
+		}
+	}
+
+	// NOTE(toby3d): reference link.
+	if !matched {
+		var label string
+
+		beforeLabel := p.pos
+		n := p.parseLinkLabel()
+
+		switch {
+		case n > 2:
+			label = p.subject[beforeLabel : beforeLabel+n]
+		case !opener.bracketAfter:
+			// NOTE(toby3d): empty or missing second label means to
+			// use the first label as the reference.
+			label = p.subject[opener.index:start]
+		}
+
+		if n == 0 {
+			p.pos = savePos
+		}
+
+		if label != "" {
+			if ref, ok := p.refs[normalizeReference(label)]; ok {
+				destination, title, matched = ref.destination, ref.title, true
+			}
+		}
+	}
+
+	if !matched {
+		p.brackets = opener.previous
+		p.pos = start
+		block.appendChild(text("]"))
+
+		return
+	}
+
+	node := &inline{kind: inlineLink, destination: destination, title: title}
+	if opener.image {
+		node.kind = inlineImage
+	}
+
+	for tmp := opener.node.next; tmp != nil; {
+		next := tmp.next
+		tmp.unlink()
+		node.appendChild(tmp)
+		tmp = next
+	}
+
+	block.appendChild(node)
+	p.processEmphasis(opener.previousDelimiter)
+	p.brackets = opener.previous
+	opener.node.unlink()
+
+	// NOTE(toby3d): links may not contain other links.
+	if !opener.image {
+		for b := p.brackets; b != nil; b = b.previous {
+			if !b.image {
+				b.active = false
+			}
+		}
+	}
+}
+
+func (p *inlineParser) parseLinkDestination() (string, bool) {
+	if match := reLinkDestBraces.FindString(p.subject[p.pos:]); match != "" {
+		p.pos += len(match)
+
+		return unescapeString(match[1 : len(match)-1]), true
+	}
+
+	if p.peek() == '<' {
+		return "", false
+	}
+
+	start, openParens := p.pos, 0
+
+loop:
+	for p.pos < len(p.subject) {
+		switch c := p.subject[p.pos]; {
+		case c == '\\' && p.pos+1 < len(p.subject) && isASCIIPunct(p.subject[p.pos+1]):
+			p.pos += 2
+		case c == '(':
+			p.pos++
+			openParens++
+		case c == ')':
+			if openParens < 1 {
+				break loop
+			}
+
+			p.pos++
+			openParens--
+		case c <= ' ':
+			break loop
+		default:
+			p.pos++
+		}
+	}
+
+	if (p.pos == start && p.peek() != ')') || openParens != 0 {
+		return "", false
+	}
+
+	return unescapeString(p.subject[start:p.pos]), true
+}
+
+func (p *inlineParser) parseLinkTitle() (string, bool) {
+	match := reLinkTitle.FindString(p.subject[p.pos:])
+	if match == "" {
+		return "", false
+	}
+
+	p.pos += len(match)
+
+	return unescapeString(match[1 : len(match)-1]), true
+}
+
+func (p *inlineParser) parseLinkLabel() int {
+	match := reLinkLabel.FindString(p.subject[p.pos:])
+	if match == "" {
+		return 0
+	}
+
+	p.pos += len(match)
+
+	return len(match)
+}
+
+func (p *inlineParser) parseAutolink(block *inline) bool {
+	rest := p.subject[p.pos:]
+
+	if match := reEmailAutolink.FindStringSubmatch(rest); match != nil {
+		p.pos += len(match[0])
+		link := &inline{kind: inlineLink, destination: "mailto:" + match[1]}
+		link.appendChild(text(match[1]))
+		block.appendChild(link)
+
+		return true
+	}
+
+	if match := reAutolink.FindStringSubmatch(rest); match != nil {
+		p.pos += len(match[0])
+		link := &inline{kind: inlineLink, destination: match[1]}
+		link.appendChild(text(match[1]))
+		block.appendChild(link)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *inlineParser) parseHTMLTag(block *inline) bool {
+	match := reHTMLTag.FindString(p.subject[p.pos:])
+	if match == "" {
+		return false
+	}
+
+	p.pos += len(match)
+	block.appendChild(&inline{kind: inlineHTML, literal: match})
+
+	return true
+}
+
+func (p *inlineParser) parseEntity(block *inline) {
+	match := reEntity.FindString(p.subject[p.pos:])
+	if match == "" {
+		block.appendChild(text("&"))
+		p.pos++
+
+		return
+	}
+
+	p.pos += len(match)
+	block.appendChild(text(html.UnescapeString(match)))
+}
+
+// spnl skips optional spaces with at most one newline.
+func (p *inlineParser) spnl() {
+	for p.peek() == ' ' {
+		p.pos++
+	}
+
+	if p.peek() == '\n' {
+		p.pos++
+	}
+
+	for p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *inlineParser) peek() byte {
+	if p.pos < len(p.subject) {
+		return p.subject[p.pos]
+	}
+
+	return 0
+}
+
+// parseReference parses link reference definition at the beginning of s into
+// refs and returns number of consumed bytes, or zero if there is none.
+func parseReference(s string, refs map[string]reference) int {
+	p := &inlineParser{subject: s}
+
+	n := p.parseLinkLabel()
+	if n == 0 || p.peek() != ':' {
+		return 0
+	}
+
+	label := s[:n]
+	p.pos++
+	p.spnl()
+
+	destination, ok := p.parseLinkDestination()
+	if !ok {
+		return 0
+	}
+
+	beforeTitle := p.pos
+	p.spnl()
+
+	var title string
+	if p.pos != beforeTitle {
+		if title, ok = p.parseLinkTitle(); !ok {
+			p.pos = beforeTitle
+		}
+	}
+
+	if match := reSpaceAtEndOfLine.FindString(p.subject[p.pos:]); match != "" || p.pos == len(p.subject) {
+		p.pos += len(match)
+	} else {
+		if title == "" {
+			return 0
+		}
+
+		// NOTE(toby3d): title is not at the line end, but definition is
+		// still valid without it.
+		title, p.pos = "", beforeTitle
+
+		match = reSpaceAtEndOfLine.FindString(p.subject[p.pos:])
+		if match == "" && p.pos != len(p.subject) {
+			return 0
+		}
+
+		p.pos += len(match)
+	}
+
+	key := normalizeReference(label)
+	if key == "" {
+		return 0
+	}
+
+	if _, ok := refs[key]; !ok {
+		refs[key] = reference{destination: destination, title: title}
+	}
+
+	return p.pos
+}
+
+// normalizeReference returns case-insensitive key of link label with collapsed
+// whitespaces.
+func normalizeReference(label string) string {
+	label = strings.TrimSpace(label[1 : len(label)-1])
+
+	return strings.ToLower(strings.ToUpper(reUnicodeWhitespace.ReplaceAllString(label, " ")))
+}
+
+// unescapeString replaces backslash escapes and entities in s.
+func unescapeString(s string) string {
+	if !strings.ContainsAny(s, `\&`) {
+		return s
+	}
+
+	return reEntityOrEscape.ReplaceAllStringFunc(s, func(match string) string {
+		if match[0] == '\\' {
+			return match[1:]
+		}
+
+		return html.UnescapeString(match)
+	})
+}
+
+func text(s string) *inline {
+	return &inline{kind: inlineText, literal: s}
+}
+
+func (n *inline) appendChild(child *inline) {
+	child.unlink()
+	child.parent = n
+
+	if n.last != nil {
+		n.last.next = child
+		child.prev = n.last
+		n.last = child
+	} else {
+		n.first, n.last = child, child
+	}
+}
+
+func (n *inline) insertAfter(sibling *inline) {
+	sibling.unlink()
+	sibling.next = n.next
+
+	if sibling.next != nil {
+		sibling.next.prev = sibling
+	}
+
+	sibling.prev = n
+	n.next = sibling
+	sibling.parent = n.parent
+
+	if sibling.next == nil && sibling.parent != nil {
+		sibling.parent.last = sibling
+	}
+}
+
+func (n *inline) unlink() {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else if n.parent != nil {
+		n.parent.first = n.next
+	}
+
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else if n.parent != nil {
+		n.parent.last = n.prev
+	}
+
+	n.parent, n.next, n.prev = nil, nil, nil
+}
+
+// mergeText joins adjacent text nodes of tree.
+func mergeText(n *inline) {
+	for c := n.first; c != nil; c = c.next {
+		for c.kind == inlineText && c.next != nil && c.next.kind == inlineText {
+			c.literal += c.next.literal
+			c.next.unlink()
+		}
+
+		mergeText(c)
+	}
+}
+
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func isASCIIPunct(c byte) bool {
+	return c != 0 && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isASCIISpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
@@ -0,0 +1,12 @@
+package markdown
+
+import "strings"
+
+// Render returns HTML representation of Markdown src.
+func Render(src string) string {
+	doc, refs := parseBlocks(src)
+	r := renderer{Builder: new(strings.Builder), refs: refs}
+	r.renderBlock(doc, false)
+
+	return r.String()
+}
@@ -0,0 +1,73 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/markdown"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		input  string
+		expect string
+	}{
+		"heading": {
+			input:  "# Hello *world*\n\nFoo\n---",
+			expect: "<h1>Hello <em>world</em></h1>\n<h2>Foo</h2>\n",
+		},
+		"emphasis": {
+			input:  "*foo**bar**baz* _foo_bar_ **foo*",
+			expect: "<p><em>foo<strong>bar</strong>baz</em> <em>foo_bar</em> *<em>foo</em></p>\n",
+		},
+		"strikethrough": {
+			input:  "~~a~~ ~b~ ~~~c~~~",
+			expect: "<p><del>a</del> <del>b</del> ~~~c~~~</p>\n",
+		},
+		"links": {
+			input: "[a](/u \"t\") [a [b](c)](d) ![a *b*](c) [ref] <https://x.y>\n\n[ref]: </my uri>",
+			expect: `<p><a href="/u" title="t">a</a> [a <a href="c">b</a>](d) <img src="c" alt="a b" /> ` +
+				`<a href="/my%20uri">ref</a> <a href="https://x.y">https://x.y</a></p>` + "\n",
+		},
+		"autolinks": {
+			input: "http://a.b/c?d. (www.x.com/(y)) x@y.z. www.a_b.c",
+			expect: `<p><a href="http://a.b/c?d">http://a.b/c?d</a>. ` +
+				`(<a href="http://www.x.com/(y)">www.x.com/(y)</a>) ` +
+				`<a href="mailto:x@y.z">x@y.z</a>. www.a_b.c</p>` + "\n",
+		},
+		"escapes": {
+			input:  "\\*not em\\* &copy; &bogus; `` a`b `` <b>x</b>\\\nc",
+			expect: "<p>*not em* © &amp;bogus; <code>a`b</code> <b>x</b><br />\nc</p>\n",
+		},
+		"lists": {
+			input: "- a\n- b\n  - c\n\n3) x\n\n   y",
+			expect: "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul>\n</li>\n</ul>\n" +
+				"<ol start=\"3\">\n<li>\n<p>x</p>\n<p>y</p>\n</li>\n</ol>\n",
+		},
+		"blocks": {
+			input: "> a\nb\n\n```go\n<hi>\n```\n\n    code\n\n<div>\nhtml\n</div>\n\n***",
+			expect: "<blockquote>\n<p>a\nb</p>\n</blockquote>\n" +
+				"<pre><code class=\"language-go\">&lt;hi&gt;\n</code></pre>\n" +
+				"<pre><code>code\n</code></pre>\n<div>\nhtml\n</div>\n<hr />\n",
+		},
+		"table": {
+			input: "| a | b |\n|:--|--:|\n| 1 | 2 \\| 3 |\n| 4 |",
+			expect: "<table>\n<thead>\n<tr>\n<th align=\"left\">a</th>\n<th align=\"right\">b</th>\n</tr>\n" +
+				"</thead>\n<tbody>\n<tr>\n<td align=\"left\">1</td>\n<td align=\"right\">2 | 3</td>\n</tr>\n" +
+				"<tr>\n<td align=\"left\">4</td>\n<td align=\"right\"></td>\n</tr>\n</tbody>\n</table>\n",
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(markdown.Render(tc.input), tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
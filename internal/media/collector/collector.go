@@ -0,0 +1,310 @@
+// Package collector provides a background garbage collector of uploaded media
+// files which are not referenced by any entry.
+//
+// The Media Endpoint MAY periodically delete files uploaded if they are not
+// used in a Micropub request within a specific amount of time.
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/media"
+)
+
+type (
+	Collector struct {
+		media   media.Repository
+		entries entry.Repository
+		logger  *log.Logger
+		now     func() time.Time
+		config  domain.Config
+	}
+
+	// Report describes a result of single collecting pass.
+	Report struct {
+		Orphans    []domain.File // unreferenced files older than grace period
+		Checked    int           // total number of stored files
+		Referenced int           // number of files used by entries
+		Freed      int64         // size of orphans in bytes
+		DryRun     bool          // orphans was not deleted
+	}
+)
+
+// mediaPrefix is a path of media endpoint which serves stored files.
+const mediaPrefix string = "/media/"
+
+func NewCollector(media media.Repository, entries entry.Repository, config domain.Config, logger *log.Logger,
+) *Collector {
+	return &Collector{
+		media:   media,
+		entries: entries,
+		logger:  logger,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// Collect deletes all stored media files which are not referenced by any entry
+// and uploaded earlier than grace period. If dryRun is true, then files are
+// only reported.
+func (c *Collector) Collect(ctx context.Context, dryRun bool) (*Report, error) {
+	files, _, err := c.media.Fetch(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch media files: %w", err)
+	}
+
+	references, err := c.references(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Report{
+		Orphans: make([]domain.File, 0),
+		Checked: len(files),
+		DryRun:  dryRun,
+	}
+	deadline := c.now().Add(-c.config.Media.GracePeriod)
+
+	for i := range files {
+		if _, ok := references[fileKey(files[i].Path)]; ok {
+			out.Referenced++
+
+			continue
+		}
+
+		// NOTE(toby3d): client may still create entry with this file.
+		if files[i].CreatedAt.After(deadline) {
+			continue
+		}
+
+		out.Orphans = append(out.Orphans, files[i])
+		out.Freed += int64(files[i].Size())
+
+		if dryRun {
+			continue
+		}
+
+		if err = c.media.Delete(ctx, files[i].Path); err != nil {
+			return out, fmt.Errorf("cannot delete orphaned media '%s': %w", files[i].Path, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Handle implements entry.Hook. It deletes media files of purged entry which
+// are not referenced by any other entry, regardless of grace period.
+func (c *Collector) Handle(ctx context.Context, action domain.Action, before, _ *domain.Entry) {
+	if action != domain.ActionPurge || before == nil {
+		return
+	}
+
+	purged := make(map[string]struct{})
+	for _, u := range References(*before) {
+		if key := c.referenceKey(before, u); key != "" {
+			purged[key] = struct{}{}
+		}
+	}
+
+	if len(purged) == 0 {
+		return
+	}
+
+	references, err := c.references(ctx)
+	if err != nil {
+		c.logger.Printf("cannot collect media of purged %s: %s", before.URL, err)
+
+		return
+	}
+
+	files, _, err := c.media.Fetch(ctx, "")
+	if err != nil {
+		c.logger.Printf("cannot collect media of purged %s: %s", before.URL, err)
+
+		return
+	}
+
+	out := &Report{Orphans: make([]domain.File, 0), Checked: len(files), DryRun: c.config.Media.DryRun}
+
+	for i := range files {
+		key := fileKey(files[i].Path)
+		if _, ok := purged[key]; !ok {
+			continue
+		}
+
+		if _, ok := references[key]; ok {
+			out.Referenced++
+
+			continue
+		}
+
+		out.Orphans = append(out.Orphans, files[i])
+		out.Freed += int64(files[i].Size())
+
+		if out.DryRun {
+			continue
+		}
+
+		if err = c.media.Delete(ctx, files[i].Path); err != nil {
+			c.logger.Printf("cannot delete media '%s' of purged entry: %s", files[i].Path, err)
+		}
+	}
+
+	c.logger.Println(out)
+}
+
+// references returns keys of all media files used by stored entries,
+// including deleted ones which can be restored.
+func (c *Collector) references(ctx context.Context) (map[string]struct{}, error) {
+	entries, _, err := c.entries.Fetch(ctx, entry.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch entries: %w", err)
+	}
+
+	out := make(map[string]struct{})
+	for i := range entries {
+		for _, u := range References(entries[i]) {
+			if key := c.referenceKey(&entries[i], u); key != "" {
+				out[key] = struct{}{}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// Run collects orphaned files every interval until ctx is done.
+func (c *Collector) Run(ctx context.Context) {
+	if c.config.Media.CollectInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.config.Media.CollectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := c.Collect(ctx, c.config.Media.DryRun)
+			if err != nil {
+				c.logger.Println("cannot collect orphaned media:", err)
+
+				continue
+			}
+
+			c.logger.Println(report)
+		}
+	}
+}
+
+func (r Report) String() string {
+	names := make([]string, 0, len(r.Orphans))
+	for i := range r.Orphans {
+		names = append(names, r.Orphans[i].Path)
+	}
+
+	action := "deleted"
+	if r.DryRun {
+		action = "would delete"
+	}
+
+	return fmt.Sprintf("checked %d media files, %d referenced, %s %d orphans (%d bytes): %s", r.Checked,
+		r.Referenced, action, len(r.Orphans), r.Freed, strings.Join(names, ", "))
+}
+
+// References returns all media URLs used in entry properties and HTML content.
+func References(e domain.Entry) []*url.URL {
+	out := make([]*url.URL, 0, len(e.Photo)+len(e.Video)+len(e.Audio))
+
+	for _, list := range [][]*url.URL{e.Photo, e.Video, e.Audio} {
+		for i := range list {
+			if list[i] != nil {
+				out = append(out, list[i])
+			}
+		}
+	}
+
+	if e.Content.HTML == nil {
+		return out
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			for _, attr := range n.Attr {
+				for _, v := range attributeURLs(attr) {
+					if u, err := url.Parse(v); err == nil {
+						out = append(out, u)
+					}
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(e.Content.HTML)
+
+	return out
+}
+
+func attributeURLs(attr html.Attribute) []string {
+	switch attr.Key {
+	case "src", "href", "poster", "data":
+		return []string{strings.TrimSpace(attr.Val)}
+	case "srcset":
+		candidates := strings.Split(attr.Val, ",")
+		out := make([]string, 0, len(candidates))
+
+		for i := range candidates {
+			if fields := strings.Fields(candidates[i]); len(fields) > 0 {
+				out = append(out, fields[0])
+			}
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
+
+// referenceKey returns key of stored media file which u of entry e refers to.
+// Returns empty string if u is not served by media endpoint of this site.
+func (c *Collector) referenceKey(e *domain.Entry, u *url.URL) string {
+	base := c.config.HTTP.BaseURL()
+	if e.URL != nil {
+		base = base.ResolveReference(e.URL)
+	}
+
+	u = base.ResolveReference(u)
+	if !strings.EqualFold(u.Host, c.config.HTTP.Host) {
+		return ""
+	}
+
+	p := path.Clean("/" + strings.ToLower(u.Path))
+	if !strings.HasPrefix(p, mediaPrefix) {
+		return ""
+	}
+
+	return fileKey(strings.TrimPrefix(p, mediaPrefix))
+}
+
+// fileKey normalizes path of stored media file in the same way as media
+// repository does.
+func fileKey(p string) string {
+	return path.Clean("/" + strings.ToLower(p))
+}
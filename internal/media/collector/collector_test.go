@@ -0,0 +1,179 @@
+package collector_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/media"
+	"source.toby3d.me/toby3d/pub/internal/media/collector"
+	mediamemoryrepo "source.toby3d.me/toby3d/pub/internal/media/repository/memory"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	config := domain.TestConfig(t)
+	old := time.Now().UTC().Add(-2 * config.Media.GracePeriod)
+
+	mediaRepo := mediamemoryrepo.NewMemoryMediaRepository()
+	for _, f := range []domain.File{
+		{Path: "photo.jpg", CreatedAt: old, Content: []byte("photo")},
+		{Path: "inline.png", CreatedAt: old, Content: []byte("inline")},
+		{Path: "orphan.mp3", CreatedAt: old, Content: []byte("orphan")},
+		{Path: "fresh.mp4", CreatedAt: time.Now().UTC(), Content: []byte("fresh")},
+	} {
+		if err := mediaRepo.Create(ctx, f.Path, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := html.Parse(strings.NewReader(`<p><img src="/media/inline.png"></p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := domain.TestEntry(t)
+	e.Photo = []*url.URL{{Scheme: "https", Host: "example.com", Path: "/media/photo.jpg"}}
+	e.Content.HTML = content
+
+	entryRepo := entrymemoryrepo.NewMemoryEntryRepository()
+	if err = entryRepo.Create(ctx, e.URL.Path, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	c := collector.NewCollector(mediaRepo, entryRepo, *config, log.New(io.Discard, "", 0))
+
+	report, err := c.Collect(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Orphans) != 1 || report.Orphans[0].Path != "orphan.mp3" {
+		t.Fatalf("Collect(dry) = %s, want only orphan.mp3", report)
+	}
+
+	if _, err = mediaRepo.Get(ctx, "orphan.mp3"); err != nil {
+		t.Errorf("expect orphan.mp3 stay after dry run, got %s", err)
+	}
+
+	if report, err = c.Collect(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Checked != 4 || report.Referenced != 2 || report.Freed != int64(len("orphan")) {
+		t.Errorf("Collect() = %s, want 4 checked, 2 referenced and %d bytes freed", report, len("orphan"))
+	}
+
+	if _, err = mediaRepo.Get(ctx, "orphan.mp3"); !errors.Is(err, media.ErrNotExist) {
+		t.Errorf("expect %v for deleted orphan, got %v", media.ErrNotExist, err)
+	}
+
+	for _, p := range []string{"photo.jpg", "inline.png", "fresh.mp4"} {
+		if _, err = mediaRepo.Get(ctx, p); err != nil {
+			t.Errorf("expect %s stay after collecting, got %s", p, err)
+		}
+	}
+}
+
+func TestCollector_CollectSameName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	config := domain.TestConfig(t)
+	old := time.Now().UTC().Add(-2 * config.Media.GracePeriod)
+
+	mediaRepo := mediamemoryrepo.NewMemoryMediaRepository()
+	for _, p := range []string{"Photo.jpg", "avatar.jpg"} {
+		if err := mediaRepo.Create(ctx, p, domain.File{Path: p, CreatedAt: old, Content: []byte(p)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// NOTE(toby3d): files with the same names elsewhere are not references
+	// of stored media.
+	content, err := html.Parse(strings.NewReader(`<p><img src="https://cdn.example/media/avatar.jpg">` +
+		`<a href="/static/avatar.jpg">avatar</a><img src="../MEDIA/photo.JPG"></p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := domain.TestEntry(t)
+	e.Content.HTML = content
+
+	entryRepo := entrymemoryrepo.NewMemoryEntryRepository()
+	if err = entryRepo.Create(ctx, e.URL.Path, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := collector.NewCollector(mediaRepo, entryRepo, *config, log.New(io.Discard, "", 0)).
+		Collect(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Referenced != 1 || len(report.Orphans) != 1 || report.Orphans[0].Path != "avatar.jpg" {
+		t.Errorf("Collect(dry) = %s, want only avatar.jpg", report)
+	}
+}
+
+func TestCollector_Handle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	config := domain.TestConfig(t)
+
+	mediaRepo := mediamemoryrepo.NewMemoryMediaRepository()
+	for _, f := range []domain.File{
+		{Path: "own.jpg", CreatedAt: time.Now().UTC(), Content: []byte("own")},
+		{Path: "shared.jpg", CreatedAt: time.Now().UTC(), Content: []byte("shared")},
+	} {
+		if err := mediaRepo.Create(ctx, f.Path, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entryRepo := entrymemoryrepo.NewMemoryEntryRepository()
+	entries := entryucase.NewEntryUseCase(entryRepo, collector.NewCollector(mediaRepo, entryRepo, *config,
+		log.New(io.Discard, "", 0)))
+
+	purged := domain.TestEntry(t)
+	purged.Photo = []*url.URL{{Path: "/media/own.jpg"}, {Path: "/media/shared.jpg"}}
+
+	kept := domain.TestEntry(t)
+	kept.URL = &url.URL{Path: "/samples/kept"}
+	kept.Photo = []*url.URL{{Path: "/media/shared.jpg"}}
+
+	for _, e := range []*domain.Entry{purged, kept} {
+		if _, err := entries.Create(ctx, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := entries.Delete(ctx, purged.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entries.Purge(ctx, purged.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mediaRepo.Get(ctx, "own.jpg"); !errors.Is(err, media.ErrNotExist) {
+		t.Errorf("expect media of purged entry deleted, got %v", err)
+	}
+
+	if _, err := mediaRepo.Get(ctx, "shared.jpg"); err != nil {
+		t.Errorf("expect media referenced by another entry kept, got %v", err)
+	}
+}
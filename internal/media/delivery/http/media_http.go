@@ -3,9 +3,13 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"source.toby3d.me/toby3d/pub/internal/common"
@@ -23,6 +27,33 @@ type (
 		Error            string `json:"error"`
 		ErrorDescription string `json:"error_description,omitempty"`
 	}
+
+	RequestSource struct {
+		Q     string
+		Limit int
+	}
+
+	ResponseSource struct {
+		Items []ResponseSourceItem `json:"items"`
+	}
+
+	ResponseUsage struct {
+		Files     int   `json:"files"`
+		Size      int64 `json:"size"`
+		Quota     int64 `json:"quota,omitempty"`
+		Remaining int64 `json:"remaining,omitempty"`
+	}
+
+	ResponseSourceItem struct {
+		URL       string  `json:"url"`
+		Published string  `json:"published,omitempty"`
+		MediaType string  `json:"mime-type,omitempty"`
+		Size      int     `json:"size"`
+		Width     int     `json:"width,omitempty"`
+		Height    int     `json:"height,omitempty"`
+		Duration  float64 `json:"duration,omitempty"` // in seconds
+		Bitrate   int     `json:"bitrate,omitempty"`  // in bits per second
+	}
 )
 
 func NewHandler(media media.UseCase, config domain.Config) *Handler {
@@ -37,7 +68,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	default:
 		WriteError(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
 	case "", http.MethodGet:
-		h.handleDownload(w, r)
+		switch q := r.URL.Query(); {
+		default:
+			h.handleDownload(w, r)
+		case strings.EqualFold(q.Get("q"), "usage"):
+			h.handleUsage(w, r)
+		case q.Has("q"):
+			h.handleSource(w, r)
+		}
 	case http.MethodPost:
 		h.handleUpload(w, r)
 	}
@@ -60,6 +98,64 @@ func (h *Handler) handleDownload(w http.ResponseWriter, r *http.Request) {
 	http.ServeContent(w, r, out.LogicalName(), time.Time{}, bytes.NewReader(out.Content))
 }
 
+func (h *Handler) handleSource(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		WriteError(w, "method MUST be "+http.MethodGet, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(RequestSource)
+	if err := req.bind(r); err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	out, err := h.media.Fetch(r.Context(), req.Limit)
+	if err != nil {
+		WriteError(w, "cannot fetch media: "+err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	resp := &ResponseSource{Items: make([]ResponseSourceItem, 0, len(out))}
+	for i := range out {
+		resp.Items = append(resp.Items, NewResponseSourceItem(out[i], h.config))
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		WriteError(w, "method MUST be "+http.MethodGet, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	out, err := h.media.Usage(r.Context())
+	if err != nil {
+		WriteError(w, "cannot calculate media usage: "+err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	resp := &ResponseUsage{
+		Files: out.Files,
+		Size:  out.Size,
+		Quota: out.Quota,
+	}
+
+	if remaining := out.Remaining(); remaining >= 0 {
+		resp.Remaining = remaining
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteError(w, "method MUST be "+http.MethodPost, http.StatusMethodNotAllowed)
@@ -96,6 +192,12 @@ func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
 
 	out, err := h.media.Upload(r.Context(), *in)
 	if err != nil {
+		if errors.Is(err, media.ErrQuotaExceeded) {
+			WriteError(w, err.Error(), http.StatusInsufficientStorage)
+
+			return
+		}
+
 		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
@@ -105,6 +207,42 @@ func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+func (r *RequestSource) bind(req *http.Request) error {
+	query := req.URL.Query()
+	if r.Q = query.Get("q"); !strings.EqualFold(r.Q, "source") {
+		return fmt.Errorf("'q' query MUST be 'source', got '%s'", r.Q)
+	}
+
+	if !query.Has("limit") {
+		return nil
+	}
+
+	var err error
+	if r.Limit, err = strconv.Atoi(query.Get("limit")); err != nil || r.Limit < 0 {
+		return fmt.Errorf("'limit' query MUST be a positive number, got '%s'", query.Get("limit"))
+	}
+
+	return nil
+}
+
+func NewResponseSourceItem(src domain.File, config domain.Config) ResponseSourceItem {
+	out := ResponseSourceItem{
+		URL:       config.HTTP.BaseURL().JoinPath(src.Path).String(),
+		MediaType: src.MediaType(),
+		Size:      src.Size(),
+		Width:     src.Width,
+		Height:    src.Height,
+		Duration:  src.Duration.Seconds(),
+		Bitrate:   src.Bitrate,
+	}
+
+	if !src.CreatedAt.IsZero() {
+		out.Published = src.CreatedAt.Format(time.RFC3339)
+	}
+
+	return out
+}
+
 func WriteError(w http.ResponseWriter, description string, status int) {
 	out := &Error{ErrorDescription: description}
 
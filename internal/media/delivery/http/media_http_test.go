@@ -2,12 +2,15 @@ package http_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"source.toby3d.me/toby3d/pub/internal/common"
 	"source.toby3d.me/toby3d/pub/internal/domain"
 	"source.toby3d.me/toby3d/pub/internal/media"
@@ -89,3 +92,34 @@ func TestHandler_Download(t *testing.T) {
 		t.Error("stored and received file contents is not the same")
 	}
 }
+
+func TestHandler_Source(t *testing.T) {
+	t.Parallel()
+
+	testConfig := domain.TestConfig(t)
+	testFile := domain.TestFile(t)
+	testFile.Width, testFile.Height = 1024, 683
+
+	req := httptest.NewRequest(http.MethodGet, "https://media.example.com/?q=source&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	delivery.NewHandler(
+		media.NewStubUseCase(nil, testFile, nil), *testConfig).
+		ServeHTTP(w, req)
+
+	resp := w.Result()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+	}
+
+	out := new(delivery.ResponseSource)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []delivery.ResponseSourceItem{delivery.NewResponseSourceItem(*testFile, *testConfig)}
+	if diff := cmp.Diff(out.Items, expect); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,78 @@
+// Package metadata extracts technical properties of uploaded media files, like
+// image dimensions or audio duration, without any external dependencies.
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // NOTE(toby3d): register GIF decoder
+	_ "image/jpeg" // NOTE(toby3d): register JPEG decoder
+	_ "image/png"  // NOTE(toby3d): register PNG decoder
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type parseFunc func(src []byte, dst *domain.File) error
+
+var (
+	ErrUnsupported error = errors.New("unsupported media format")
+	ErrMalformed   error = errors.New("malformed media content")
+)
+
+// Populate detects format of dst content and fills dst dimensions, duration and
+// bitrate. Returns ErrUnsupported if format is not recognized.
+func Populate(dst *domain.File) error {
+	src := dst.Content
+
+	var parse parseFunc
+
+	switch {
+	case isMP4(src):
+		parse = parseMP4
+	case isWebM(src):
+		parse = parseWebM
+	case isOgg(src):
+		parse = parseOgg
+	case isMP3(src):
+		parse = parseMP3
+	default:
+		parse = parseImage
+	}
+
+	if err := parse(src, dst); err != nil {
+		return fmt.Errorf("cannot extract metadata of '%s': %w", dst.Path, err)
+	}
+
+	if dst.Bitrate == 0 && dst.Duration > 0 {
+		dst.Bitrate = int(float64(len(src)*8) / dst.Duration.Seconds())
+	}
+
+	return nil
+}
+
+func parseImage(src []byte, dst *domain.File) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return ErrUnsupported
+		}
+
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	dst.Width, dst.Height = config.Width, config.Height
+
+	return nil
+}
+
+// seconds converts number of samples in provided rate into duration.
+func seconds(samples, rate uint64) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+
+	return time.Duration(float64(samples) / float64(rate) * float64(time.Second))
+}
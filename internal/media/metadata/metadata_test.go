@@ -0,0 +1,193 @@
+package metadata_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"math"
+	"testing"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/media/metadata"
+)
+
+func TestPopulate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		input  []byte
+		expect domain.File
+	}{
+		"png": {
+			input:  testPNG(t, 320, 240),
+			expect: domain.File{Width: 320, Height: 240},
+		},
+		"mp4": {
+			input:  testMP4(t, 1000, 5500, 640, 360),
+			expect: domain.File{Width: 640, Height: 360, Duration: 5500 * time.Millisecond},
+		},
+		"mp4 empty track header": {
+			input: bytes.Join([][]byte{
+				testBox(t, "ftyp", []byte("isom\x00\x00\x02\x00")),
+				testBox(t, "moov", testBox(t, "mvhd", make([]byte, 100)), testBox(t, "trak", testBox(t, "tkhd"))),
+			}, nil),
+			expect: domain.File{},
+		},
+		"mp3": {
+			input:  testMP3(t, 128, 2*time.Second),
+			expect: domain.File{Duration: 2 * time.Second, Bitrate: 128000},
+		},
+		"vorbis": {
+			input:  testOgg(t, "\x01vorbis", 44100*3),
+			expect: domain.File{Duration: 3 * time.Second, Bitrate: 96000},
+		},
+		"opus": {
+			input:  testOgg(t, "OpusHead", 48000*4+312),
+			expect: domain.File{Duration: 4 * time.Second},
+		},
+		"webm": {
+			input:  testWebM(t, 12500, 1280, 720),
+			expect: domain.File{Width: 1280, Height: 720, Duration: 12500 * time.Millisecond},
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &domain.File{Path: "sample", Content: tc.input}
+			if err := metadata.Populate(out); err != nil {
+				t.Fatal(err)
+			}
+
+			if out.Width != tc.expect.Width || out.Height != tc.expect.Height {
+				t.Errorf("Populate(%s) = %dx%d, want %dx%d", name, out.Width, out.Height,
+					tc.expect.Width, tc.expect.Height)
+			}
+
+			if out.Duration.Round(time.Millisecond) != tc.expect.Duration {
+				t.Errorf("Populate(%s) = %s, want %s", name, out.Duration, tc.expect.Duration)
+			}
+
+			if tc.expect.Bitrate != 0 && out.Bitrate != tc.expect.Bitrate {
+				t.Errorf("Populate(%s) = %d bps, want %d bps", name, out.Bitrate, tc.expect.Bitrate)
+			}
+		})
+	}
+}
+
+func TestPopulate_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	err := metadata.Populate(&domain.File{Path: "readme.txt", Content: []byte("Hello, World!")})
+	if !errors.Is(err, metadata.ErrUnsupported) {
+		t.Errorf("Populate() = %v, want %v", err, metadata.ErrUnsupported)
+	}
+}
+
+func testPNG(tb testing.TB, width, height int) []byte {
+	tb.Helper()
+
+	buf := bytes.NewBuffer(nil)
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		tb.Fatalf("cannot encode testing image: %s", err)
+	}
+
+	return buf.Bytes()
+}
+
+func testBox(tb testing.TB, kind string, payload ...[]byte) []byte {
+	tb.Helper()
+
+	body := bytes.Join(payload, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+
+	return append(append(out, kind...), body...)
+}
+
+func testMP4(tb testing.TB, timescale, duration uint32, width, height uint16) []byte {
+	tb.Helper()
+
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:], duration)
+
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], uint32(width)<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], uint32(height)<<16)
+
+	return bytes.Join([][]byte{
+		testBox(tb, "ftyp", []byte("isom\x00\x00\x02\x00")),
+		testBox(tb, "moov", testBox(tb, "mvhd", mvhd), testBox(tb, "trak", testBox(tb, "tkhd", tkhd))),
+		testBox(tb, "mdat", make([]byte, 32)),
+	}, nil)
+}
+
+func testMP3(tb testing.TB, kbps int, duration time.Duration) []byte {
+	tb.Helper()
+
+	// NOTE(toby3d): MPEG-1 Layer III, 44.1 kHz, stereo.
+	bitrates := map[int]byte{64: 0x5, 128: 0x9, 320: 0xe}
+	header := []byte{0xff, 0xfb, bitrates[kbps]<<4 | 0x00, 0x00}
+
+	size := int(duration.Seconds() * float64(kbps*1000) / 8)
+	out := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x0a"), make([]byte, 10)...)
+	body := make([]byte, size)
+	copy(body, header)
+
+	return append(out, body...)
+}
+
+func testOgg(tb testing.TB, codec string, granule uint64) []byte {
+	tb.Helper()
+
+	page := func(granule uint64, payload []byte) []byte {
+		out := make([]byte, 27, 28+len(payload))
+		copy(out, "OggS")
+		binary.LittleEndian.PutUint64(out[6:], granule)
+		binary.LittleEndian.PutUint32(out[14:], 42)
+		out[26] = 1
+
+		return append(append(out, byte(len(payload))), payload...)
+	}
+
+	head := make([]byte, 30)
+	copy(head, codec)
+
+	switch codec {
+	case "OpusHead":
+		binary.LittleEndian.PutUint16(head[10:], 312)
+	default:
+		binary.LittleEndian.PutUint32(head[12:], 44100)
+		binary.LittleEndian.PutUint32(head[20:], 96000)
+	}
+
+	return bytes.Join([][]byte{page(0, head), page(granule/2, make([]byte, 64)), page(granule, make([]byte, 64))},
+		nil)
+}
+
+func testWebM(tb testing.TB, duration float64, width, height uint16) []byte {
+	tb.Helper()
+
+	element := func(id []byte, payload ...[]byte) []byte {
+		body := bytes.Join(payload, nil)
+
+		return append(append(id, 0x80|byte(len(body))), body...)
+	}
+
+	return bytes.Join([][]byte{
+		element([]byte{0x1A, 0x45, 0xDF, 0xA3}, element([]byte{0x42, 0x82}, []byte("webm"))),
+		element([]byte{0x18, 0x53, 0x80, 0x67},
+			element([]byte{0x15, 0x49, 0xA9, 0x66},
+				element([]byte{0x2A, 0xD7, 0xB1}, []byte{0x0F, 0x42, 0x40}),
+				element([]byte{0x44, 0x89}, binary.BigEndian.AppendUint64(nil, math.Float64bits(duration)))),
+			element([]byte{0x16, 0x54, 0xAE, 0x6B},
+				element([]byte{0xAE},
+					element([]byte{0xE0},
+						element([]byte{0xB0}, binary.BigEndian.AppendUint16(nil, width)),
+						element([]byte{0xBA}, binary.BigEndian.AppendUint16(nil, height)))))),
+	}, nil)
+}
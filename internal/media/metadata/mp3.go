@@ -0,0 +1,172 @@
+package metadata
+
+import (
+	"encoding/binary"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// frameHeader represents a decoded MPEG audio frame header.
+type frameHeader struct {
+	version    int // 1 for MPEG-1, 2 for MPEG-2 and MPEG-2.5
+	layer      int
+	bitrate    int // in bits per second
+	sampleRate int
+	mono       bool
+}
+
+const (
+	id3v1Size       int = 128
+	id3v2HeaderSize int = 10
+)
+
+// NOTE(toby3d): kilobits per second, indexed by [version-1][layer-1][index].
+var mpegBitrates = [2][3][16]int{{
+	{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+}, {
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+}}
+
+// NOTE(toby3d): indexed by raw version bits: MPEG-2.5, reserved, MPEG-2, MPEG-1.
+var mpegSampleRates = [4][3]int{
+	{11025, 12000, 8000},
+	{0, 0, 0},
+	{22050, 24000, 16000},
+	{44100, 48000, 32000},
+}
+
+func isMP3(src []byte) bool {
+	if len(src) >= 3 && string(src[:3]) == "ID3" {
+		return true
+	}
+
+	_, ok := parseFrameHeader(src)
+
+	return ok
+}
+
+// parseMP3 reads first frame of MPEG audio stream and calculates duration based
+// on Xing/Info header frames count or constant bitrate.
+func parseMP3(src []byte, dst *domain.File) error {
+	if len(src) >= id3v2HeaderSize && string(src[:3]) == "ID3" {
+		// NOTE(toby3d): tag size is a synchsafe integer.
+		size := int(src[6]&0x7f)<<21 | int(src[7]&0x7f)<<14 | int(src[8]&0x7f)<<7 | int(src[9]&0x7f)
+		size += id3v2HeaderSize
+
+		if src[5]&0x10 != 0 {
+			size += id3v2HeaderSize // footer
+		}
+
+		if size > len(src) {
+			return ErrMalformed
+		}
+
+		src = src[size:]
+	}
+
+	if len(src) >= id3v1Size && string(src[len(src)-id3v1Size:len(src)-id3v1Size+3]) == "TAG" {
+		src = src[:len(src)-id3v1Size]
+	}
+
+	// NOTE(toby3d): skip padding or garbage before first frame.
+	var (
+		header frameHeader
+		ok     bool
+	)
+
+	for len(src) >= 4 {
+		if header, ok = parseFrameHeader(src); ok {
+			break
+		}
+
+		src = src[1:]
+	}
+
+	if !ok {
+		return ErrMalformed
+	}
+
+	dst.Bitrate = header.bitrate
+
+	if frames, ok := xingFrames(src, header); ok {
+		dst.Duration = seconds(uint64(frames)*uint64(header.samplesPerFrame()), uint64(header.sampleRate))
+		dst.Bitrate = 0 // NOTE(toby3d): variable bitrate, calculate average
+
+		return nil
+	}
+
+	dst.Duration = seconds(uint64(len(src))*8, uint64(header.bitrate))
+
+	return nil
+}
+
+func parseFrameHeader(src []byte) (frameHeader, bool) {
+	if len(src) < 4 || src[0] != 0xff || src[1]&0xe0 != 0xe0 {
+		return frameHeader{}, false
+	}
+
+	versionBits := src[1] >> 3 & 0x03
+	layerBits := src[1] >> 1 & 0x03
+	bitrateIndex := src[2] >> 4
+	rateIndex := src[2] >> 2 & 0x03
+
+	if versionBits == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 0x0f || rateIndex == 3 {
+		return frameHeader{}, false
+	}
+
+	out := frameHeader{
+		version:    2,
+		layer:      int(4 - layerBits),
+		sampleRate: mpegSampleRates[versionBits][rateIndex],
+		mono:       src[3]>>6 == 0x03,
+	}
+
+	if versionBits == 3 {
+		out.version = 1
+	}
+
+	out.bitrate = mpegBitrates[out.version-1][out.layer-1][bitrateIndex] * 1000
+
+	return out, true
+}
+
+func (h frameHeader) samplesPerFrame() int {
+	switch {
+	case h.layer == 1:
+		return 384
+	case h.layer == 3 && h.version != 1:
+		return 576
+	default:
+		return 1152
+	}
+}
+
+// xingFrames returns total frames count from Xing or Info header of VBR stream.
+func xingFrames(frame []byte, header frameHeader) (uint32, bool) {
+	offset := 4 + 32
+
+	switch {
+	case header.version == 1 && header.mono, header.version != 1 && !header.mono:
+		offset = 4 + 17
+	case header.version != 1 && header.mono:
+		offset = 4 + 9
+	}
+
+	if len(frame) < offset+12 {
+		return 0, false
+	}
+
+	if tag := string(frame[offset : offset+4]); tag != "Xing" && tag != "Info" {
+		return 0, false
+	}
+
+	if flags := binary.BigEndian.Uint32(frame[offset+4 : offset+8]); flags&0x01 == 0 {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint32(frame[offset+8 : offset+12]), true
+}
@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"encoding/binary"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// box represents a single ISO Base Media File Format box.
+type box struct {
+	kind    string
+	payload []byte
+}
+
+func isMP4(src []byte) bool {
+	return len(src) >= 12 && string(src[4:8]) == "ftyp"
+}
+
+// parseMP4 reads movie header duration and track header dimensions.
+//
+// See: ISO/IEC 14496-12
+func parseMP4(src []byte, dst *domain.File) error {
+	moov, ok := findBox(src, "moov")
+	if !ok {
+		return ErrMalformed
+	}
+
+	mvhd, ok := findBox(moov, "mvhd")
+	if !ok || len(mvhd) < 20 {
+		return ErrMalformed
+	}
+
+	var timescale, duration uint64
+
+	if mvhd[0] == 1 {
+		if len(mvhd) < 32 {
+			return ErrMalformed
+		}
+
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:24]))
+		duration = binary.BigEndian.Uint64(mvhd[24:32])
+	} else {
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	}
+
+	dst.Duration = seconds(duration, timescale)
+
+	for _, b := range readBoxes(moov) {
+		if b.kind != "trak" {
+			continue
+		}
+
+		tkhd, ok := findBox(b.payload, "tkhd")
+		if !ok || len(tkhd) == 0 {
+			continue
+		}
+
+		// NOTE(toby3d): width and height are stored as 16.16 fixed-point
+		// numbers at the end of track header.
+		offset := 76
+		if tkhd[0] == 1 {
+			offset = 88
+		}
+
+		if len(tkhd) < offset+8 {
+			continue
+		}
+
+		width := int(binary.BigEndian.Uint32(tkhd[offset:offset+4]) >> 16)
+		height := int(binary.BigEndian.Uint32(tkhd[offset+4:offset+8]) >> 16)
+
+		if width > 0 && height > 0 {
+			dst.Width, dst.Height = width, height
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// readBoxes splits src into sequence of boxes, ignoring truncated tail.
+func readBoxes(src []byte) []box {
+	out := make([]box, 0)
+
+	for len(src) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(src[:4]))
+		kind := string(src[4:8])
+		header := uint64(8)
+
+		switch size {
+		case 0:
+			size = uint64(len(src))
+		case 1:
+			if len(src) < 16 {
+				return out
+			}
+
+			size, header = binary.BigEndian.Uint64(src[8:16]), 16
+		}
+
+		if size < header || size > uint64(len(src)) {
+			return out
+		}
+
+		out = append(out, box{kind: kind, payload: src[header:size]})
+		src = src[size:]
+	}
+
+	return out
+}
+
+func findBox(src []byte, kind string) ([]byte, bool) {
+	for _, b := range readBoxes(src) {
+		if b.kind == kind {
+			return b.payload, true
+		}
+	}
+
+	return nil, false
+}
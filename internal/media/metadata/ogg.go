@@ -0,0 +1,91 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+const oggHeaderSize int = 27
+
+// NOTE(toby3d): Opus granule position is always counted in 48 kHz samples.
+const opusSampleRate uint64 = 48000
+
+var oggCapture = []byte("OggS")
+
+func isOgg(src []byte) bool {
+	return bytes.HasPrefix(src, oggCapture)
+}
+
+// parseOgg reads identification header of Vorbis or Opus stream from the first
+// page and calculates duration from granule position of the last page.
+//
+// See: RFC 3533, RFC 7845
+func parseOgg(src []byte, dst *domain.File) error {
+	payload, serial, ok := oggPage(src)
+	if !ok {
+		return ErrMalformed
+	}
+
+	var rate, skip uint64
+
+	switch {
+	case bytes.HasPrefix(payload, []byte("\x01vorbis")) && len(payload) >= 24:
+		rate = uint64(binary.LittleEndian.Uint32(payload[12:16]))
+		dst.Bitrate = int(int32(binary.LittleEndian.Uint32(payload[20:24])))
+	case bytes.HasPrefix(payload, []byte("OpusHead")) && len(payload) >= 12:
+		rate = opusSampleRate
+		skip = uint64(binary.LittleEndian.Uint16(payload[10:12]))
+	default:
+		return ErrUnsupported
+	}
+
+	if dst.Bitrate < 0 {
+		dst.Bitrate = 0
+	}
+
+	var granule uint64
+
+	// NOTE(toby3d): search last page of the same logical stream from the end.
+	for i := bytes.LastIndex(src, oggCapture); i >= 0; i = bytes.LastIndex(src[:i], oggCapture) {
+		page := src[i:]
+		if len(page) < oggHeaderSize || binary.LittleEndian.Uint32(page[14:18]) != serial {
+			continue
+		}
+
+		if granule = binary.LittleEndian.Uint64(page[6:14]); granule != ^uint64(0) {
+			break
+		}
+	}
+
+	if granule > skip {
+		dst.Duration = seconds(granule-skip, rate)
+	}
+
+	return nil
+}
+
+// oggPage returns payload and stream serial number of the first page in src.
+func oggPage(src []byte) ([]byte, uint32, bool) {
+	if len(src) < oggHeaderSize || !isOgg(src) {
+		return nil, 0, false
+	}
+
+	segments := int(src[26])
+	if len(src) < oggHeaderSize+segments {
+		return nil, 0, false
+	}
+
+	size := 0
+	for _, lacing := range src[oggHeaderSize : oggHeaderSize+segments] {
+		size += int(lacing)
+	}
+
+	start := oggHeaderSize + segments
+	if len(src) < start+size {
+		return nil, 0, false
+	}
+
+	return src[start : start+size], binary.LittleEndian.Uint32(src[14:18]), true
+}
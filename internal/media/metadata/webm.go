@@ -0,0 +1,143 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// NOTE(toby3d): only elements required for metadata extraction.
+//
+// See: https://www.matroska.org/technical/elements.html
+const (
+	ebmlHeader     uint64 = 0x1A45DFA3
+	ebmlSegment    uint64 = 0x18538067
+	ebmlInfo       uint64 = 0x1549A966
+	ebmlTimescale  uint64 = 0x2AD7B1
+	ebmlDuration   uint64 = 0x4489
+	ebmlTracks     uint64 = 0x1654AE6B
+	ebmlTrackEntry uint64 = 0xAE
+	ebmlVideo      uint64 = 0xE0
+	ebmlWidth      uint64 = 0xB0
+	ebmlHeight     uint64 = 0xBA
+	ebmlCluster    uint64 = 0x1F43B675
+)
+
+// webmState accumulates values which depends on each other.
+type webmState struct {
+	timescale uint64
+	duration  float64
+}
+
+func isWebM(src []byte) bool {
+	id, _, ok := readVint(src, true)
+
+	return ok && id == ebmlHeader
+}
+
+// parseWebM walks over Matroska/WebM master elements up to the first cluster
+// and reads segment duration and video track dimensions.
+func parseWebM(src []byte, dst *domain.File) error {
+	state := &webmState{timescale: uint64(time.Millisecond)}
+
+	if !walkEBML(src, dst, state) {
+		return ErrMalformed
+	}
+
+	dst.Duration = time.Duration(state.duration * float64(state.timescale))
+
+	return nil
+}
+
+func walkEBML(src []byte, dst *domain.File, state *webmState) bool {
+	for len(src) > 0 {
+		id, idLen, ok := readVint(src, true)
+		if !ok {
+			return false
+		}
+
+		size, sizeLen, ok := readVint(src[idLen:], false)
+		if !ok {
+			return false
+		}
+
+		src = src[idLen+sizeLen:]
+
+		// NOTE(toby3d): unknown-sized elements lasts up to the end of the parent.
+		if size > uint64(len(src)) {
+			size = uint64(len(src))
+		}
+
+		payload := src[:size]
+		src = src[size:]
+
+		switch id {
+		case ebmlCluster:
+			return true
+		case ebmlSegment, ebmlInfo, ebmlTracks, ebmlTrackEntry, ebmlVideo:
+			if !walkEBML(payload, dst, state) {
+				return false
+			}
+		case ebmlTimescale:
+			state.timescale = readUint(payload)
+		case ebmlDuration:
+			switch len(payload) {
+			case 4:
+				state.duration = float64(math.Float32frombits(binary.BigEndian.Uint32(payload)))
+			case 8:
+				state.duration = math.Float64frombits(binary.BigEndian.Uint64(payload))
+			}
+		case ebmlWidth:
+			dst.Width = int(readUint(payload))
+		case ebmlHeight:
+			dst.Height = int(readUint(payload))
+		}
+	}
+
+	return true
+}
+
+// readVint reads EBML variable-size integer. Element IDs keeps their length
+// marker, data sizes does not.
+func readVint(src []byte, keepMarker bool) (uint64, int, bool) {
+	if len(src) == 0 || src[0] == 0 {
+		return 0, 0, false
+	}
+
+	length := 1
+	for mask := byte(0x80); src[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+
+	if length > 8 || len(src) < length {
+		return 0, 0, false
+	}
+
+	out := uint64(src[0])
+	if !keepMarker {
+		out &= uint64(0xff >> length)
+	}
+
+	for _, b := range src[1:length] {
+		out = out<<8 | uint64(b)
+	}
+
+	// NOTE(toby3d): all ones data size means unknown size.
+	if !keepMarker && out == 1<<(7*length)-1 {
+		out = math.MaxUint64
+	}
+
+	return out, length, true
+}
+
+func readUint(src []byte) uint64 {
+	var out uint64
+
+	for _, b := range src {
+		out = out<<8 | uint64(b)
+	}
+
+	return out
+}
@@ -19,6 +19,10 @@ type (
 		// file is not exist.
 		Get(ctx context.Context, path string) (*domain.File, error)
 
+		// Fetch returns all stored media files which path starts with
+		// provided path prefix.
+		Fetch(ctx context.Context, path string) ([]domain.File, int, error)
+
 		// Update replaces already exists media file or creates a new
 		// one if it is not. Returns error overwise.
 		Update(ctx context.Context, path string, update UpdateFunc) error
@@ -41,6 +45,7 @@ type (
 		Creates       int
 		Updates       int
 		Gets          int
+		Fetches       int
 		Deletes       int
 	}
 
@@ -64,6 +69,10 @@ func (dummyRepository) Get(_ context.Context, _ string) (*domain.File, error)
 func (dummyRepository) Update(_ context.Context, _ string, _ UpdateFunc) error  { return nil }
 func (dummyRepository) Delete(_ context.Context, _ string) error                { return nil }
 
+func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.File, int, error) {
+	return make([]domain.File, 0), 0, nil
+}
+
 // NewStubMediaRepository creates a repository that always returns input as a
 // output. It is used in tests where some dependency on the repository is
 // required.
@@ -82,6 +91,14 @@ func (repo *stubRepository) Get(_ context.Context, _ string) (*domain.File, erro
 	return repo.output, repo.err
 }
 
+func (repo *stubRepository) Fetch(_ context.Context, _ string) ([]domain.File, int, error) {
+	if repo.output == nil {
+		return make([]domain.File, 0), 0, repo.err
+	}
+
+	return []domain.File{*repo.output}, 1, repo.err
+}
+
 func (repo *stubRepository) Update(_ context.Context, _ string, _ UpdateFunc) error {
 	return repo.err
 }
@@ -103,6 +120,7 @@ func NewSpyMediaRepository(subRepo Repository) *spyRepository {
 		Creates:       0,
 		Updates:       0,
 		Gets:          0,
+		Fetches:       0,
 		Deletes:       0,
 	}
 }
@@ -119,6 +137,12 @@ func (repo *spyRepository) Get(ctx context.Context, path string) (*domain.File,
 	return repo.subRepository.Get(ctx, path)
 }
 
+func (repo *spyRepository) Fetch(ctx context.Context, path string) ([]domain.File, int, error) {
+	repo.Fetches++
+
+	return repo.subRepository.Fetch(ctx, path)
+}
+
 func (repo *spyRepository) Update(ctx context.Context, path string, update UpdateFunc) error {
 	repo.Updates++
 
@@ -54,6 +54,25 @@ func (repo *memoryMediaRepository) Get(ctx context.Context, p string) (*domain.F
 	return nil, media.ErrNotExist
 }
 
+func (repo *memoryMediaRepository) Fetch(ctx context.Context, p string) ([]domain.File, int, error) {
+	p = strings.ToLower(p)
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := make([]domain.File, 0)
+
+	for mediaPath, f := range repo.media {
+		if !strings.HasPrefix(mediaPath, p) {
+			continue
+		}
+
+		out = append(out, f)
+	}
+
+	return out, len(out), nil
+}
+
 func (repo *memoryMediaRepository) Update(ctx context.Context, p string, update media.UpdateFunc) error {
 	p = path.Clean(strings.ToLower(p))
 
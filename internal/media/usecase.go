@@ -2,6 +2,7 @@ package media
 
 import (
 	"context"
+	"errors"
 	"net/url"
 
 	"source.toby3d.me/toby3d/pub/internal/domain"
@@ -15,6 +16,25 @@ type (
 
 		// Download downloads early uploaded media stored in path.
 		Download(ctx context.Context, path string) (*domain.File, error)
+
+		// Fetch returns up to limit early uploaded media, newest first.
+		// Zero limit means no limit.
+		Fetch(ctx context.Context, limit int) ([]domain.File, error)
+
+		// Rehost downloads remote media from u into the store and
+		// returns a local URL to it. Returns u as is if rehosting is
+		// disabled or u is already local.
+		Rehost(ctx context.Context, u *url.URL) (*url.URL, error)
+
+		// Usage returns current storage usage and configured quota.
+		Usage(ctx context.Context) (*Usage, error)
+	}
+
+	// Usage represents a storage usage of uploaded media.
+	Usage struct {
+		Files int
+		Size  int64 // in bytes
+		Quota int64 // in bytes, zero means unlimited
 	}
 
 	dummyUseCase struct{}
@@ -26,6 +46,11 @@ type (
 	}
 )
 
+var (
+	ErrQuotaExceeded   error = errors.New("media storage quota exceeded")
+	ErrUnsupportedType error = errors.New("unsupported media type")
+)
+
 // NewDummyUseCase creates a dummy use case what does nothing.
 func NewDummyUseCase() UseCase {
 	return &dummyUseCase{}
@@ -33,6 +58,9 @@ func NewDummyUseCase() UseCase {
 
 func (dummyUseCase) Upload(_ context.Context, _ domain.File) (*url.URL, error)  { return nil, nil }
 func (dummyUseCase) Download(_ context.Context, _ string) (*domain.File, error) { return nil, nil }
+func (dummyUseCase) Fetch(_ context.Context, _ int) ([]domain.File, error)      { return nil, nil }
+func (dummyUseCase) Usage(_ context.Context) (*Usage, error)                    { return nil, nil }
+func (dummyUseCase) Rehost(_ context.Context, u *url.URL) (*url.URL, error)     { return u, nil }
 
 // NewDummyUseCase creates a stub use case what always returns provided input.
 func NewStubUseCase(err error, file *domain.File, u *url.URL) UseCase {
@@ -50,3 +78,37 @@ func (ucase stubUseCase) Upload(_ context.Context, _ domain.File) (*url.URL, err
 func (ucase stubUseCase) Download(_ context.Context, _ string) (*domain.File, error) {
 	return ucase.file, ucase.err
 }
+
+func (ucase stubUseCase) Fetch(_ context.Context, _ int) ([]domain.File, error) {
+	if ucase.file == nil {
+		return make([]domain.File, 0), ucase.err
+	}
+
+	return []domain.File{*ucase.file}, ucase.err
+}
+
+func (ucase stubUseCase) Rehost(_ context.Context, _ *url.URL) (*url.URL, error) {
+	return ucase.u, ucase.err
+}
+
+func (ucase stubUseCase) Usage(_ context.Context) (*Usage, error) {
+	if ucase.file == nil {
+		return &Usage{}, ucase.err
+	}
+
+	return &Usage{Files: 1, Size: int64(ucase.file.Size())}, ucase.err
+}
+
+// Remaining returns number of bytes which can be uploaded before quota
+// exceeded. Returns -1 if quota is not limited.
+func (u Usage) Remaining() int64 {
+	if u.Quota <= 0 {
+		return -1
+	}
+
+	if u.Size >= u.Quota {
+		return 0
+	}
+
+	return u.Quota - u.Size
+}
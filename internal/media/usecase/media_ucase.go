@@ -4,21 +4,37 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"mime"
+	"net/http"
 	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"source.toby3d.me/toby3d/pub/internal/common"
 	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
 	"source.toby3d.me/toby3d/pub/internal/media"
+	"source.toby3d.me/toby3d/pub/internal/media/metadata"
 )
 
 type mediaUseCase struct {
-	media media.Repository
+	media  media.Repository
+	client *http.Client
+	mutex  *sync.Mutex // guards quota of uploads
+	config domain.Config
 }
 
 const charset string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
 
-func NewMediaUseCase(media media.Repository) media.UseCase {
+func NewMediaUseCase(media media.Repository, client *http.Client, config domain.Config) media.UseCase {
 	return &mediaUseCase{
-		media: media,
+		media:  media,
+		client: client,
+		mutex:  new(sync.Mutex),
+		config: config,
 	}
 }
 
@@ -29,7 +45,29 @@ func (ucase *mediaUseCase) Upload(ctx context.Context, file domain.File) (*url.U
 		randName[i] = charset[rand.Intn(len(charset))]
 	}
 
-	newName := string(randName) + "." + file.Ext()
+	newName := string(randName) + file.Ext()
+	file.Path = newName
+	file.CreatedAt = time.Now().UTC()
+
+	// NOTE(toby3d): metadata is optional, unknown or broken formats are
+	// still stored as-is.
+	_ = metadata.Populate(&file)
+
+	// NOTE(toby3d): usage check and storing of file are atomic, so
+	// concurrent uploads cannot exceed quota together.
+	ucase.mutex.Lock()
+	defer ucase.mutex.Unlock()
+
+	if ucase.config.Media.Quota > 0 {
+		usage, err := ucase.Usage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check media quota: %w", err)
+		}
+
+		if remaining := usage.Remaining(); remaining >= 0 && int64(file.Size()) > remaining {
+			return nil, fmt.Errorf("cannot upload %d bytes of media: %w", file.Size(), media.ErrQuotaExceeded)
+		}
+	}
 
 	if err := ucase.media.Create(ctx, newName, file); err != nil {
 		return nil, fmt.Errorf("cannot upload nedia: %w", err)
@@ -48,3 +86,104 @@ func (ucase *mediaUseCase) Download(ctx context.Context, path string) (*domain.F
 
 	return out, nil
 }
+
+func (ucase *mediaUseCase) Fetch(ctx context.Context, limit int) ([]domain.File, error) {
+	out, _, err := ucase.media.Fetch(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch media files: %w", err)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func (ucase *mediaUseCase) Usage(ctx context.Context) (*media.Usage, error) {
+	files, count, err := ucase.media.Fetch(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch media files: %w", err)
+	}
+
+	out := &media.Usage{
+		Files: count,
+		Quota: ucase.config.Media.Quota,
+	}
+
+	for i := range files {
+		out.Size += int64(files[i].Size())
+	}
+
+	return out, nil
+}
+
+func (ucase *mediaUseCase) Rehost(ctx context.Context, u *url.URL) (*url.URL, error) {
+	if !ucase.config.Media.Rehost || u == nil || !u.IsAbs() || strings.EqualFold(u.Host, ucase.config.HTTP.Host) {
+		return u, nil
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("cannot rehost '%s': %w", u, httputil.ErrForbiddenScheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ucase.config.Media.RemoteTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request for remote media: %w", err)
+	}
+
+	resp, err := ucase.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download remote media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download remote media: got %d status code", resp.StatusCode)
+	}
+
+	if resp.ContentLength > ucase.config.Media.RemoteMaxSize {
+		return nil, fmt.Errorf("cannot download %d bytes of remote media: %w", resp.ContentLength,
+			httputil.ErrTooLarge)
+	}
+
+	content, err := httputil.ReadAll(resp.Body, ucase.config.Media.RemoteMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download remote media: %w", err)
+	}
+
+	// NOTE(toby3d): trust content itself rather than remote server headers.
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(resp.Header.Get(common.HeaderContentType))
+	}
+
+	if !strings.HasPrefix(mediaType, "image/") && !strings.HasPrefix(mediaType, "audio/") &&
+		!strings.HasPrefix(mediaType, "video/") {
+		return nil, fmt.Errorf("cannot rehost '%s' as media: %w", mediaType, media.ErrUnsupportedType)
+	}
+
+	name := path.Base(u.Path)
+	if ext := path.Ext(name); ext == "" || !strings.HasPrefix(mime.TypeByExtension(ext), mediaType) {
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			name = strings.TrimSuffix(name, ext) + exts[0]
+		}
+	}
+
+	out, err := ucase.Upload(ctx, domain.File{
+		Path:    name,
+		Content: content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot store remote media: %w", err)
+	}
+
+	return ucase.config.HTTP.BaseURL().JoinPath(out.Path), nil
+}
@@ -1,13 +1,24 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"source.toby3d.me/toby3d/pub/internal/domain"
 	"source.toby3d.me/toby3d/pub/internal/media"
+	mediamemoryrepo "source.toby3d.me/toby3d/pub/internal/media/repository/memory"
 	"source.toby3d.me/toby3d/pub/internal/media/usecase"
 )
 
@@ -17,7 +28,7 @@ func TestUpload(t *testing.T) {
 	f := domain.TestFile(t)
 	repo := media.NewSpyMediaRepository(media.NewStubMediaRepository(f, nil))
 
-	out, err := usecase.NewMediaUseCase(repo).Upload(context.Background(), *f)
+	out, err := usecase.NewMediaUseCase(repo, http.DefaultClient, *domain.TestConfig(t)).Upload(context.Background(), *f)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -37,7 +48,7 @@ func TestDownload(t *testing.T) {
 	f := domain.TestFile(t)
 	repo := media.NewStubMediaRepository(f, nil)
 
-	out, err := usecase.NewMediaUseCase(repo).
+	out, err := usecase.NewMediaUseCase(repo, http.DefaultClient, *domain.TestConfig(t)).
 		Download(context.Background(), f.Path)
 	if err != nil {
 		t.Fatal(err)
@@ -47,3 +58,169 @@ func TestDownload(t *testing.T) {
 		t.Errorf("%#+v", diff)
 	}
 }
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	f := domain.TestFile(t)
+	repo := media.NewSpyMediaRepository(media.NewStubMediaRepository(f, nil))
+
+	out, err := usecase.NewMediaUseCase(repo, http.DefaultClient, *domain.TestConfig(t)).Fetch(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out) != 1 {
+		t.Errorf("expect %d media files, got %d", 1, len(out))
+	}
+
+	if expect := 1; repo.Fetches != expect {
+		t.Errorf("expect %d Fetch calls, got %d", expect, repo.Fetches)
+	}
+}
+
+func TestUpload_Quota(t *testing.T) {
+	t.Parallel()
+
+	f := domain.TestFile(t)
+	config := domain.TestConfig(t)
+	config.Media.Quota = int64(f.Size())
+	repo := media.NewSpyMediaRepository(media.NewStubMediaRepository(f, nil))
+
+	_, err := usecase.NewMediaUseCase(repo, http.DefaultClient, *config).Upload(context.Background(), *f)
+	if !errors.Is(err, media.ErrQuotaExceeded) {
+		t.Errorf("expect %v error, got %v", media.ErrQuotaExceeded, err)
+	}
+
+	if repo.Creates != 0 {
+		t.Errorf("expect %d Create calls, got %d", 0, repo.Creates)
+	}
+}
+
+func TestUpload_QuotaConcurrent(t *testing.T) {
+	t.Parallel()
+
+	f := domain.TestFile(t)
+	config := domain.TestConfig(t)
+	config.Media.Quota = 3 * int64(f.Size())
+	ucase := usecase.NewMediaUseCase(slowFetchRepository{mediamemoryrepo.NewMemoryMediaRepository()},
+		http.DefaultClient, *config)
+
+	var (
+		wg       sync.WaitGroup
+		uploaded int32
+	)
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := ucase.Upload(context.Background(), *f); err == nil {
+				atomic.AddInt32(&uploaded, 1)
+			} else if !errors.Is(err, media.ErrQuotaExceeded) {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if uploaded != 3 {
+		t.Errorf("expect %d uploaded files within quota, got %d", 3, uploaded)
+	}
+
+	usage, err := ucase.Usage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Size > config.Media.Quota {
+		t.Errorf("expect usage within %d bytes of quota, got %d", config.Media.Quota, usage.Size)
+	}
+}
+
+func TestRehost(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.NewBuffer(nil)
+	if err := png.Encode(content, image.NewGray(image.Rect(0, 0, 16, 9))); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		default:
+			http.NotFound(w, r)
+		case "/photo":
+			_, _ = w.Write(content.Bytes())
+		case "/page":
+			_, _ = w.Write([]byte("<!DOCTYPE html><p>Hello, World!</p>"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	config := domain.TestConfig(t)
+	config.Media.Rehost = true
+
+	t.Run("media", func(t *testing.T) {
+		t.Parallel()
+
+		repo := media.NewSpyMediaRepository(media.NewDummyMediaRepository())
+
+		out, err := usecase.NewMediaUseCase(repo, srv.Client(), *config).
+			Rehost(context.Background(), domain.TestURL(t, srv.URL+"/photo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if out.Host != config.HTTP.Host || path.Ext(out.Path) != ".png" {
+			t.Errorf("expect local PNG URL, got %s", out)
+		}
+
+		if expect := 1; repo.Creates != expect {
+			t.Errorf("expect %d Create calls, got %d", expect, repo.Creates)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := usecase.NewMediaUseCase(media.NewDummyMediaRepository(), srv.Client(), *config).
+			Rehost(context.Background(), domain.TestURL(t, srv.URL+"/page"))
+		if !errors.Is(err, media.ErrUnsupportedType) {
+			t.Errorf("expect %v error, got %v", media.ErrUnsupportedType, err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		in := domain.TestURL(t, srv.URL+"/photo")
+		repo := media.NewSpyMediaRepository(media.NewDummyMediaRepository())
+
+		out, err := usecase.NewMediaUseCase(repo, srv.Client(), *domain.TestConfig(t)).
+			Rehost(context.Background(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if out != in || repo.Creates != 0 {
+			t.Errorf("expect untouched %s URL without Create calls, got %s", in, out)
+		}
+	})
+}
+
+// slowFetchRepository widens window between quota check and storing of file.
+type slowFetchRepository struct {
+	media.Repository
+}
+
+func (repo slowFetchRepository) Fetch(ctx context.Context, p string) ([]domain.File, int, error) {
+	out, count, err := repo.Repository.Fetch(ctx, p)
+
+	time.Sleep(10 * time.Millisecond)
+
+	return out, count, err
+}
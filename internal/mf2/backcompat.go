@@ -0,0 +1,164 @@
+package mf2
+
+// NOTE(toby3d): classic microformats root class names mapped into their
+// microformats2 equivalents.
+var backcompatRoots = map[string]string{
+	"adr":               "h-adr",
+	"geo":               "h-geo",
+	"hentry":            "h-entry",
+	"hfeed":             "h-feed",
+	"hproduct":          "h-product",
+	"hrecipe":           "h-recipe",
+	"hresume":           "h-resume",
+	"hreview":           "h-review",
+	"hreview-aggregate": "h-review-aggregate",
+	"vcard":             "h-card",
+	"vevent":            "h-event",
+}
+
+// NOTE(toby3d): classic microformats property class names of each root mapped
+// into their microformats2 equivalents.
+var backcompatProperties = map[string]map[string]string{
+	"h-adr": {
+		"post-office-box":  "p-post-office-box",
+		"extended-address": "p-extended-address",
+		"street-address":   "p-street-address",
+		"locality":         "p-locality",
+		"region":           "p-region",
+		"postal-code":      "p-postal-code",
+		"country-name":     "p-country-name",
+	},
+	"h-card": {
+		"fn":                "p-name",
+		"honorific-prefix":  "p-honorific-prefix",
+		"given-name":        "p-given-name",
+		"additional-name":   "p-additional-name",
+		"family-name":       "p-family-name",
+		"honorific-suffix":  "p-honorific-suffix",
+		"nickname":          "p-nickname",
+		"email":             "u-email",
+		"logo":              "u-logo",
+		"photo":             "u-photo",
+		"url":               "u-url",
+		"uid":               "u-uid",
+		"category":          "p-category",
+		"adr":               "p-adr",
+		"extended-address":  "p-extended-address",
+		"street-address":    "p-street-address",
+		"locality":          "p-locality",
+		"region":            "p-region",
+		"postal-code":       "p-postal-code",
+		"country-name":      "p-country-name",
+		"label":             "p-label",
+		"geo":               "p-geo",
+		"latitude":          "p-latitude",
+		"longitude":         "p-longitude",
+		"tel":               "p-tel",
+		"note":              "p-note",
+		"bday":              "dt-bday",
+		"key":               "u-key",
+		"org":               "p-org",
+		"organization-name": "p-organization-name",
+		"organization-unit": "p-organization-unit",
+		"title":             "p-job-title",
+		"role":              "p-role",
+		"tz":                "p-tz",
+		"rev":               "dt-rev",
+	},
+	"h-entry": {
+		"entry-title":   "p-name",
+		"entry-summary": "p-summary",
+		"entry-content": "e-content",
+		"published":     "dt-published",
+		"updated":       "dt-updated",
+		"author":        "p-author",
+		"category":      "p-category",
+		"geo":           "p-geo",
+		"latitude":      "p-latitude",
+		"longitude":     "p-longitude",
+	},
+	"h-event": {
+		"summary":     "p-name",
+		"dtstart":     "dt-start",
+		"dtend":       "dt-end",
+		"duration":    "dt-duration",
+		"description": "p-description",
+		"url":         "u-url",
+		"category":    "p-category",
+		"location":    "p-location",
+		"geo":         "p-location",
+		"attendee":    "p-attendee",
+		"contact":     "p-contact",
+		"organizer":   "p-organizer",
+	},
+	"h-feed": {
+		"category": "p-category",
+	},
+	"h-geo": {
+		"latitude":  "p-latitude",
+		"longitude": "p-longitude",
+	},
+	"h-product": {
+		"fn":          "p-name",
+		"photo":       "u-photo",
+		"brand":       "p-brand",
+		"category":    "p-category",
+		"description": "p-description",
+		"identifier":  "u-identifier",
+		"url":         "u-url",
+		"review":      "p-review",
+		"price":       "p-price",
+	},
+	"h-recipe": {
+		"fn":           "p-name",
+		"ingredient":   "p-ingredient",
+		"yield":        "p-yield",
+		"instructions": "e-instructions",
+		"duration":     "dt-duration",
+		"photo":        "u-photo",
+		"summary":      "p-summary",
+		"author":       "p-author",
+		"nutrition":    "p-nutrition",
+		"category":     "p-category",
+	},
+	"h-resume": {
+		"contact":     "p-contact",
+		"summary":     "p-summary",
+		"education":   "p-education",
+		"experience":  "p-experience",
+		"skill":       "p-skill",
+		"affiliation": "p-affiliation",
+	},
+	"h-review": {
+		"summary":     "p-name",
+		"description": "e-content",
+		"item":        "p-item",
+		"reviewer":    "p-author",
+		"dtreviewed":  "dt-published",
+		"rating":      "p-rating",
+		"best":        "p-best",
+		"worst":       "p-worst",
+		"category":    "p-category",
+		"url":         "u-url",
+	},
+	"h-review-aggregate": {
+		"summary":  "p-name",
+		"item":     "p-item",
+		"rating":   "p-rating",
+		"average":  "p-average",
+		"best":     "p-best",
+		"worst":    "p-worst",
+		"count":    "p-count",
+		"votes":    "p-votes",
+		"category": "p-category",
+		"url":      "u-url",
+	},
+}
+
+// NOTE(toby3d): rel values of classic microformats links mapped into
+// microformats2 properties.
+var backcompatRels = map[string]map[string]string{
+	"h-entry":  {"bookmark": "u-url", "tag": "p-category"},
+	"h-feed":   {"tag": "p-category"},
+	"h-review": {"bookmark": "u-url", "tag": "p-category"},
+}
@@ -0,0 +1,7 @@
+// Package mf2 provides a microformats2 parser of HTML documents which outputs
+// the canonical JSON structure: h-*, p-*, u-*, dt-* and e-* properties, implied
+// properties, value class pattern, rel links and backward compatible classic
+// microformats.
+//
+// See: https://microformats.org/wiki/microformats2-parsing
+package mf2
@@ -0,0 +1,147 @@
+package mf2
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// Data represents all microformats and rel links of parsed document.
+	Data struct {
+		Rels    map[string][]string `json:"rels"`
+		RelURLs map[string]*RelURL  `json:"rel-urls"`
+		Items   []*Microformat      `json:"items"`
+	}
+
+	// Microformat represents a single parsed h-* item.
+	Microformat struct {
+		Properties map[string][]any `json:"properties"`
+		ID         string           `json:"id,omitempty"`
+		// NOTE(toby3d): Value and HTML are provided only for
+		// microformats which are also a property of parent.
+		Value    string         `json:"value,omitempty"`
+		HTML     string         `json:"html,omitempty"`
+		Type     []string       `json:"type"`
+		Children []*Microformat `json:"children,omitempty"`
+	}
+
+	// RelURL represents attributes of a single link with rel attribute.
+	RelURL struct {
+		Text     string   `json:"text,omitempty"`
+		Title    string   `json:"title,omitempty"`
+		Media    string   `json:"media,omitempty"`
+		HrefLang string   `json:"hreflang,omitempty"`
+		Type     string   `json:"type,omitempty"`
+		Rels     []string `json:"rels"`
+	}
+
+	// Image represents a value of u-* property parsed from img element
+	// with alt attribute.
+	Image struct {
+		Value string `json:"value"`
+		Alt   string `json:"alt"`
+	}
+
+	// Embedded represents a value of e-* property.
+	Embedded struct {
+		HTML  string `json:"html"`
+		Value string `json:"value"`
+	}
+)
+
+// Parse reads HTML document from r and returns all microformats and rel links
+// of it. Relative URLs are resolved against document <base> or provided base
+// URL.
+func Parse(r io.Reader, base *url.URL) (*Data, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse HTML document: %w", err)
+	}
+
+	return ParseNode(doc, base), nil
+}
+
+// ParseNode returns all microformats and rel links of parsed HTML document.
+func ParseNode(doc *html.Node, base *url.URL) *Data {
+	p := newParser(doc, base)
+	out := &Data{
+		Items:   make([]*Microformat, 0),
+		Rels:    make(map[string][]string),
+		RelURLs: make(map[string]*RelURL),
+	}
+
+	p.parseItems(doc, out)
+	p.parseRels(doc, out)
+
+	return out
+}
+
+// Find returns the first top-level microformat of provided type, or nil.
+func (d Data) Find(t string) *Microformat {
+	for _, item := range d.Items {
+		if item.Is(t) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Is reports whether microformat has provided type, like "h-entry".
+func (m Microformat) Is(t string) bool {
+	for _, v := range m.Type {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the first plain text value of property, the text value of
+// embedded and image property or the value of nested microformat. Returns
+// empty string if there is no such property.
+func (m Microformat) String(name string) string {
+	for _, v := range m.Properties[name] {
+		switch v := v.(type) {
+		case string:
+			return v
+		case Image:
+			return v.Value
+		case Embedded:
+			return v.Value
+		case *Microformat:
+			return v.Value
+		}
+	}
+
+	return ""
+}
+
+// Strings returns all values of property in the same manner as String.
+func (m Microformat) Strings(name string) []string {
+	out := make([]string, 0, len(m.Properties[name]))
+
+	for i := range m.Properties[name] {
+		tmp := Microformat{Properties: map[string][]any{name: m.Properties[name][i : i+1]}}
+		if v := tmp.String(name); v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Item returns the first nested microformat in property, or nil.
+func (m Microformat) Item(name string) *Microformat {
+	for _, v := range m.Properties[name] {
+		if item, ok := v.(*Microformat); ok {
+			return item
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package mf2_test
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/mf2"
+)
+
+// knownGaps contains fixtures of microformats2 test suite which are not
+// supported yet, mapped to the reason. Keys are paths relative to testdata,
+// a directory key skips all fixtures in it.
+var knownGaps = map[string]string{
+	"microformats-v1/includes": "include pattern of classic microformats is not supported",
+	"microformats-v2/h-entry/urlincontent": "e-* html is rendered by x/net/html, which serializes void " +
+		"elements as self-closing",
+}
+
+// TestParse checks parser against fixtures in format of microformats2 test
+// suite: each HTML document in testdata is paired with JSON file of expected
+// output, parsed with http://example.com/ base URL. Cases of the suite itself
+// are placed in microformats-v1 and microformats-v2 directories, following
+// its layout.
+//
+// See: https://github.com/microformats/tests
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	inputs := make([]string, 0)
+	if err := filepath.WalkDir("testdata", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && filepath.Ext(path) == ".html" {
+			inputs = append(inputs, path)
+		}
+
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	base := &url.URL{Scheme: "http", Host: "example.com", Path: "/"}
+
+	for _, input := range inputs {
+		input := input
+		name, _ := filepath.Rel("testdata", strings.TrimSuffix(input, ".html"))
+		name = filepath.ToSlash(name)
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for gap, reason := range knownGaps {
+				if name == gap || strings.HasPrefix(name, gap+"/") {
+					t.Skip(reason)
+				}
+			}
+
+			f, err := os.Open(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = f.Close() })
+
+			data, err := mf2.Parse(f, base)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// NOTE(toby3d): compare both sides as generic JSON values.
+			actual, err := json.Marshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expect, err := os.ReadFile(strings.TrimSuffix(input, ".html") + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want any
+			if err = json.Unmarshal(actual, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = json.Unmarshal(expect, &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestMicroformat_String(t *testing.T) {
+	t.Parallel()
+
+	data, err := mf2.Parse(strings.NewReader(`<article class="h-entry">`+
+		`<a class="u-author h-card" href="https://example.com/">Jane</a>`+
+		`<img class="u-photo" src="a.jpg" alt="A"><img class="u-photo" src="b.jpg">`+
+		`<div class="e-content"><b>Hello</b></div></article>`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := data.Find("h-entry")
+	if entry == nil {
+		t.Fatal("h-entry not found")
+	}
+
+	for name, expect := range map[string]string{
+		"author":  "https://example.com/",
+		"content": "Hello",
+		"photo":   "a.jpg",
+		"missing": "",
+	} {
+		if actual := entry.String(name); actual != expect {
+			t.Errorf("String(%s) = %q, want %q", name, actual, expect)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"a.jpg", "b.jpg"}, entry.Strings("photo")); diff != "" {
+		t.Error(diff)
+	}
+
+	if author := entry.Item("author"); author == nil || !author.Is("h-card") {
+		t.Errorf("Item(author) = %+v, want h-card", author)
+	}
+}
@@ -0,0 +1,964 @@
+package mf2
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	parser struct {
+		base *url.URL
+	}
+
+	// state collects properties of a single microformat during parsing.
+	state struct {
+		item       *Microformat
+		date       string // date of the last dt-* property for implied dates
+		backcompat bool
+		hasP       bool // explicit p-* or e-* properties
+		hasU       bool // explicit u-* properties
+		hasNested  bool // nested microformats
+	}
+
+	property struct {
+		prefix string // p, u, dt or e
+		name   string
+		tag    bool // classic rel="tag" link, value is the last URL segment
+	}
+)
+
+var (
+	rootClass     = regexp.MustCompile(`^h-(?:[a-z0-9]+-)?[a-z]+(?:-[a-z]+)*$`)
+	propertyClass = regexp.MustCompile(`^(p|u|dt|e)-((?:[a-z0-9]+-)?[a-z]+(?:-[a-z]+)*)$`)
+)
+
+func newParser(doc *html.Node, base *url.URL) *parser {
+	p := &parser{base: base}
+	if p.base == nil {
+		p.base = new(url.URL)
+	}
+
+	if n := findElement(doc, "base"); n != nil {
+		if href, ok := attr(n, "href"); ok {
+			if u, err := url.Parse(strings.TrimSpace(href)); err == nil {
+				p.base = p.base.ResolveReference(u)
+			}
+		}
+	}
+
+	return p
+}
+
+// parseItems appends all top-level microformats of n into dst.
+func (p *parser) parseItems(n *html.Node, dst *Data) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		if types, backcompat := rootClasses(c); len(types) > 0 {
+			dst.Items = append(dst.Items, p.parseItem(c, types, backcompat))
+
+			continue
+		}
+
+		p.parseItems(c, dst)
+	}
+}
+
+func (p *parser) parseItem(n *html.Node, types []string, backcompat bool) *Microformat {
+	st := &state{
+		item: &Microformat{
+			Type:       types,
+			Properties: make(map[string][]any),
+		},
+		backcompat: backcompat,
+	}
+
+	st.item.ID, _ = attr(n, "id")
+
+	p.parseChildren(n, st)
+
+	if !backcompat {
+		p.imply(n, st)
+	}
+
+	return st.item
+}
+
+func (p *parser) parseChildren(n *html.Node, st *state) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		props := st.properties(c)
+
+		types, backcompat := rootClasses(c)
+		if len(types) == 0 {
+			for _, prop := range props {
+				st.add(prop, p.parseValue(c, prop, st))
+			}
+
+			p.parseChildren(c, st)
+
+			continue
+		}
+
+		child := p.parseItem(c, types, backcompat)
+		st.hasNested = true
+
+		if len(props) == 0 {
+			st.item.Children = append(st.item.Children, child)
+
+			continue
+		}
+
+		for _, prop := range props {
+			value := *child
+
+			switch prop.prefix {
+			case "p":
+				if name, ok := first(child, "name").(string); ok {
+					value.Value = name
+				} else {
+					value.Value = p.parseP(c)
+				}
+			case "u":
+				if u, ok := first(child, "url").(string); ok {
+					value.Value = u
+				} else {
+					value.Value = stringValue(p.parseU(c))
+				}
+			case "dt":
+				value.Value = p.parseDT(c, st)
+			case "e":
+				embedded := p.parseE(c)
+				value.Value, value.HTML = embedded.Value, embedded.HTML
+			}
+
+			st.add(prop, &value)
+		}
+	}
+}
+
+func (p *parser) parseValue(n *html.Node, prop property, st *state) any {
+	switch prop.prefix {
+	case "u":
+		return p.parseU(n)
+	case "dt":
+		return p.parseDT(n, st)
+	case "e":
+		return p.parseE(n)
+	}
+
+	if href, ok := attr(n, "href"); ok && prop.tag {
+		if u := p.resolve(href); u != "" {
+			if parsed, err := url.Parse(u); err == nil {
+				return path.Base(strings.TrimSuffix(parsed.Path, "/"))
+			}
+		}
+	}
+
+	return p.parseP(n)
+}
+
+func (p *parser) parseP(n *html.Node) string {
+	if v, ok := p.valueClass(n); ok {
+		return v
+	}
+
+	var keys []string
+
+	switch n.Data {
+	case "abbr", "link":
+		keys = []string{"title"}
+	case "data", "input":
+		keys = []string{"value"}
+	case "img", "area":
+		keys = []string{"alt"}
+	}
+
+	for _, key := range keys {
+		if v, ok := attr(n, key); ok {
+			return v
+		}
+	}
+
+	return p.textContent(n)
+}
+
+func (p *parser) parseU(n *html.Node) any {
+	var keys []string
+
+	switch n.Data {
+	case "a", "area", "link":
+		keys = []string{"href"}
+	case "img":
+		if src, ok := attr(n, "src"); ok {
+			return p.image(n, src)
+		}
+	case "audio", "source", "iframe":
+		keys = []string{"src"}
+	case "video":
+		keys = []string{"src", "poster"}
+	case "object":
+		keys = []string{"data"}
+	}
+
+	for _, key := range keys {
+		if v, ok := attr(n, key); ok {
+			return p.resolve(v)
+		}
+	}
+
+	if v, ok := p.valueClass(n); ok {
+		return p.resolve(v)
+	}
+
+	switch n.Data {
+	case "abbr":
+		keys = []string{"title"}
+	case "data", "input":
+		keys = []string{"value"}
+	}
+
+	for _, key := range keys {
+		if v, ok := attr(n, key); ok {
+			return p.resolve(v)
+		}
+	}
+
+	return p.resolve(p.plainText(n))
+}
+
+func (p *parser) parseDT(n *html.Node, st *state) string {
+	v, ok := p.valueClassDateTime(n)
+	if !ok {
+		var keys []string
+
+		switch n.Data {
+		case "time", "ins", "del":
+			keys = []string{"datetime"}
+		case "abbr":
+			keys = []string{"title"}
+		case "data", "input":
+			keys = []string{"value"}
+		}
+
+		v = ""
+
+		for _, key := range keys {
+			if value, found := attr(n, key); found {
+				v, ok = value, true
+
+				break
+			}
+		}
+
+		if !ok {
+			v = p.plainText(n)
+		}
+	}
+
+	date, timeOfDay, _ := splitDateTime(v)
+
+	switch {
+	case date != "":
+		st.date = date
+	case timeOfDay != "" && st.date != "":
+		// NOTE(toby3d): time without date implies the date of previous
+		// dt-* property.
+		v = st.date + " " + v
+	}
+
+	return v
+}
+
+func (p *parser) parseE(n *html.Node) Embedded {
+	buf := new(bytes.Buffer)
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		_ = html.Render(buf, p.resolveTree(c))
+	}
+
+	return Embedded{
+		HTML:  strings.TrimSpace(buf.String()),
+		Value: p.textContent(n),
+	}
+}
+
+// imply adds implied name, photo and url properties into microformat parsed
+// from n.
+func (p *parser) imply(n *html.Node, st *state) {
+	if _, ok := st.item.Properties["name"]; !ok && !st.hasP && !st.hasNested {
+		if name := p.impliedName(n); name != "" {
+			st.item.Properties["name"] = []any{name}
+		}
+	}
+
+	if st.hasU || st.hasNested {
+		return
+	}
+
+	if _, ok := st.item.Properties["photo"]; !ok {
+		if photo := p.impliedPhoto(n); photo != nil {
+			st.item.Properties["photo"] = []any{photo}
+		}
+	}
+
+	if _, ok := st.item.Properties["url"]; !ok {
+		if u := p.impliedURL(n); u != "" {
+			st.item.Properties["url"] = []any{u}
+		}
+	}
+}
+
+func (p *parser) impliedName(n *html.Node) string {
+	if v, ok := nameAttr(n, false); ok {
+		return v
+	}
+
+	if child := onlyChild(n); child != nil {
+		if v, ok := nameAttr(child, true); ok {
+			return v
+		}
+
+		if grandchild := onlyChild(child); grandchild != nil {
+			if v, ok := nameAttr(grandchild, true); ok {
+				return v
+			}
+		}
+	}
+
+	return p.plainText(n)
+}
+
+// nameAttr returns alt attribute of img and area elements or title attribute
+// of abbr element.
+func nameAttr(n *html.Node, nonEmpty bool) (string, bool) {
+	key := "alt"
+
+	switch n.Data {
+	default:
+		return "", false
+	case "img", "area":
+	case "abbr":
+		key = "title"
+	}
+
+	v, ok := attr(n, key)
+	if !ok || (nonEmpty && v == "") {
+		return "", false
+	}
+
+	return v, true
+}
+
+func (p *parser) impliedPhoto(n *html.Node) any {
+	for _, node := range []*html.Node{n, onlyChild(n)} {
+		if node == nil {
+			continue
+		}
+
+		if node == n {
+			if src, ok := attr(n, "src"); ok && n.Data == "img" {
+				return p.image(n, src)
+			}
+
+			if data, ok := attr(n, "data"); ok && n.Data == "object" {
+				return p.resolve(data)
+			}
+		}
+
+		if img := onlyOfType(node, "img"); img != nil {
+			if src, ok := attr(img, "src"); ok {
+				return p.image(img, src)
+			}
+		}
+
+		if object := onlyOfType(node, "object"); object != nil {
+			if data, ok := attr(object, "data"); ok {
+				return p.resolve(data)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) impliedURL(n *html.Node) string {
+	if href, ok := attr(n, "href"); ok && (n.Data == "a" || n.Data == "area") {
+		return p.resolve(href)
+	}
+
+	for _, node := range []*html.Node{n, onlyChild(n)} {
+		if node == nil {
+			continue
+		}
+
+		for _, tag := range []string{"a", "area"} {
+			if link := onlyOfType(node, tag); link != nil {
+				if href, ok := attr(link, "href"); ok {
+					return p.resolve(href)
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseRels collects all links with rel attribute of document into dst.
+func (p *parser) parseRels(n *html.Node, dst *Data) {
+	if n.Type == html.ElementNode && (n.Data == "a" || n.Data == "area" || n.Data == "link") {
+		rel, hasRel := attr(n, "rel")
+		href, hasHref := attr(n, "href")
+
+		if rels := unique(strings.Fields(strings.ToLower(rel))); hasRel && hasHref && len(rels) > 0 {
+			u := p.resolve(href)
+
+			relURL, ok := dst.RelURLs[u]
+			if !ok {
+				relURL = &RelURL{Rels: make([]string, 0, len(rels))}
+				relURL.Title, _ = attr(n, "title")
+				relURL.Media, _ = attr(n, "media")
+				relURL.HrefLang, _ = attr(n, "hreflang")
+				relURL.Type, _ = attr(n, "type")
+
+				if n.Data != "link" {
+					relURL.Text = p.plainText(n)
+				}
+
+				dst.RelURLs[u] = relURL
+			}
+
+			for _, r := range rels {
+				if !contains(dst.Rels[r], u) {
+					dst.Rels[r] = append(dst.Rels[r], u)
+				}
+
+				if !contains(relURL.Rels, r) {
+					relURL.Rels = append(relURL.Rels, r)
+				}
+			}
+
+			sort.Strings(relURL.Rels)
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.parseRels(c, dst)
+	}
+}
+
+// valueClass returns concatenated values of value class pattern elements of n.
+//
+// See: https://microformats.org/wiki/value-class-pattern
+func (p *parser) valueClass(n *html.Node) (string, bool) {
+	nodes := valueNodes(n)
+	if len(nodes) == 0 {
+		return "", false
+	}
+
+	var b strings.Builder
+
+	for _, node := range nodes {
+		b.WriteString(p.valueOf(node))
+	}
+
+	return b.String(), true
+}
+
+// valueClassDateTime returns date and time combined from value class pattern
+// elements of n.
+func (p *parser) valueClassDateTime(n *html.Node) (string, bool) {
+	nodes := valueNodes(n)
+	if len(nodes) == 0 {
+		return "", false
+	}
+
+	var date, timeOfDay, zone string
+
+	for _, node := range nodes {
+		v := p.valueOf(node)
+		if datetime, ok := attr(node, "datetime"); ok && (node.Data == "time" || node.Data == "ins" ||
+			node.Data == "del") {
+			v = datetime
+		}
+
+		d, t, z := splitDateTime(strings.TrimSpace(v))
+		if date == "" {
+			date = d
+		}
+
+		if timeOfDay == "" {
+			timeOfDay = t
+		}
+
+		if zone == "" {
+			zone = z
+		}
+	}
+
+	switch {
+	case date != "" && timeOfDay != "":
+		return date + " " + timeOfDay + zone, true
+	case date != "":
+		return date, true
+	default:
+		return timeOfDay + zone, true
+	}
+}
+
+// valueOf returns value of a single value class pattern element.
+func (p *parser) valueOf(n *html.Node) string {
+	if hasClass(n, "value-title") {
+		v, _ := attr(n, "title")
+
+		return v
+	}
+
+	var key string
+
+	switch n.Data {
+	case "img", "area":
+		key = "alt"
+	case "data":
+		key = "value"
+	case "abbr":
+		key = "title"
+	}
+
+	if v, ok := attr(n, key); ok && key != "" {
+		return v
+	}
+
+	return p.plainText(n)
+}
+
+// textContent returns trimmed text of n without scripts and styles, where
+// images are replaced by their alt or src attributes.
+func (p *parser) textContent(n *html.Node) string {
+	return p.text(n, true)
+}
+
+// plainText returns trimmed text of n without scripts and styles.
+func (p *parser) plainText(n *html.Node) string {
+	return p.text(n, false)
+}
+
+func (p *parser) text(n *html.Node, images bool) string {
+	var b strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style" || n.Data == "template"):
+			return
+		case n.Type == html.ElementNode && n.Data == "img" && images:
+			if alt, ok := attr(n, "alt"); ok {
+				b.WriteString(alt)
+			} else if src, ok := attr(n, "src"); ok {
+				b.WriteString(" " + p.resolve(src) + " ")
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(n)
+
+	return strings.TrimSpace(b.String())
+}
+
+// resolveTree returns a deep copy of n where relative URLs of links and
+// embedded resources are resolved.
+func (p *parser) resolveTree(n *html.Node) *html.Node {
+	out := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+		Attr:      make([]html.Attribute, len(n.Attr)),
+	}
+
+	copy(out.Attr, n.Attr)
+
+	for i := range out.Attr {
+		switch out.Attr[i].Key {
+		case "href", "src", "poster", "cite":
+			out.Attr[i].Val = p.resolve(out.Attr[i].Val)
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		out.AppendChild(p.resolveTree(c))
+	}
+
+	return out
+}
+
+func (p *parser) image(n *html.Node, src string) any {
+	if alt, ok := attr(n, "alt"); ok {
+		return Image{Value: p.resolve(src), Alt: alt}
+	}
+
+	return p.resolve(src)
+}
+
+func (p *parser) resolve(ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+
+	// NOTE(toby3d): relative URLs stays as is without any base.
+	if *p.base == (url.URL{}) {
+		return u.String()
+	}
+
+	return p.base.ResolveReference(u).String()
+}
+
+// properties returns property classes of n which belongs to microformat of
+// st.
+func (st *state) properties(n *html.Node) []property {
+	out := make([]property, 0)
+	seen := make(map[string]struct{})
+	push := func(class string, tag bool) {
+		match := propertyClass.FindStringSubmatch(class)
+		if match == nil {
+			return
+		}
+
+		if _, ok := seen[class]; ok {
+			return
+		}
+
+		seen[class] = struct{}{}
+		out = append(out, property{prefix: match[1], name: match[2], tag: tag})
+	}
+
+	classes := strings.Fields(attrValue(n, "class"))
+
+	if !st.backcompat {
+		for _, class := range classes {
+			push(class, false)
+		}
+
+		return out
+	}
+
+	for _, t := range st.item.Type {
+		for _, class := range classes {
+			if v, ok := backcompatProperties[t][class]; ok {
+				push(v, false)
+			}
+		}
+
+		for _, rel := range strings.Fields(strings.ToLower(attrValue(n, "rel"))) {
+			if v, ok := backcompatRels[t][rel]; ok {
+				push(v, rel == "tag")
+			}
+		}
+	}
+
+	return out
+}
+
+func (st *state) add(prop property, value any) {
+	st.item.Properties[prop.name] = append(st.item.Properties[prop.name], value)
+
+	switch prop.prefix {
+	case "p", "e":
+		st.hasP = true
+	case "u":
+		st.hasU = true
+	}
+}
+
+// rootClasses returns sorted microformats2 root class names of n, or their
+// equivalents of classic microformats root class names if n has none.
+func rootClasses(n *html.Node) ([]string, bool) {
+	classes := strings.Fields(attrValue(n, "class"))
+	out := make([]string, 0)
+
+	for _, class := range classes {
+		if rootClass.MatchString(class) {
+			out = append(out, class)
+		}
+	}
+
+	if len(out) > 0 {
+		out = unique(out)
+		sort.Strings(out)
+
+		return out, false
+	}
+
+	for _, class := range classes {
+		if v, ok := backcompatRoots[class]; ok {
+			out = append(out, v)
+		}
+	}
+
+	out = unique(out)
+	sort.Strings(out)
+
+	return out, true
+}
+
+// valueNodes returns descendants of n with value or value-title class which
+// are not a part of nested properties or microformats.
+func valueNodes(n *html.Node) []*html.Node {
+	out := make([]*html.Node, 0)
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		if hasClass(c, "value") || hasClass(c, "value-title") {
+			out = append(out, c)
+
+			continue
+		}
+
+		if types, _ := rootClasses(c); len(types) > 0 || hasPropertyClass(c) {
+			continue
+		}
+
+		out = append(out, valueNodes(c)...)
+	}
+
+	return out
+}
+
+// onlyChild returns the only element child of n if it is not a microformat.
+func onlyChild(n *html.Node) *html.Node {
+	var out *html.Node
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		if out != nil {
+			return nil
+		}
+
+		out = c
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if types, _ := rootClasses(out); len(types) > 0 {
+		return nil
+	}
+
+	return out
+}
+
+// onlyOfType returns the only element child of n with provided tag if it is
+// not a microformat.
+func onlyOfType(n *html.Node, tag string) *html.Node {
+	var out *html.Node
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != tag {
+			continue
+		}
+
+		if out != nil {
+			return nil
+		}
+
+		out = c
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if types, _ := rootClasses(out); len(types) > 0 {
+		return nil
+	}
+
+	return out
+}
+
+// splitDateTime splits value into date, time and timezone parts. Time in 12
+// hours format is converted into 24 hours format.
+func splitDateTime(v string) (date, timeOfDay, zone string) {
+	if match := datePattern.FindString(v); match != "" {
+		date = match
+		v = strings.TrimLeft(v[len(match):], "T ")
+	}
+
+	if match := zonePattern.FindString(v); match != "" && len(match) < len(v) {
+		zone = strings.ReplaceAll(match, "z", "Z")
+		v = strings.TrimSpace(v[:len(v)-len(match)])
+	} else if match == v && date == "" {
+		return "", "", strings.ReplaceAll(match, "z", "Z")
+	}
+
+	if match := timePattern.FindStringSubmatch(v); match != nil {
+		timeOfDay = normalizeTime(match)
+	}
+
+	return date, timeOfDay, zone
+}
+
+var (
+	datePattern = regexp.MustCompile(`^\d{4}-(?:\d{2}-\d{2}|\d{3})`)
+	timePattern = regexp.MustCompile(`(?i)^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?\s*(a\.?m\.?|p\.?m\.?)?$`)
+	zonePattern = regexp.MustCompile(`(?i)(?:z|[+-]\d{1,2}(?::?\d{2})?)$`)
+)
+
+func normalizeTime(match []string) string {
+	hours, minutes, seconds := match[1], match[2], match[3]
+	meridiem := strings.ToLower(strings.ReplaceAll(match[4], ".", ""))
+	if meridiem == "" {
+		if minutes == "" {
+			return hours
+		}
+
+		if len(hours) == 1 {
+			hours = "0" + hours
+		}
+
+		out := hours + ":" + minutes
+		if seconds != "" {
+			out += ":" + seconds
+		}
+
+		return out
+	}
+
+	h := 0
+	for _, r := range hours {
+		h = h*10 + int(r-'0')
+	}
+
+	switch {
+	case meridiem == "pm" && h < 12:
+		h += 12
+	case meridiem == "am" && h == 12:
+		h = 0
+	}
+
+	if minutes == "" {
+		minutes = "00"
+	}
+
+	out := string([]byte{byte('0' + h/10), byte('0' + h%10)}) + ":" + minutes
+	if seconds != "" {
+		out += ":" + seconds
+	}
+
+	return out
+}
+
+func findElement(n *html.Node, tag string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if out := findElement(c, tag); out != nil {
+			return out
+		}
+	}
+
+	return nil
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, v := range strings.Fields(attrValue(n, "class")) {
+		if v == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasPropertyClass(n *html.Node) bool {
+	for _, v := range strings.Fields(attrValue(n, "class")) {
+		if propertyClass.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func attr(n *html.Node, key string) (string, bool) {
+	for i := range n.Attr {
+		if n.Attr[i].Namespace == "" && n.Attr[i].Key == key {
+			return n.Attr[i].Val, true
+		}
+	}
+
+	return "", false
+}
+
+func attrValue(n *html.Node, key string) string {
+	v, _ := attr(n, key)
+
+	return v
+}
+
+func first(item *Microformat, name string) any {
+	if values := item.Properties[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return nil
+}
+
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case Image:
+		return v.Value
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+func contains(values []string, v string) bool {
+	for i := range values {
+		if values[i] == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unique(values []string) []string {
+	out := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if !contains(out, v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
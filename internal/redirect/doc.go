@@ -0,0 +1,5 @@
+// Package redirect provides permanent redirects from former URLs of moved
+// entries to the current ones, and tombstones of permanently removed entries.
+// Chains of moves are collapsed, so each former URL points directly to the
+// current one.
+package redirect
@@ -0,0 +1,93 @@
+package redirect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+)
+
+// NewHook creates a hook which redirects former URL of entry after it moves,
+// and leaves a tombstone after entry is purged. Redirects from the URL which
+// is used by entry again are removed.
+func NewHook(redirects Repository, logger *log.Logger) entry.Hook {
+	return entry.HookFunc(func(ctx context.Context, action domain.Action, before, after *domain.Entry) {
+		var from, to *url.URL
+
+		switch {
+		case after != nil && after.URL != nil:
+			to = clone(after.URL)
+
+			if _, err := redirects.Delete(ctx, to.RequestURI()); err != nil {
+				logger.Printf("cannot remove redirect from %s: %s", to, err)
+			}
+
+			if before == nil || before.URL == nil || equal(before.URL, after.URL) {
+				return
+			}
+
+			from = clone(before.URL)
+		case action == domain.ActionPurge && before != nil && before.URL != nil:
+			from = clone(before.URL)
+		default:
+			return
+		}
+
+		// NOTE(toby3d): redirects must not fail already stored changes.
+		if err := move(ctx, redirects, from, to); err != nil {
+			logger.Printf("cannot redirect %s: %s", from, err)
+		}
+	})
+}
+
+// move redirects from and all former URLs of it directly to provided target,
+// or marks them as gone if target is nil.
+func move(ctx context.Context, redirects Repository, from, to *url.URL) error {
+	former, err := redirects.Fetch(ctx, from.RequestURI())
+	if err != nil {
+		return fmt.Errorf("cannot fetch former redirects: %w", err)
+	}
+
+	for i := range former {
+		former[i].To = to
+
+		if err = redirects.Update(ctx, former[i].From.RequestURI(), former[i]); err != nil {
+			return fmt.Errorf("cannot collapse redirect from %s: %w", former[i].From, err)
+		}
+	}
+
+	r := domain.Redirect{
+		CreatedAt: time.Now().UTC(),
+		From:      from,
+		To:        to,
+	}
+
+	if err = redirects.Create(ctx, from.RequestURI(), r); err != nil {
+		if !errors.Is(err, ErrExist) {
+			return fmt.Errorf("cannot create redirect: %w", err)
+		}
+
+		if err = redirects.Update(ctx, from.RequestURI(), r); err != nil {
+			return fmt.Errorf("cannot update redirect: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func equal(a, b *url.URL) bool {
+	return path.Clean(strings.ToLower(a.RequestURI())) == path.Clean(strings.ToLower(b.RequestURI()))
+}
+
+func clone(u *url.URL) *url.URL {
+	out := *u
+
+	return &out
+}
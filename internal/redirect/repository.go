@@ -0,0 +1,55 @@
+package redirect
+
+import (
+	"context"
+	"errors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Repository interface {
+		// Create stores redirect from provided path. Returns ErrExist
+		// if path is already redirected.
+		Create(ctx context.Context, path string, r domain.Redirect) error
+
+		// Get returns redirect from provided path.
+		Get(ctx context.Context, path string) (*domain.Redirect, error)
+
+		// Fetch returns all redirects to provided path.
+		Fetch(ctx context.Context, to string) ([]domain.Redirect, error)
+
+		// Update replaces redirect from provided path.
+		Update(ctx context.Context, path string, r domain.Redirect) error
+
+		// Delete removes redirect from provided path.
+		Delete(ctx context.Context, path string) (bool, error)
+	}
+
+	dummyRepository struct{}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+)
+
+var (
+	ErrExist    error = errors.New("this redirect already exist")
+	ErrNotExist error = errors.New("this redirect is not exist")
+)
+
+// NewDummyRedirectRepository creates an empty repository to satisfy contracts.
+// It is used in tests where repository working is not important.
+func NewDummyRedirectRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, _ domain.Redirect) error { return nil }
+func (dummyRepository) Update(_ context.Context, _ string, _ domain.Redirect) error { return nil }
+func (dummyRepository) Delete(_ context.Context, _ string) (bool, error)            { return false, nil }
+
+func (dummyRepository) Get(_ context.Context, _ string) (*domain.Redirect, error) {
+	return nil, ErrNotExist
+}
+
+func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.Redirect, error) {
+	return make([]domain.Redirect, 0), nil
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+)
+
+type memoryRedirectRepository struct {
+	mutex     *sync.RWMutex
+	redirects map[string]domain.Redirect
+}
+
+func NewMemoryRedirectRepository() redirect.Repository {
+	return &memoryRedirectRepository{
+		mutex:     new(sync.RWMutex),
+		redirects: make(map[string]domain.Redirect),
+	}
+}
+
+func (repo *memoryRedirectRepository) Create(_ context.Context, p string, r domain.Redirect) error {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.redirects[p]; ok {
+		return redirect.ErrExist
+	}
+
+	repo.redirects[p] = r
+
+	return nil
+}
+
+func (repo *memoryRedirectRepository) Get(_ context.Context, p string) (*domain.Redirect, error) {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out, ok := repo.redirects[p]
+	if !ok {
+		return nil, redirect.ErrNotExist
+	}
+
+	return &out, nil
+}
+
+func (repo *memoryRedirectRepository) Fetch(_ context.Context, to string) ([]domain.Redirect, error) {
+	to = path.Clean(strings.ToLower(to))
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := make([]domain.Redirect, 0)
+
+	for _, r := range repo.redirects {
+		if r.To != nil && path.Clean(strings.ToLower(r.To.RequestURI())) == to {
+			out = append(out, r)
+		}
+	}
+
+	return out, nil
+}
+
+func (repo *memoryRedirectRepository) Update(_ context.Context, p string, r domain.Redirect) error {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.redirects[p]; !ok {
+		return fmt.Errorf("cannot update redirect: %w", redirect.ErrNotExist)
+	}
+
+	repo.redirects[p] = r
+
+	return nil
+}
+
+func (repo *memoryRedirectRepository) Delete(_ context.Context, p string) (bool, error) {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.redirects[p]; !ok {
+		return false, nil
+	}
+
+	delete(repo.redirects, p)
+
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package redirect
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Resolve returns the current URL of entry formerly available
+		// on provided URL. Returns ErrNotExist if URL was never moved
+		// and ErrGone if entry was permanently removed.
+		Resolve(ctx context.Context, u *url.URL) (*url.URL, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		target *url.URL
+		err    error
+	}
+)
+
+var ErrGone error = domain.Error{
+	Description: "this entry was permanently removed",
+	Frame:       xerrors.Caller(1),
+	Code:        http.StatusGone,
+}
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Resolve(_ context.Context, _ *url.URL) (*url.URL, error) {
+	return nil, ErrNotExist
+}
+
+// NewStubUseCase creates a stub use case what always resolves to provided
+// target.
+func NewStubUseCase(target *url.URL, err error) UseCase {
+	return &stubUseCase{
+		target: target,
+		err:    err,
+	}
+}
+
+func (ucase *stubUseCase) Resolve(_ context.Context, _ *url.URL) (*url.URL, error) {
+	return ucase.target, ucase.err
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+)
+
+type redirectUseCase struct {
+	redirects redirect.Repository
+}
+
+// NewRedirectUseCase creates a new redirect use case.
+func NewRedirectUseCase(redirects redirect.Repository) redirect.UseCase {
+	return &redirectUseCase{
+		redirects: redirects,
+	}
+}
+
+// Resolve implements redirect.UseCase.
+func (ucase *redirectUseCase) Resolve(ctx context.Context, u *url.URL) (*url.URL, error) {
+	r, err := ucase.redirects.Get(ctx, u.RequestURI())
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve redirect: %w", err)
+	}
+
+	if r.Gone() {
+		return nil, redirect.ErrGone
+	}
+
+	return r.To, nil
+}
@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/url"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	redirectmemoryrepo "source.toby3d.me/toby3d/pub/internal/redirect/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/redirect/usecase"
+)
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	redirects := redirectmemoryrepo.NewMemoryRedirectRepository()
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(),
+		redirect.NewHook(redirects, log.New(io.Discard, "", 0)))
+	ucase := usecase.NewRedirectUseCase(redirects)
+
+	e := domain.TestEntry(t)
+	if _, err := entries.Create(ctx, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	move := func(tb testing.TB, from, to string) {
+		tb.Helper()
+
+		in, err := entries.Source(ctx, &url.URL{Path: from})
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		in.URL = &url.URL{Path: to}
+
+		if _, err = entries.Update(ctx, &url.URL{Path: from}, entry.UpdateOptions{Replace: in}); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	resolve := func(tb testing.TB, p string) (string, error) {
+		tb.Helper()
+
+		out, err := ucase.Resolve(ctx, &url.URL{Path: p})
+		if err != nil {
+			return "", err
+		}
+
+		return out.Path, nil
+	}
+
+	move(t, "/samples/lipsum", "/samples/first")
+	move(t, "/samples/first", "/samples/second")
+
+	for _, p := range []string{"/samples/lipsum", "/samples/first"} {
+		if out, err := resolve(t, p); err != nil || out != "/samples/second" {
+			t.Errorf("expect %s redirected to /samples/second, got '%s', %v", p, out, err)
+		}
+	}
+
+	if _, err := resolve(t, "/samples/second"); !errors.Is(err, redirect.ErrNotExist) {
+		t.Errorf("expect %v for current URL, got %v", redirect.ErrNotExist, err)
+	}
+
+	// NOTE(toby3d): moving back claims the former URL.
+	move(t, "/samples/second", "/samples/lipsum")
+
+	if _, err := resolve(t, "/samples/lipsum"); !errors.Is(err, redirect.ErrNotExist) {
+		t.Errorf("expect %v for claimed URL, got %v", redirect.ErrNotExist, err)
+	}
+
+	if _, err := entries.Delete(ctx, &url.URL{Path: "/samples/lipsum"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entries.Purge(ctx, &url.URL{Path: "/samples/lipsum"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{"/samples/lipsum", "/samples/first", "/samples/second"} {
+		if _, err := resolve(t, p); !errors.Is(err, redirect.ErrGone) {
+			t.Errorf("expect %v for %s, got %v", redirect.ErrGone, p, err)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package revision provides history of entries changes: immutable snapshots
+// stored after each change, differences between them and reverting of entries
+// to previous states.
+package revision
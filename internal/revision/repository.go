@@ -0,0 +1,77 @@
+package revision
+
+import (
+	"context"
+	"errors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Repository interface {
+		// Create stores revision of entry on provided path as the next in
+		// sequence, ignoring it's number, and returns stored revision.
+		Create(ctx context.Context, path string, r domain.Revision) (*domain.Revision, error)
+
+		// Get returns a early stored revision of entry on provided path.
+		// Returns error if revision is not exist.
+		Get(ctx context.Context, path string, number int) (*domain.Revision, error)
+
+		// Fetch returns all revisions of entry on provided path, the
+		// oldest first.
+		Fetch(ctx context.Context, path string) ([]domain.Revision, int, error)
+	}
+
+	dummyRepository struct{}
+
+	stubRepository struct {
+		output  *domain.Revision
+		err     error
+		outputs []domain.Revision
+	}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+)
+
+var ErrNotExist error = errors.New("this revision is not exist")
+
+// NewDummyRevisionRepository creates an empty repository to satisfy contracts.
+// It is used in tests where repository working is not important.
+func NewDummyRevisionRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, r domain.Revision) (*domain.Revision, error) {
+	return &r, nil
+}
+
+func (dummyRepository) Get(_ context.Context, _ string, _ int) (*domain.Revision, error) {
+	return nil, nil
+}
+
+func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.Revision, int, error) {
+	return make([]domain.Revision, 0), 0, nil
+}
+
+// NewStubRevisionRepository creates a repository that always returns input as
+// a output. It is used in tests where some dependency on the repository is
+// required.
+func NewStubRevisionRepository(outputs []domain.Revision, output *domain.Revision, err error) Repository {
+	return &stubRepository{
+		outputs: outputs,
+		output:  output,
+		err:     err,
+	}
+}
+
+func (repo *stubRepository) Create(_ context.Context, _ string, _ domain.Revision) (*domain.Revision, error) {
+	return repo.output, repo.err
+}
+
+func (repo *stubRepository) Get(_ context.Context, _ string, _ int) (*domain.Revision, error) {
+	return repo.output, repo.err
+}
+
+func (repo *stubRepository) Fetch(_ context.Context, _ string) ([]domain.Revision, int, error) {
+	return repo.outputs, len(repo.outputs), repo.err
+}
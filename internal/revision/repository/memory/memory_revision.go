@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+)
+
+type memoryRevisionRepository struct {
+	mutex     *sync.RWMutex
+	revisions map[string][]domain.Revision // entry path → revisions, the oldest first
+}
+
+func NewMemoryRevisionRepository() revision.Repository {
+	return &memoryRevisionRepository{
+		mutex:     new(sync.RWMutex),
+		revisions: make(map[string][]domain.Revision),
+	}
+}
+
+func (repo *memoryRevisionRepository) Create(_ context.Context, p string, r domain.Revision) (*domain.Revision,
+	error,
+) {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	r.Number = len(repo.revisions[p]) + 1
+	repo.revisions[p] = append(repo.revisions[p], r)
+
+	return &r, nil
+}
+
+func (repo *memoryRevisionRepository) Get(_ context.Context, p string, number int) (*domain.Revision, error) {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if number < 1 || number > len(repo.revisions[p]) {
+		return nil, revision.ErrNotExist
+	}
+
+	out := repo.revisions[p][number-1]
+
+	return &out, nil
+}
+
+func (repo *memoryRevisionRepository) Fetch(_ context.Context, p string) ([]domain.Revision, int, error) {
+	p = path.Clean(strings.ToLower(p))
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := append(make([]domain.Revision, 0, len(repo.revisions[p])), repo.revisions[p]...)
+
+	return out, len(out), nil
+}
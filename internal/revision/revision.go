@@ -0,0 +1,167 @@
+package revision
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+)
+
+type (
+	contextKey       struct{}
+	actionContextKey struct{}
+
+	origin struct {
+		author string
+		client string
+	}
+)
+
+// NewContext returns a copy of ctx which carries author and client of entries
+// changes made within it.
+func NewContext(ctx context.Context, author, client string) context.Context {
+	return context.WithValue(ctx, contextKey{}, origin{author: author, client: client})
+}
+
+// FromContext returns author and client of changes stored in ctx, if any.
+func FromContext(ctx context.Context) (string, string) {
+	out, _ := ctx.Value(contextKey{}).(origin)
+
+	return out.author, out.client
+}
+
+// WithAction returns a copy of ctx in which changes of entries are stored as
+// provided action instead of the original one, like update made by revert.
+func WithAction(ctx context.Context, action domain.Action) context.Context {
+	return context.WithValue(ctx, actionContextKey{}, action)
+}
+
+// NewHook creates a hook which stores a revision of entry after each change.
+func NewHook(revisions Repository, logger *log.Logger) entry.Hook {
+	return entry.HookFunc(func(ctx context.Context, action domain.Action, _, after *domain.Entry) {
+		if after == nil || after.URL == nil {
+			return
+		}
+
+		author, client := FromContext(ctx)
+		if override, ok := ctx.Value(actionContextKey{}).(domain.Action); ok {
+			action = override
+		}
+
+		// NOTE(toby3d): history must not fail already stored changes.
+		if _, err := revisions.Create(ctx, after.URL.RequestURI(), domain.Revision{
+			CreatedAt: time.Now().UTC(),
+			Entry:     after.Clone(),
+			Action:    action,
+			Author:    author,
+			Client:    client,
+		}); err != nil {
+			logger.Printf("cannot store revision of %s: %s", after.URL, err)
+		}
+	})
+}
+
+// Diff returns changed properties between two states of entry, in order of
+// microformats2 vocabulary.
+func Diff(before, after domain.Entry) []domain.Change {
+	out := make([]domain.Change, 0)
+	a, b := Properties(before), Properties(after)
+
+	for _, key := range propertiesOrder {
+		if equal(a[key], b[key]) {
+			continue
+		}
+
+		out = append(out, domain.Change{Property: key, Before: a[key], After: b[key]})
+	}
+
+	return out
+}
+
+var propertiesOrder = []string{
+	"name", "summary", "content", "published", "updated", "category", "url", "uid", "syndication", "in-reply-to",
+	"like-of", "repost-of", "bookmark-of", "rsvp", "post-status", "photo", "video", "audio", "deleted",
+}
+
+// Properties returns microformats2 properties of entry as strings.
+func Properties(e domain.Entry) map[string][]string {
+	out := make(map[string][]string)
+	set := func(key string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				out[key] = append(out[key], v)
+			}
+		}
+	}
+	setTime := func(key string, t time.Time) {
+		if !t.IsZero() {
+			set(key, t.Format(time.RFC3339))
+		}
+	}
+	setURLs := func(key string, urls ...*url.URL) {
+		for _, u := range urls {
+			if u != nil {
+				set(key, u.String())
+			}
+		}
+	}
+
+	set("name", e.Title)
+	set("summary", e.Description)
+
+	switch {
+	case e.Content.Markdown != "":
+		set("content", e.Content.Markdown)
+	case e.Content.Text != "":
+		set("content", e.Content.Text)
+	default:
+		set("content", e.Content.RenderHTML())
+	}
+
+	setTime("published", e.PublishedAt)
+	setTime("updated", e.UpdatedAt)
+	set("category", e.Tags...)
+	setURLs("url", e.URL)
+	set("uid", e.ID)
+	setURLs("syndication", e.Syndications...)
+	setURLs("in-reply-to", e.InReplyTo...)
+	setURLs("like-of", e.LikeOf...)
+	setURLs("repost-of", e.RepostOf...)
+	setURLs("bookmark-of", e.BookmarkOf...)
+
+	if e.RSVP != domain.RSVPUnd {
+		set("rsvp", e.RSVP.String())
+	}
+
+	if e.Status != domain.PostStatusUnd {
+		set("post-status", e.Status.String())
+	}
+
+	setURLs("photo", e.Photo...)
+	setURLs("video", e.Video...)
+	setURLs("audio", e.Audio...)
+
+	if !e.DeletedAt.IsZero() {
+		set("deleted", strconv.FormatBool(true))
+	}
+
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package revision
+
+import (
+	"context"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Fetch returns all revisions of entry on provided URL, the
+		// oldest first.
+		Fetch(ctx context.Context, u *url.URL) ([]domain.Revision, error)
+
+		// Source returns revision of entry on provided URL by it's
+		// number.
+		Source(ctx context.Context, u *url.URL, number int) (*domain.Revision, error)
+
+		// Diff returns changed properties of entry on provided URL
+		// between two revisions.
+		Diff(ctx context.Context, u *url.URL, from, to int) ([]domain.Change, error)
+
+		// Revert restores entry on provided URL to the state of
+		// revision. Revert itself is stored as a new revision.
+		Revert(ctx context.Context, u *url.URL, number int) (*domain.Entry, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		revision *domain.Revision
+		err      error
+	}
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Fetch(_ context.Context, _ *url.URL) ([]domain.Revision, error) {
+	return make([]domain.Revision, 0), nil
+}
+
+func (dummyUseCase) Source(_ context.Context, _ *url.URL, _ int) (*domain.Revision, error) {
+	return nil, nil
+}
+
+func (dummyUseCase) Diff(_ context.Context, _ *url.URL, _, _ int) ([]domain.Change, error) {
+	return make([]domain.Change, 0), nil
+}
+
+func (dummyUseCase) Revert(_ context.Context, _ *url.URL, _ int) (*domain.Entry, error) {
+	return nil, nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided
+// revision as the only one.
+func NewStubUseCase(r *domain.Revision, err error) UseCase {
+	return &stubUseCase{
+		revision: r,
+		err:      err,
+	}
+}
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ *url.URL) ([]domain.Revision, error) {
+	out := make([]domain.Revision, 0, 1)
+	if ucase.revision != nil {
+		out = append(out, *ucase.revision)
+	}
+
+	return out, ucase.err
+}
+
+func (ucase *stubUseCase) Source(_ context.Context, _ *url.URL, _ int) (*domain.Revision, error) {
+	return ucase.revision, ucase.err
+}
+
+func (ucase *stubUseCase) Diff(_ context.Context, _ *url.URL, _, _ int) ([]domain.Change, error) {
+	return make([]domain.Change, 0), ucase.err
+}
+
+func (ucase *stubUseCase) Revert(_ context.Context, _ *url.URL, _ int) (*domain.Entry, error) {
+	if ucase.revision == nil {
+		return nil, ucase.err
+	}
+
+	out := ucase.revision.Entry
+
+	return &out, ucase.err
+}
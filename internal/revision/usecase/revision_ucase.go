@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+)
+
+type revisionUseCase struct {
+	revisions revision.Repository
+	entries   entry.UseCase
+}
+
+func NewRevisionUseCase(revisions revision.Repository, entries entry.UseCase) revision.UseCase {
+	return &revisionUseCase{
+		revisions: revisions,
+		entries:   entries,
+	}
+}
+
+// Fetch implements revision.UseCase.
+func (ucase *revisionUseCase) Fetch(ctx context.Context, u *url.URL) ([]domain.Revision, error) {
+	out, _, err := ucase.revisions.Fetch(ctx, u.RequestURI())
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch revisions: %w", err)
+	}
+
+	return out, nil
+}
+
+// Source implements revision.UseCase.
+func (ucase *revisionUseCase) Source(ctx context.Context, u *url.URL, number int) (*domain.Revision, error) {
+	out, err := ucase.revisions.Get(ctx, u.RequestURI(), number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision: %w", err)
+	}
+
+	return out, nil
+}
+
+// Diff implements revision.UseCase.
+func (ucase *revisionUseCase) Diff(ctx context.Context, u *url.URL, from, to int) ([]domain.Change, error) {
+	before, err := ucase.revisions.Get(ctx, u.RequestURI(), from)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision to compare from: %w", err)
+	}
+
+	after, err := ucase.revisions.Get(ctx, u.RequestURI(), to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision to compare to: %w", err)
+	}
+
+	return revision.Diff(before.Entry, after.Entry), nil
+}
+
+// Revert implements revision.UseCase.
+func (ucase *revisionUseCase) Revert(ctx context.Context, u *url.URL, number int) (*domain.Entry, error) {
+	r, err := ucase.revisions.Get(ctx, u.RequestURI(), number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get revision to revert: %w", err)
+	}
+
+	current, err := ucase.entries.Source(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get reverted entry: %w", err)
+	}
+
+	// NOTE(toby3d): history is stored per URL, so reverting must not move
+	// entry out of it.
+	in := r.Entry
+	in.URL = current.URL
+
+	out, err := ucase.entries.Update(revision.WithAction(ctx, domain.ActionRevert), u,
+		entry.UpdateOptions{Replace: &in})
+	if err != nil {
+		return nil, fmt.Errorf("cannot revert entry: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+	revisionmemoryrepo "source.toby3d.me/toby3d/pub/internal/revision/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/revision/usecase"
+)
+
+// setup creates entry with one update, so it has two revisions.
+func setup(tb testing.TB) (entry.UseCase, revision.UseCase, *domain.Entry) {
+	tb.Helper()
+
+	ctx := revision.NewContext(context.Background(), "https://example.com/", "Quill")
+	revisions := revisionmemoryrepo.NewMemoryRevisionRepository()
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(),
+		revision.NewHook(revisions, log.New(testWriter{tb}, "", 0)))
+
+	e := domain.TestEntry(tb)
+	if _, err := entries.Create(ctx, *e); err != nil {
+		tb.Fatal(err)
+	}
+
+	if _, err := entries.Update(ctx, e.URL, entry.UpdateOptions{
+		Add: &domain.Entry{Tags: []string{"indieweb"}},
+	}); err != nil {
+		tb.Fatal(err)
+	}
+
+	return entries, usecase.NewRevisionUseCase(revisions, entries), e
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	_, ucase, e := setup(t)
+
+	out, err := ucase.Fetch(context.Background(), e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := make([]domain.Action, 0, len(out))
+	for i := range out {
+		if out[i].Number != i+1 {
+			t.Errorf("expect revision #%d, got #%d", i+1, out[i].Number)
+		}
+
+		if out[i].Author != "https://example.com/" || out[i].Client != "Quill" {
+			t.Errorf("expect stored author and client, got '%s' and '%s'", out[i].Author, out[i].Client)
+		}
+
+		actions = append(actions, out[i].Action)
+	}
+
+	if diff := cmp.Diff([]domain.Action{domain.ActionCreate, domain.ActionUpdate}, actions,
+		cmp.AllowUnexported(domain.Action{})); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	_, ucase, e := setup(t)
+
+	out, err := ucase.Diff(context.Background(), e.URL, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE(toby3d): updated time may be the same within a second.
+	var category *domain.Change
+
+	for i := range out {
+		switch out[i].Property {
+		case "updated":
+		case "category":
+			category = &out[i]
+		default:
+			t.Errorf("expect only category and updated changes, got %s", out[i].Property)
+		}
+	}
+
+	if diff := cmp.Diff(&domain.Change{
+		Property: "category",
+		Before:   e.Tags,
+		After:    append(append([]string(nil), e.Tags...), "indieweb"),
+	}, category); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err = ucase.Diff(context.Background(), e.URL, 1, 42); err == nil {
+		t.Error("expect error for unknown revision")
+	}
+}
+
+func TestRevert(t *testing.T) {
+	t.Parallel()
+
+	entries, ucase, e := setup(t)
+
+	out, err := ucase.Revert(context.Background(), e.URL, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(e.Tags, out.Tags); diff != "" {
+		t.Error(diff)
+	}
+
+	revisions, err := ucase.Fetch(context.Background(), e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := revisions[len(revisions)-1]; last.Number != 3 || last.Action != domain.ActionRevert {
+		t.Errorf("expect revert stored as revision #3, got #%d '%s'", last.Number, last.Action)
+	}
+
+	source, err := entries.Source(context.Background(), e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(e.Tags, source.Tags); diff != "" {
+		t.Error(diff)
+	}
+}
+
+type testWriter struct{ testing.TB }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.Log(string(p))
+
+	return len(p), nil
+}
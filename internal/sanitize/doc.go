@@ -0,0 +1,4 @@
+// Package sanitize provides allowlist-based cleaning of parsed HTML content from
+// scripts, event handlers, unsafe URLs and any other markup which is not
+// explicitly allowed.
+package sanitize
@@ -0,0 +1,257 @@
+package sanitize
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// Policy describes which elements, attributes and URL schemes are allowed in
+// content.
+type Policy struct {
+	elements   map[string]struct{}
+	attributes map[string]map[string]struct{} // by element, '*' for any
+	schemes    map[string]struct{}
+	reject     bool
+}
+
+var (
+	// DefaultElements is an allowlist of elements which used if config
+	// does not provide any.
+	DefaultElements = []string{
+		"a", "abbr", "b", "blockquote", "br", "cite", "code", "data", "dd", "del", "dl", "dt", "em",
+		"figcaption", "figure", "h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li",
+		"mark", "ol", "p", "pre", "q", "s", "samp", "small", "span", "strong", "sub", "sup", "table",
+		"tbody", "td", "tfoot", "th", "thead", "time", "tr", "u", "ul",
+	}
+
+	// DefaultAttributes is an allowlist of 'element:attribute' pairs which
+	// used if config does not provide any.
+	DefaultAttributes = []string{
+		"*:class", "*:lang", "*:title", "a:href", "a:rel", "blockquote:cite", "data:value", "del:cite",
+		"del:datetime", "img:alt", "img:height", "img:src", "img:width", "ins:cite", "ins:datetime",
+		"li:value", "ol:reversed", "ol:start", "q:cite", "td:align", "th:align", "time:datetime",
+	}
+
+	ErrDisallowed error = domain.Error{
+		Description: "content contains disallowed markup",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+)
+
+// dropped contains elements which removed with all their contents instead of
+// unwrapping, because their contents is not a text for reading.
+var dropped = map[atom.Atom]struct{}{
+	atom.Applet: {}, atom.Embed: {}, atom.Frame: {}, atom.Frameset: {}, atom.Iframe: {}, atom.Math: {},
+	atom.Noembed: {}, atom.Noframes: {}, atom.Noscript: {}, atom.Object: {}, atom.Script: {},
+	atom.Select: {}, atom.Style: {}, atom.Svg: {}, atom.Template: {}, atom.Textarea: {}, atom.Title: {},
+}
+
+// urlAttributes contains attributes which values are URLs.
+var urlAttributes = map[string]struct{}{
+	"action": {}, "background": {}, "cite": {}, "formaction": {}, "href": {}, "longdesc": {}, "poster": {},
+	"src": {}, "usemap": {}, "xlink:href": {},
+}
+
+// NewPolicy creates a new sanitising policy from provided allowlists.
+func NewPolicy(config domain.ConfigSanitize) *Policy {
+	elements, attributes := config.Elements, config.Attributes
+	if len(elements) == 0 {
+		elements = DefaultElements
+	}
+
+	if len(attributes) == 0 {
+		attributes = DefaultAttributes
+	}
+
+	p := &Policy{
+		elements:   make(map[string]struct{}, len(elements)),
+		attributes: make(map[string]map[string]struct{}),
+		schemes:    make(map[string]struct{}, len(config.Schemes)),
+		reject:     config.Reject,
+	}
+
+	for _, element := range elements {
+		p.elements[strings.ToLower(strings.TrimSpace(element))] = struct{}{}
+	}
+
+	for _, pair := range attributes {
+		element, attribute, ok := strings.Cut(strings.ToLower(strings.TrimSpace(pair)), ":")
+		if !ok {
+			element, attribute = "*", element
+		}
+
+		if _, ok := p.attributes[element]; !ok {
+			p.attributes[element] = make(map[string]struct{})
+		}
+
+		p.attributes[element][attribute] = struct{}{}
+	}
+
+	for _, scheme := range config.Schemes {
+		p.schemes[strings.ToLower(strings.TrimSpace(scheme))] = struct{}{}
+	}
+
+	return p
+}
+
+// Sanitize removes elements, attributes and URLs which are not allowed by
+// policy from n in place. Disallowed elements are unwrapped, except scripts,
+// styles, frames and similar which are removed with their contents. Comments
+// are always removed.
+//
+// If policy rejects disallowed markup, n stays untouched and ErrDisallowed is
+// returned instead.
+func (p *Policy) Sanitize(n *html.Node) error {
+	if p.reject {
+		if reason := p.check(n); reason != "" {
+			return fmt.Errorf("%w: %s", ErrDisallowed, reason)
+		}
+
+		return nil
+	}
+
+	p.clean(n)
+
+	return nil
+}
+
+// check returns description of the first disallowed markup in n, or empty
+// string if there is none.
+func (p *Policy) check(n *html.Node) string {
+	switch n.Type {
+	case html.CommentNode:
+		return "comment"
+	case html.ElementNode:
+		if isWrapper(n) {
+			if len(n.Attr) > 0 {
+				return "attribute " + n.Attr[0].Key + " of <" + n.Data + ">"
+			}
+
+			break
+		}
+
+		if !p.allowElement(n) {
+			return "element <" + n.Data + ">"
+		}
+
+		for _, attr := range n.Attr {
+			if !p.allowAttribute(n, attr) {
+				return "attribute " + attr.Key + " of <" + n.Data + ">"
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if reason := p.check(c); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
+
+func (p *Policy) clean(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+
+		switch c.Type {
+		case html.CommentNode:
+			n.RemoveChild(c)
+		case html.ElementNode:
+			p.clean(c)
+
+			switch {
+			case isWrapper(c):
+				c.Attr = nil
+			case !p.allowElement(c):
+				if _, ok := dropped[c.DataAtom]; !ok {
+					// NOTE(toby3d): keep readable contents of
+					// unwrapped element in place.
+					for gc := c.FirstChild; gc != nil; gc = c.FirstChild {
+						c.RemoveChild(gc)
+						n.InsertBefore(gc, c)
+					}
+				}
+
+				n.RemoveChild(c)
+			default:
+				attrs := c.Attr[:0]
+
+				for _, attr := range c.Attr {
+					if p.allowAttribute(c, attr) {
+						attrs = append(attrs, attr)
+					}
+				}
+
+				c.Attr = attrs
+			}
+		}
+
+		c = next
+	}
+}
+
+func (p *Policy) allowElement(n *html.Node) bool {
+	if _, ok := dropped[n.DataAtom]; ok || n.Namespace != "" {
+		return false
+	}
+
+	_, ok := p.elements[n.Data]
+
+	return ok
+}
+
+func (p *Policy) allowAttribute(n *html.Node, attr html.Attribute) bool {
+	key := attr.Key
+	if attr.Namespace != "" {
+		key = attr.Namespace + ":" + key
+	}
+
+	_, ok := p.attributes["*"][key]
+	if !ok {
+		_, ok = p.attributes[n.Data][key]
+	}
+
+	if !ok {
+		return false
+	}
+
+	if _, isURL := urlAttributes[key]; isURL {
+		return p.allowURL(attr.Val)
+	}
+
+	return true
+}
+
+// allowURL reports whether raw is a relative URL or an absolute URL with
+// allowed scheme.
+func (p *Policy) allowURL(raw string) bool {
+	// NOTE(toby3d): parser fails on control characters, which browsers
+	// ignores inside URLs, so 'java\tscript:' is rejected too.
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		return true
+	}
+
+	_, ok := p.schemes[strings.ToLower(u.Scheme)]
+
+	return ok
+}
+
+// isWrapper reports whether n is a document element added by HTML parser.
+func isWrapper(n *html.Node) bool {
+	return n.DataAtom == atom.Html || n.DataAtom == atom.Head || n.DataAtom == atom.Body
+}
@@ -0,0 +1,149 @@
+package sanitize_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/sanitize"
+)
+
+func TestPolicy_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		input  string
+		expect string
+	}{
+		"allowed": {
+			input:  `<p class="note">Hello, <a href="https://example.com/" title="x">World</a>!</p>`,
+			expect: `<p class="note">Hello, <a href="https://example.com/" title="x">World</a>!</p>`,
+		},
+		"script": {
+			input:  `<p>Hello</p><script>alert(1)</script><style>p{}</style>`,
+			expect: `<p>Hello</p>`,
+		},
+		"handlers": {
+			input:  `<p onclick="alert(1)">Hello <img src="/a.jpg" onerror="alert(1)" alt="a"/></p>`,
+			expect: `<p>Hello <img src="/a.jpg" alt="a"/></p>`,
+		},
+		"javascript": {
+			input:  `<a href="javascript:alert(1)">a</a><a href=" JavaScript:alert(1)">b</a><a href="java&#9;script:x">c</a>`,
+			expect: `<a>a</a><a>b</a><a>c</a>`,
+		},
+		"relative": {
+			input:  `<a href="/notes/1?a=b#c">a</a><a href="mailto:me@example.com">b</a>`,
+			expect: `<a href="/notes/1?a=b#c">a</a><a href="mailto:me@example.com">b</a>`,
+		},
+		"unwrap": {
+			input:  `<div><font color="red">Hello</font> <em>World</em></div><!-- comment -->`,
+			expect: `Hello <em>World</em>`,
+		},
+		"frames": {
+			input:  `<iframe src="https://example.com/"><p>fallback</p></iframe><svg><a href="/">x</a></svg>`,
+			expect: ``,
+		},
+		"body": {
+			input:  `<body onload="alert(1)">Hello</body>`,
+			expect: `Hello`,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			node, err := html.Parse(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := domain.TestConfig(t).Sanitize
+			if err = sanitize.NewPolicy(config).Sanitize(node); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(render(t, node), tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_Sanitize_Reject(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t).Sanitize
+	config.Reject = true
+	policy := sanitize.NewPolicy(config)
+
+	for name, tc := range map[string]struct {
+		input  string
+		expect error
+	}{
+		"allowed": {input: `<p><a href="https://example.com/">Hello</a></p>`, expect: nil},
+		"script":  {input: `<p>Hello</p><script>alert(1)</script>`, expect: sanitize.ErrDisallowed},
+		"handler": {input: `<p onclick="alert(1)">Hello</p>`, expect: sanitize.ErrDisallowed},
+		"scheme":  {input: `<a href="data:text/html,x">Hello</a>`, expect: sanitize.ErrDisallowed},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			node, err := html.Parse(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before := render(t, node)
+
+			if err = policy.Sanitize(node); !errors.Is(err, tc.expect) {
+				t.Errorf("Sanitize(%s) = %v, want %v", tc.input, err, tc.expect)
+			}
+
+			if after := render(t, node); after != before {
+				t.Errorf("Sanitize(%s) changes rejected content to %s", tc.input, after)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	node, err := html.Parse(strings.NewReader(`<table><tr><td align="left">a</td></tr></table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE(toby3d): empty allowlists means defaults.
+	if err = sanitize.NewPolicy(domain.ConfigSanitize{}).Sanitize(node); err != nil {
+		t.Fatal(err)
+	}
+
+	const expect = `<table><tbody><tr><td align="left">a</td></tr></tbody></table>`
+	if out := render(t, node); out != expect {
+		t.Errorf("got '%s', want '%s'", out, expect)
+	}
+}
+
+func render(tb testing.TB, n *html.Node) string {
+	tb.Helper()
+
+	buf := bytes.NewBuffer(nil)
+	if err := html.Render(buf, n); err != nil {
+		tb.Fatal(err)
+	}
+
+	out := buf.String()
+
+	// NOTE(toby3d): trim '<html><head></head><body>' prefix and
+	// '</body></html>' suffix
+	return out[25 : len(out)-14]
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"source.toby3d.me/toby3d/pub/internal/search/stemmer"
+)
+
+var stopWords = map[string]struct{}{
+	// english
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "but": {}, "by": {}, "for": {},
+	"if": {}, "in": {}, "into": {}, "is": {}, "it": {}, "no": {}, "not": {}, "of": {}, "on": {}, "or": {},
+	"such": {}, "that": {}, "the": {}, "their": {}, "then": {}, "there": {}, "these": {}, "they": {},
+	"this": {}, "to": {}, "was": {}, "will": {}, "with": {},
+	// russian
+	"а": {}, "без": {}, "бы": {}, "в": {}, "во": {}, "вот": {}, "все": {}, "да": {}, "для": {}, "до": {},
+	"же": {}, "за": {}, "и": {}, "из": {}, "или": {}, "к": {}, "как": {}, "ко": {}, "ли": {}, "на": {},
+	"не": {}, "но": {}, "о": {}, "об": {}, "от": {}, "по": {}, "при": {}, "с": {}, "со": {}, "так": {},
+	"то": {}, "у": {}, "что": {}, "это": {},
+}
+
+// Terms splits text into words and returns their stems in order of
+// appearance, without stop words. Words in cyrillic are stemmed as russian,
+// in latin as english, other words are only lowercased.
+func Terms(text string) []string {
+	out := make([]string, 0)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\'' && r != '’'
+	}) {
+		if word = strings.Trim(strings.ReplaceAll(word, "’", "'"), "'"); word == "" {
+			continue
+		}
+
+		if _, ok := stopWords[word]; ok {
+			continue
+		}
+
+		switch {
+		case isScript(word, unicode.Cyrillic):
+			word = stemmer.Russian(word)
+		case isScript(word, unicode.Latin):
+			word = stemmer.English(word)
+		}
+
+		out = append(out, word)
+	}
+
+	return out
+}
+
+// isScript reports whether all letters of word belongs to provided script.
+func isScript(word string, script *unicode.RangeTable) bool {
+	for _, r := range word {
+		if unicode.IsLetter(r) && !unicode.Is(script, r) {
+			return false
+		}
+	}
+
+	return true
+}
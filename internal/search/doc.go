@@ -0,0 +1,3 @@
+// Package search provides a full-text search over entries titles, summaries,
+// text contents and tags with russian and english stemming.
+package search
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"context"
+)
+
+type (
+	// Document represents weighted terms of a single indexed entry.
+	Document map[string]float64
+
+	// Hit represents a single matched document with it's relevance score.
+	Hit struct {
+		Path  string
+		Score float64
+	}
+
+	Repository interface {
+		// Index stores terms of document on provided path, replacing
+		// the previous one.
+		Index(ctx context.Context, p string, doc Document) error
+
+		// Remove deletes document on provided path from the index.
+		Remove(ctx context.Context, p string) error
+
+		// Search returns documents which contains all of provided
+		// terms, scored by their relevance, the most relevant first.
+		Search(ctx context.Context, terms []string) ([]Hit, error)
+	}
+
+	dummyRepository struct{}
+
+	stubRepository struct {
+		err  error
+		hits []Hit
+	}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+	// NOTE(toby3d): mockRepository is complicated. Mocking too much is bad.
+)
+
+// NewDummySearchRepository creates an empty repository to satisfy contracts.
+// It is used in tests where repository working is not important.
+func NewDummySearchRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Index(_ context.Context, _ string, _ Document) error { return nil }
+func (dummyRepository) Remove(_ context.Context, _ string) error            { return nil }
+
+func (dummyRepository) Search(_ context.Context, _ []string) ([]Hit, error) {
+	return make([]Hit, 0), nil
+}
+
+// NewStubSearchRepository creates a repository that always returns provided
+// hits. It is used in tests where some dependency on the repository is
+// required.
+func NewStubSearchRepository(hits []Hit, err error) Repository {
+	return &stubRepository{
+		hits: hits,
+		err:  err,
+	}
+}
+
+func (repo *stubRepository) Index(_ context.Context, _ string, _ Document) error { return repo.err }
+func (repo *stubRepository) Remove(_ context.Context, _ string) error            { return repo.err }
+
+func (repo *stubRepository) Search(_ context.Context, _ []string) ([]Hit, error) {
+	return repo.hits, repo.err
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/search"
+)
+
+type memorySearchRepository struct {
+	mutex     *sync.RWMutex
+	documents map[string]search.Document    // path → weighted terms
+	postings  map[string]map[string]float64 // term → path → weight
+	lengths   map[string]float64            // path → sum of weights
+	total     float64                       // sum of all documents lengths
+}
+
+// NOTE(toby3d): BM25 ranking parameters.
+const (
+	k1 float64 = 1.2
+	b  float64 = 0.75
+)
+
+// NewMemorySearchRepository creates an in-memory inverted index which ranks
+// documents by BM25.
+func NewMemorySearchRepository() search.Repository {
+	return &memorySearchRepository{
+		mutex:     new(sync.RWMutex),
+		documents: make(map[string]search.Document),
+		postings:  make(map[string]map[string]float64),
+		lengths:   make(map[string]float64),
+		total:     0,
+	}
+}
+
+func (repo *memorySearchRepository) Index(_ context.Context, p string, doc search.Document) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.remove(p)
+
+	if len(doc) == 0 {
+		return nil
+	}
+
+	repo.documents[p] = doc
+
+	for term, weight := range doc {
+		if repo.postings[term] == nil {
+			repo.postings[term] = make(map[string]float64)
+		}
+
+		repo.postings[term][p] = weight
+		repo.lengths[p] += weight
+	}
+
+	repo.total += repo.lengths[p]
+
+	return nil
+}
+
+func (repo *memorySearchRepository) Remove(_ context.Context, p string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.remove(p)
+
+	return nil
+}
+
+func (repo *memorySearchRepository) Search(_ context.Context, terms []string) ([]search.Hit, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := make([]search.Hit, 0)
+	if len(terms) == 0 || len(repo.documents) == 0 {
+		return out, nil
+	}
+
+	// NOTE(toby3d): start from the rarest term, so fewer documents are
+	// checked against the rest.
+	terms = append(make([]string, 0, len(terms)), terms...)
+	sort.Slice(terms, func(i, j int) bool { return len(repo.postings[terms[i]]) < len(repo.postings[terms[j]]) })
+
+	count := float64(len(repo.documents))
+	average := repo.total / count
+
+	for p := range repo.postings[terms[0]] {
+		score := 0.0
+
+		for _, term := range terms {
+			weight, ok := repo.postings[term][p]
+			if !ok {
+				score = -1
+
+				break
+			}
+
+			frequency := float64(len(repo.postings[term]))
+			idf := math.Log(1 + (count-frequency+0.5)/(frequency+0.5))
+			score += idf * weight * (k1 + 1) / (weight + k1*(1-b+b*repo.lengths[p]/average))
+		}
+
+		if score < 0 {
+			continue
+		}
+
+		out = append(out, search.Hit{Path: p, Score: score})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+
+		return out[i].Path < out[j].Path
+	})
+
+	return out, nil
+}
+
+// remove deletes document on path p from the index. Caller MUST hold the
+// write lock.
+func (repo *memorySearchRepository) remove(p string) {
+	doc, ok := repo.documents[p]
+	if !ok {
+		return
+	}
+
+	for term := range doc {
+		if delete(repo.postings[term], p); len(repo.postings[term]) == 0 {
+			delete(repo.postings, term)
+		}
+	}
+
+	repo.total -= repo.lengths[p]
+
+	delete(repo.lengths, p)
+	delete(repo.documents, p)
+}
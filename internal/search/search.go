@@ -0,0 +1,77 @@
+package search
+
+import (
+	"context"
+	"log"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+)
+
+// NOTE(toby3d): a single occurrence of term weights more in title and tags
+// than in text content.
+const (
+	WeightTitle   float64 = 3
+	WeightTag     float64 = 2
+	WeightSummary float64 = 1.5
+	WeightContent float64 = 1
+)
+
+// NewDocument returns weighted terms of entry title, summary, text content and
+// tags.
+func NewDocument(e domain.Entry) Document {
+	out := make(Document)
+
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{e.Title, WeightTitle},
+		{e.Description, WeightSummary},
+		{e.Content.PlainText(), WeightContent},
+	} {
+		for _, term := range Terms(field.text) {
+			out[term] += field.weight
+		}
+	}
+
+	for _, tag := range e.Tags {
+		for _, term := range Terms(tag) {
+			out[term] += WeightTag
+		}
+	}
+
+	return out
+}
+
+// NewHook creates an entry hook which keeps index of provided use case up to
+// date: created, updated and undeleted entries are indexed, deleted entries
+// are removed from index.
+func NewHook(ucase UseCase, logger *log.Logger) entry.Hook {
+	return entry.HookFunc(func(ctx context.Context, _ domain.Action, before, after *domain.Entry) {
+		// NOTE(toby3d): index errors must not fail already stored
+		// changes, search results just become stale.
+		if before != nil && before.URL != nil && (after == nil || after.URL == nil ||
+			after.URL.RequestURI() != before.URL.RequestURI()) {
+			if err := ucase.Remove(ctx, before.URL); err != nil {
+				logger.Printf("cannot remove %s from search index: %s", before.URL, err)
+			}
+		}
+
+		if after == nil || after.URL == nil {
+			return
+		}
+
+		if !after.DeletedAt.IsZero() {
+			if err := ucase.Remove(ctx, after.URL); err != nil {
+				logger.Printf("cannot remove %s from search index: %s", after.URL, err)
+			}
+
+			return
+		}
+
+		if err := ucase.Index(ctx, *after); err != nil {
+			logger.Printf("cannot index %s: %s", after.URL, err)
+		}
+	})
+}
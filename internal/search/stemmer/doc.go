@@ -0,0 +1,3 @@
+// Package stemmer provides a snowball stemming algorithms of supported
+// languages, which reduces inflected words to their word stem.
+package stemmer
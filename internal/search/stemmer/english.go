@@ -0,0 +1,299 @@
+package stemmer
+
+import "strings"
+
+var englishExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	// NOTE(toby3d): invariant forms.
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// NOTE(toby3d): words which stays invariant after step 1a.
+var englishExceptions1a = map[string]struct{}{
+	"inning": {}, "outing": {}, "canning": {}, "herring": {}, "earring": {}, "proceed": {}, "exceed": {},
+	"succeed": {},
+}
+
+var englishStep2 = []struct {
+	suffix, replace string
+}{
+	{"ization", "ize"}, {"ational", "ate"}, {"fulness", "ful"}, {"ousness", "ous"}, {"iveness", "ive"},
+	{"tional", "tion"}, {"biliti", "ble"}, {"lessli", "less"}, {"entli", "ent"}, {"ation", "ate"},
+	{"alism", "al"}, {"aliti", "al"}, {"ousli", "ous"}, {"iviti", "ive"}, {"fulli", "ful"}, {"enci", "ence"},
+	{"anci", "ance"}, {"abli", "able"}, {"izer", "ize"}, {"ator", "ate"}, {"alli", "al"}, {"bli", "ble"},
+	{"ogi", "og"}, {"li", ""},
+}
+
+var englishStep3 = []struct {
+	suffix, replace string
+}{
+	{"ational", "ate"}, {"tional", "tion"}, {"alize", "al"}, {"icate", "ic"}, {"iciti", "ic"}, {"ative", ""},
+	{"ical", "ic"}, {"ness", ""}, {"ful", ""},
+}
+
+var englishStep4 = []string{
+	"ement", "ance", "ence", "able", "ible", "ment", "ant", "ent", "ism", "ate", "iti", "ous", "ive", "ize",
+	"ion", "al", "er", "ic",
+}
+
+// English returns a stem of lowercased english word by Porter2 (snowball)
+// algorithm.
+//
+// See: https://snowballstem.org/algorithms/english/stemmer.html
+func English(word string) string {
+	if out, ok := englishExceptions[word]; ok {
+		return out
+	}
+
+	if len(word) <= 2 {
+		return word
+	}
+
+	w := []byte(strings.TrimPrefix(word, "'"))
+	if len(w) == 0 {
+		return word
+	}
+
+	// NOTE(toby3d): consonant 'y' is marked as 'Y'.
+	for i := range w {
+		if w[i] == 'y' && (i == 0 || isEnglishVowel(w[i-1])) {
+			w[i] = 'Y'
+		}
+	}
+
+	r1, r2 := englishRegions(w)
+
+	// Step 0: remove possessive.
+	for _, suffix := range []string{"'s'", "'s", "'"} {
+		if hasSuffix(w, suffix) {
+			w = w[:len(w)-len(suffix)]
+
+			break
+		}
+	}
+
+	// Step 1a: plurals.
+	switch {
+	case hasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case hasSuffix(w, "ied"), hasSuffix(w, "ies"):
+		if len(w) > 4 {
+			w = w[:len(w)-2]
+		} else {
+			w = w[:len(w)-1]
+		}
+	case hasSuffix(w, "us"), hasSuffix(w, "ss"):
+	case hasSuffix(w, "s"):
+		if len(w) > 2 && containsEnglishVowel(w[:len(w)-2]) {
+			w = w[:len(w)-1]
+		}
+	}
+
+	if _, ok := englishExceptions1a[string(w)]; ok {
+		return string(w)
+	}
+
+	// Step 1b: past tenses and gerunds.
+	switch suffix := longestSuffix(w, "eedly", "ingly", "edly", "eed", "ing", "ed"); suffix {
+	case "":
+	case "eed", "eedly":
+		if len(w)-len(suffix) >= r1 {
+			w = append(w[:len(w)-len(suffix)], 'e', 'e')
+		}
+	default:
+		if !containsEnglishVowel(w[:len(w)-len(suffix)]) {
+			break
+		}
+
+		w = w[:len(w)-len(suffix)]
+
+		switch {
+		case hasSuffix(w, "at"), hasSuffix(w, "bl"), hasSuffix(w, "iz"):
+			w = append(w, 'e')
+		case isEnglishDouble(w):
+			w = w[:len(w)-1]
+		case isEnglishShort(w, r1):
+			w = append(w, 'e')
+		}
+	}
+
+	// Step 1c: replace suffix y by i after consonant which is not the
+	// first letter.
+	if n := len(w); n > 2 && (w[n-1] == 'y' || w[n-1] == 'Y') && !isEnglishVowel(w[n-2]) {
+		w[n-1] = 'i'
+	}
+
+	// Step 2.
+	for _, rule := range englishStep2 {
+		if !hasSuffix(w, rule.suffix) {
+			continue
+		}
+
+		start := len(w) - len(rule.suffix)
+		if start < r1 {
+			break
+		}
+
+		switch rule.suffix {
+		case "ogi":
+			if start > 0 && w[start-1] == 'l' {
+				w = append(w[:start], rule.replace...)
+			}
+		case "li":
+			if start > 0 && strings.IndexByte("cdeghkmnrt", w[start-1]) >= 0 {
+				w = w[:start]
+			}
+		default:
+			w = append(w[:start], rule.replace...)
+		}
+
+		break
+	}
+
+	// Step 3.
+	for _, rule := range englishStep3 {
+		if !hasSuffix(w, rule.suffix) {
+			continue
+		}
+
+		start := len(w) - len(rule.suffix)
+		if start < r1 || (rule.suffix == "ative" && start < r2) {
+			break
+		}
+
+		w = append(w[:start], rule.replace...)
+
+		break
+	}
+
+	// Step 4.
+	if suffix := longestSuffix(w, englishStep4...); suffix != "" {
+		if start := len(w) - len(suffix); start >= r2 {
+			switch {
+			case suffix != "ion":
+				w = w[:start]
+			case start > 0 && (w[start-1] == 's' || w[start-1] == 't'):
+				w = w[:start]
+			}
+		}
+	}
+
+	// Step 5.
+	switch n := len(w); {
+	case n > 0 && w[n-1] == 'e':
+		if n-1 >= r2 || (n-1 >= r1 && !endsWithShortSyllable(w[:n-1])) {
+			w = w[:n-1]
+		}
+	case n > 1 && w[n-1] == 'l':
+		if n-1 >= r2 && w[n-2] == 'l' {
+			w = w[:n-1]
+		}
+	}
+
+	return strings.ReplaceAll(string(w), "Y", "y")
+}
+
+// englishRegions returns starts of R1 and R2 regions of word.
+func englishRegions(w []byte) (r1, r2 int) {
+	r1 = len(w)
+
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(w), prefix) {
+			r1 = len(prefix)
+
+			break
+		}
+	}
+
+	if r1 == len(w) {
+		r1 = englishRegion(w, 0)
+	}
+
+	return r1, englishRegion(w, r1)
+}
+
+// englishRegion returns position after the first non-vowel following a vowel
+// starting from start.
+func englishRegion(w []byte, start int) int {
+	for i := start + 1; i < len(w); i++ {
+		if !isEnglishVowel(w[i]) && isEnglishVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+
+	return len(w)
+}
+
+func isEnglishVowel(b byte) bool {
+	return strings.IndexByte("aeiouy", b) >= 0
+}
+
+func containsEnglishVowel(w []byte) bool {
+	for i := range w {
+		if isEnglishVowel(w[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEnglishDouble(w []byte) bool {
+	n := len(w)
+
+	return n > 1 && w[n-1] == w[n-2] && strings.IndexByte("bdfgmnprt", w[n-1]) >= 0
+}
+
+// endsWithShortSyllable reports whether word ends with a vowel followed by a
+// non-vowel other than w, x or Y and preceded by a non-vowel, or is a vowel at
+// the beginning followed by a non-vowel.
+func endsWithShortSyllable(w []byte) bool {
+	switch n := len(w); {
+	case n == 2:
+		return isEnglishVowel(w[0]) && !isEnglishVowel(w[1])
+	case n > 2:
+		return !isEnglishVowel(w[n-3]) && isEnglishVowel(w[n-2]) && !isEnglishVowel(w[n-1]) &&
+			strings.IndexByte("wxY", w[n-1]) < 0
+	default:
+		return false
+	}
+}
+
+// isEnglishShort reports whether word ends in a short syllable and R1 is null.
+func isEnglishShort(w []byte, r1 int) bool {
+	return r1 >= len(w) && endsWithShortSyllable(w)
+}
+
+func hasSuffix(w []byte, suffix string) bool {
+	return strings.HasSuffix(string(w), suffix)
+}
+
+// longestSuffix returns the longest of provided suffixes of word, or empty
+// string if there is none of them.
+func longestSuffix(w []byte, suffixes ...string) string {
+	out := ""
+
+	for _, suffix := range suffixes {
+		if len(suffix) > len(out) && hasSuffix(w, suffix) {
+			out = suffix
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,172 @@
+package stemmer
+
+import "strings"
+
+var (
+	russianPerfectiveGerund1 = []string{"вшись", "вши", "в"} // preceded by а or я
+	russianPerfectiveGerund2 = []string{"ившись", "ывшись", "ивши", "ывши", "ив", "ыв"}
+	russianAdjective         = []string{
+		"ими", "ыми", "его", "ого", "ему", "ому", "ее", "ие", "ые", "ое", "ей", "ий", "ый", "ой", "ем", "им",
+		"ым", "ом", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+	russianParticiple1 = []string{"ем", "нн", "вш", "ющ", "щ"} // preceded by а or я
+	russianParticiple2 = []string{"ивш", "ывш", "ующ"}
+	russianReflexive   = []string{"ся", "сь"}
+	russianVerb1       = []string{
+		"ете", "йте", "ешь", "нно", "ла", "на", "ли", "ем", "ло", "но", "ет", "ют", "ны", "ть", "й", "л", "н",
+	} // preceded by а or я
+	russianVerb2 = []string{
+		"ейте", "уйте", "ила", "ыла", "ена", "ите", "или", "ыли", "ило", "ыло", "ено", "ует", "уют", "ены", "ить",
+		"ыть", "ишь", "ей", "уй", "ил", "ыл", "им", "ым", "ен", "ят", "ит", "ыт", "ую", "ю",
+	}
+	russianNoun = []string{
+		"иями", "ями", "ами", "ией", "иям", "ием", "иях", "ев", "ов", "ие", "ье", "еи", "ии", "ей", "ой", "ий",
+		"ям", "ем", "ам", "ом", "ах", "ях", "ию", "ью", "ия", "ья", "а", "е", "и", "й", "о", "у", "ы", "ь",
+		"ю", "я",
+	}
+	russianSuperlative  = []string{"ейше", "ейш"}
+	russianDerivational = []string{"ость", "ост"}
+)
+
+// Russian returns a stem of lowercased russian word by snowball algorithm.
+//
+// See: https://snowballstem.org/algorithms/russian/stemmer.html
+func Russian(word string) string {
+	w := []rune(strings.ReplaceAll(word, "ё", "е"))
+	rv, r2 := russianRegions(w)
+
+	// NOTE(toby3d): all steps works only in RV region.
+	prefix, w := w[:rv], w[rv:]
+	r2 -= rv
+
+	// Step 1.
+	if out, ok := russianRemove(w, russianPerfectiveGerund1, russianPerfectiveGerund2); ok {
+		w = out
+	} else {
+		if suffix := russianSuffix(w, russianReflexive...); suffix != "" {
+			w = w[:len(w)-len([]rune(suffix))]
+		}
+
+		if out, ok := russianRemoveAdjectival(w); ok {
+			w = out
+		} else if out, ok := russianRemove(w, russianVerb1, russianVerb2); ok {
+			w = out
+		} else if suffix := russianSuffix(w, russianNoun...); suffix != "" {
+			w = w[:len(w)-len([]rune(suffix))]
+		}
+	}
+
+	// Step 2.
+	if len(w) > 0 && w[len(w)-1] == 'и' {
+		w = w[:len(w)-1]
+	}
+
+	// Step 3.
+	if suffix := russianSuffix(w, russianDerivational...); suffix != "" && len(w)-len([]rune(suffix)) >= r2 {
+		w = w[:len(w)-len([]rune(suffix))]
+	}
+
+	// Step 4.
+	switch suffix := russianSuffix(w, append([]string{"н", "ь"}, russianSuperlative...)...); suffix {
+	case "ь":
+		w = w[:len(w)-1]
+	case "н":
+		if russianSuffix(w, "нн") != "" {
+			w = w[:len(w)-1]
+		}
+	case "":
+	default:
+		if w = w[:len(w)-len([]rune(suffix))]; russianSuffix(w, "нн") != "" {
+			w = w[:len(w)-1]
+		}
+	}
+
+	return string(prefix) + string(w)
+}
+
+// russianRegions returns starts of RV and R2 regions of word.
+func russianRegions(w []rune) (rv, r2 int) {
+	rv, r2 = len(w), len(w)
+
+	for i := range w {
+		if isRussianVowel(w[i]) {
+			rv = i + 1
+
+			break
+		}
+	}
+
+	r1 := russianRegion(w, 0)
+
+	return rv, russianRegion(w, r1)
+}
+
+// russianRegion returns position after the first non-vowel following a vowel
+// starting from start.
+func russianRegion(w []rune, start int) int {
+	for i := start + 1; i < len(w); i++ {
+		if !isRussianVowel(w[i]) && isRussianVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+
+	return len(w)
+}
+
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
+
+// russianSuffix returns the longest of provided suffixes of word, or empty
+// string if there is none of them.
+func russianSuffix(w []rune, suffixes ...string) string {
+	out := ""
+
+	for _, suffix := range suffixes {
+		if len(suffix) > len(out) && strings.HasSuffix(string(w), suffix) {
+			out = suffix
+		}
+	}
+
+	return out
+}
+
+// russianRemove removes the longest suffix of both groups. Suffixes of the
+// first group are removed only if they are preceded by а or я.
+func russianRemove(w []rune, group1, group2 []string) ([]rune, bool) {
+	suffix := russianSuffix(w, append(append(make([]string, 0, len(group1)+len(group2)), group1...), group2...)...)
+	if suffix == "" {
+		return w, false
+	}
+
+	start := len(w) - len([]rune(suffix))
+
+	for _, s := range group2 {
+		if s == suffix {
+			return w[:start], true
+		}
+	}
+
+	if start > 0 && (w[start-1] == 'а' || w[start-1] == 'я') {
+		return w[:start], true
+	}
+
+	return w, false
+}
+
+// russianRemoveAdjectival removes adjective ending with optional participle
+// before it.
+func russianRemoveAdjectival(w []rune) ([]rune, bool) {
+	suffix := russianSuffix(w, russianAdjective...)
+	if suffix == "" {
+		return w, false
+	}
+
+	w = w[:len(w)-len([]rune(suffix))]
+
+	if out, ok := russianRemove(w, russianParticiple1, russianParticiple2); ok {
+		return out, true
+	}
+
+	return w, true
+}
@@ -0,0 +1,74 @@
+package stemmer_test
+
+import (
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/search/stemmer"
+)
+
+func TestEnglish(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[string]string{
+		"abilities":      "abil",
+		"agreed":         "agre",
+		"caresses":       "caress",
+		"cats":           "cat",
+		"communication":  "communic",
+		"consigning":     "consign",
+		"cried":          "cri",
+		"electrical":     "electr",
+		"gas":            "gas",
+		"generalization": "general",
+		"generously":     "generous",
+		"happy":          "happi",
+		"hopeful":        "hope",
+		"hopping":        "hop",
+		"news":           "news",
+		"ponies":         "poni",
+		"relational":     "relat",
+		"running":        "run",
+		"skies":          "sky",
+		"ties":           "tie",
+	} {
+		input, expect := input, expect
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := stemmer.English(input); actual != expect {
+				t.Errorf("English(%s) = %s, want %s", input, actual, expect)
+			}
+		})
+	}
+}
+
+func TestRussian(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[string]string{
+		"важнейшие":        "важн",
+		"городов":          "город",
+		"делать":           "дела",
+		"ёжик":             "ежик",
+		"злость":           "злост",
+		"исследования":     "исследован",
+		"каменный":         "камен",
+		"книги":            "книг",
+		"красивая":         "красив",
+		"новостей":         "новост",
+		"программирование": "программирован",
+		"прочитавши":       "прочита",
+		"улыбнулся":        "улыбнул",
+	} {
+		input, expect := input, expect
+
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := stemmer.Russian(input); actual != expect {
+				t.Errorf("Russian(%s) = %s, want %s", input, actual, expect)
+			}
+		})
+	}
+}
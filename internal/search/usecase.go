@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	// Query describes a search request.
+	Query struct {
+		Term      string // user input, analyzed into terms
+		Limit     int    // zero means no limit
+		Offset    int
+		Published bool // only publicly listed entries
+	}
+
+	// Result represents a single page of found entries, the most relevant
+	// and recent first.
+	Result struct {
+		Entries []domain.Entry
+		Total   int // number of found entries on all pages
+	}
+
+	UseCase interface {
+		// Index adds or replaces entry in the search index.
+		Index(ctx context.Context, e domain.Entry) error
+
+		// Remove deletes entry on provided URL from the search index.
+		Remove(ctx context.Context, u *url.URL) error
+
+		// Search returns entries which contains all words of query
+		// term, ranked by relevance and recency.
+		Search(ctx context.Context, query Query) (*Result, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		err     error
+		entries []domain.Entry
+	}
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Index(_ context.Context, _ domain.Entry) error { return nil }
+func (dummyUseCase) Remove(_ context.Context, _ *url.URL) error    { return nil }
+
+func (dummyUseCase) Search(_ context.Context, _ Query) (*Result, error) {
+	return &Result{Entries: make([]domain.Entry, 0), Total: 0}, nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(entries []domain.Entry, err error) UseCase {
+	return &stubUseCase{
+		entries: entries,
+		err:     err,
+	}
+}
+
+func (ucase *stubUseCase) Index(_ context.Context, _ domain.Entry) error { return ucase.err }
+func (ucase *stubUseCase) Remove(_ context.Context, _ *url.URL) error    { return ucase.err }
+
+func (ucase *stubUseCase) Search(_ context.Context, _ Query) (*Result, error) {
+	return &Result{Entries: ucase.entries, Total: len(ucase.entries)}, ucase.err
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/search"
+)
+
+type searchUseCase struct {
+	index   search.Repository
+	entries entry.Repository
+}
+
+// HalfLife is an age of entry on which it's recency bonus is halved. Fresh
+// entries scores up to twice as relevant as the old ones.
+const HalfLife time.Duration = 365 * 24 * time.Hour
+
+func NewSearchUseCase(index search.Repository, entries entry.Repository) search.UseCase {
+	return &searchUseCase{
+		index:   index,
+		entries: entries,
+	}
+}
+
+// Index implements search.UseCase.
+func (ucase *searchUseCase) Index(ctx context.Context, e domain.Entry) error {
+	if err := ucase.index.Index(ctx, e.URL.RequestURI(), search.NewDocument(e)); err != nil {
+		return fmt.Errorf("cannot index entry: %w", err)
+	}
+
+	return nil
+}
+
+// Remove implements search.UseCase.
+func (ucase *searchUseCase) Remove(ctx context.Context, u *url.URL) error {
+	if err := ucase.index.Remove(ctx, u.RequestURI()); err != nil {
+		return fmt.Errorf("cannot remove entry from index: %w", err)
+	}
+
+	return nil
+}
+
+// Search implements search.UseCase.
+func (ucase *searchUseCase) Search(ctx context.Context, query search.Query) (*search.Result, error) {
+	out := &search.Result{Entries: make([]domain.Entry, 0)}
+
+	terms := make([]string, 0)
+	unique := make(map[string]struct{})
+
+	for _, term := range search.Terms(query.Term) {
+		if _, ok := unique[term]; !ok {
+			unique[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+
+	if len(terms) == 0 {
+		return out, nil
+	}
+
+	hits, err := ucase.index.Search(ctx, terms)
+	if err != nil {
+		return nil, fmt.Errorf("cannot search entries: %w", err)
+	}
+
+	now := time.Now().UTC()
+	scores := make([]float64, 0, len(hits))
+
+	for _, hit := range hits {
+		e, err := ucase.entries.Get(ctx, hit.Path)
+		if err != nil {
+			if errors.Is(err, entry.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("cannot search entries: %w", err)
+		}
+
+		if !e.DeletedAt.IsZero() || (query.Published && !e.IsPublished()) {
+			continue
+		}
+
+		out.Entries = append(out.Entries, *e)
+		scores = append(scores, hit.Score*(1+Recency(e.Date(), now)))
+	}
+
+	sort.Stable(byScore{entries: out.Entries, scores: scores})
+
+	out.Total = len(out.Entries)
+
+	if query.Offset > 0 {
+		if query.Offset > len(out.Entries) {
+			query.Offset = len(out.Entries)
+		}
+
+		out.Entries = out.Entries[query.Offset:]
+	}
+
+	if query.Limit > 0 && len(out.Entries) > query.Limit {
+		out.Entries = out.Entries[:query.Limit]
+	}
+
+	return out, nil
+}
+
+// Recency returns a bonus of entry published at t, from 1 for just published
+// entries down to 0 for the oldest ones.
+func Recency(t, now time.Time) float64 {
+	age := now.Sub(t)
+	if age < 0 {
+		age = 0
+	}
+
+	return math.Pow(0.5, float64(age)/float64(HalfLife))
+}
+
+type byScore struct {
+	entries []domain.Entry
+	scores  []float64
+}
+
+func (s byScore) Len() int { return len(s.entries) }
+
+func (s byScore) Less(i, j int) bool {
+	if s.scores[i] != s.scores[j] {
+		return s.scores[i] > s.scores[j]
+	}
+
+	return s.entries[i].Date().After(s.entries[j].Date())
+}
+
+func (s byScore) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
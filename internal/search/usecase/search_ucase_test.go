@@ -0,0 +1,145 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	searchmemoryrepo "source.toby3d.me/toby3d/pub/internal/search/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/search/usecase"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	ucase := usecase.NewSearchUseCase(searchmemoryrepo.NewMemorySearchRepository(), entries)
+	hook := search.NewHook(ucase, log.New(io.Discard, "", 0))
+
+	for p, e := range map[string]*domain.Entry{
+		"title": {Title: "Running notes", Content: domain.Content{Text: "About my morning runs."}},
+		"old": {
+			Content:     domain.Content{Text: "I was running late today."},
+			PublishedAt: now.AddDate(-3, 0, 0),
+		},
+		"new": {
+			Content:     domain.Content{Text: "Ran and ran, running again."},
+			PublishedAt: now.AddDate(0, 0, -1),
+		},
+		"russian": {Content: domain.Content{Text: "Новые заметки о поиске"}, Tags: []string{"Golang"}},
+		"draft":   {Content: domain.Content{Text: "Черновик заметки"}, Status: domain.PostStatusDraft},
+		"deleted": {Content: domain.Content{Text: "Удалённая заметка"}},
+	} {
+		e.URL = &url.URL{Path: "/notes/" + p}
+		if e.PublishedAt.IsZero() {
+			e.PublishedAt = now
+		}
+
+		if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+			t.Fatal(err)
+		}
+
+		hook.Handle(context.Background(), domain.ActionCreate, nil, e)
+	}
+
+	deleted, err := entries.Get(context.Background(), "/notes/deleted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := *deleted
+	deleted.DeletedAt = now
+	hook.Handle(context.Background(), domain.ActionDelete, &before, deleted)
+
+	paths := func(result *search.Result) []string {
+		out := make([]string, 0, len(result.Entries))
+		for i := range result.Entries {
+			out = append(out, result.Entries[i].URL.Path)
+		}
+
+		return out
+	}
+
+	for name, tc := range map[string]struct {
+		query  search.Query
+		expect []string
+		total  int
+	}{
+		"relevance and recency": {
+			query:  search.Query{Term: "run"},
+			expect: []string{"/notes/title", "/notes/new", "/notes/old"},
+			total:  3,
+		},
+		"limit": {
+			query:  search.Query{Term: "running", Limit: 1, Offset: 1},
+			expect: []string{"/notes/new"},
+			total:  3,
+		},
+		"all terms": {
+			query:  search.Query{Term: "running late"},
+			expect: []string{"/notes/old"},
+			total:  1,
+		},
+		"russian": {
+			query:  search.Query{Term: "заметка"},
+			expect: []string{"/notes/draft", "/notes/russian"},
+			total:  2,
+		},
+		"published": {
+			query:  search.Query{Term: "заметка", Published: true},
+			expect: []string{"/notes/russian"},
+			total:  1,
+		},
+		"tag": {
+			query:  search.Query{Term: "golang"},
+			expect: []string{"/notes/russian"},
+			total:  1,
+		},
+		"stop words": {
+			query:  search.Query{Term: "the"},
+			expect: []string{},
+			total:  0,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ucase.Search(context.Background(), tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(paths(result), tc.expect); diff != "" || result.Total != tc.total {
+				t.Errorf("%s, total: %d, want %d", diff, result.Total, tc.total)
+			}
+		})
+	}
+}
+
+func TestNewHook_Error(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	e := domain.TestEntry(t)
+
+	search.NewHook(search.NewStubUseCase(nil, errors.New("index is broken")), log.New(buf, "", 0)).
+		Handle(context.Background(), domain.ActionCreate, nil, e)
+
+	if !strings.Contains(buf.String(), "index is broken") {
+		t.Errorf("expect logged index error, got %q", buf.String())
+	}
+}
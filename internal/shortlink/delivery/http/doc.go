@@ -0,0 +1,4 @@
+// Package provides a short URLs HTTP endpoint.
+//
+// Each short URL permanently redirects to the current permalink of entry.
+package http
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+)
+
+type Handler struct {
+	shortlinks shortlink.UseCase
+	config     domain.Config
+}
+
+func NewHandler(shortlinks shortlink.UseCase, config domain.Config) *Handler {
+	return &Handler{
+		shortlinks: shortlinks,
+		config:     config,
+	}
+}
+
+// ServeHTTP redirects short URL to the permalink of entry.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	code := shortlink.Code(h.config, r)
+	if code == "" {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	out, err := h.shortlinks.Resolve(r.Context(), code)
+	if err != nil {
+		if errors.Is(err, shortlink.ErrNotExist) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	http.Redirect(w, r, h.config.HTTP.BaseURL().ResolveReference(out.URL).String(), http.StatusMovedPermanently)
+}
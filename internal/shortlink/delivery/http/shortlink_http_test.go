@@ -0,0 +1,75 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+	delivery "source.toby3d.me/toby3d/pub/internal/shortlink/delivery/http"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+
+	for name, tc := range map[string]struct {
+		shortlinks shortlink.UseCase
+		method     string
+		target     string
+		location   string
+		expect     int
+	}{
+		"redirect": {
+			shortlinks: shortlink.NewStubUseCase(&domain.Shortlink{
+				URL:  &url.URL{Path: "/samples/lipsum"},
+				Code: "5Ne1",
+			}, nil),
+			method:   http.MethodGet,
+			target:   "https://example.com/s/5Ne1",
+			location: "https://example.com/samples/lipsum",
+			expect:   http.StatusMovedPermanently,
+		},
+		"unknown": {
+			shortlinks: shortlink.NewDummyUseCase(),
+			method:     http.MethodGet,
+			target:     "https://example.com/s/5Ne2",
+			expect:     http.StatusNotFound,
+		},
+		"empty": {
+			shortlinks: shortlink.NewDummyUseCase(),
+			method:     http.MethodGet,
+			target:     "https://example.com/s/",
+			expect:     http.StatusNotFound,
+		},
+		"method": {
+			shortlinks: shortlink.NewDummyUseCase(),
+			method:     http.MethodPost,
+			target:     "https://example.com/s/5Ne1",
+			expect:     http.StatusMethodNotAllowed,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			w := httptest.NewRecorder()
+			delivery.NewHandler(tc.shortlinks, *config).ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tc.expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+
+			if location := resp.Header.Get(common.HeaderLocation); location != tc.location {
+				t.Errorf("expect Location '%s', got '%s'", tc.location, location)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Package shortlink provides short permalinks of entries for character-limited
+// silos: compact NewBase60 codes of publishing date with ordinal number within
+// it or sequential ones, served on short domain or prefix with redirects to
+// the full permalinks.
+//
+// See: https://tantek.com/2011/010/t1/whistle-algorithmic-shortener
+// See: https://microformats.org/wiki/rel-shortlink
+package shortlink
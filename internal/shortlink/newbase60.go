@@ -0,0 +1,47 @@
+package shortlink
+
+import (
+	"strings"
+	"time"
+)
+
+// alphabet of NewBase60 digits without visually ambiguous 'I', 'O' and 'l'.
+const alphabet string = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ_abcdefghijkmnopqrstuvwxyz"
+
+// dateWidth is a number of digits of encoded days, enough for dates until
+// year 2561.
+const dateWidth int = 3
+
+// FormatNewBase60 returns NewBase60 representation of n.
+func FormatNewBase60(n uint64) string {
+	if n == 0 {
+		return alphabet[:1]
+	}
+
+	out := make([]byte, 0, 11)
+	for ; n > 0; n /= 60 {
+		out = append(out, alphabet[n%60])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+// FormatDate returns fixed-width NewBase60 representation of days between
+// Unix epoch and t, so codes of the same day share the same prefix.
+func FormatDate(t time.Time) string {
+	days := t.UTC().Unix() / int64(24*time.Hour/time.Second)
+	if days < 0 {
+		days = 0
+	}
+
+	out := FormatNewBase60(uint64(days))
+	if len(out) < dateWidth {
+		out = strings.Repeat(alphabet[:1], dateWidth-len(out)) + out
+	}
+
+	return out
+}
@@ -0,0 +1,43 @@
+package shortlink_test
+
+import (
+	"testing"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+)
+
+func TestFormatNewBase60(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[uint64]string{
+		0:      "0",
+		1:      "1",
+		10:     "A",
+		18:     "J",
+		34:     "_",
+		59:     "z",
+		60:     "10",
+		3599:   "zz",
+		216000: "1000",
+	} {
+		if out := shortlink.FormatNewBase60(input); out != expect {
+			t.Errorf("FormatNewBase60(%d) = %s, want %s", input, out, expect)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[time.Time]string{
+		time.Unix(0, 0): "000",
+		time.Date(1970, time.January, 2, 23, 59, 59, 0, time.UTC): "001",
+		time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC):    "43W",
+		time.Date(2023, time.January, 2, 15, 4, 5, 0, time.UTC):   "5Ne",
+	} {
+		if out := shortlink.FormatDate(input); out != expect {
+			t.Errorf("FormatDate(%s) = %s, want %s", input, out, expect)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package shortlink
+
+import (
+	"context"
+	"errors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	Repository interface {
+		// Create stores shortlink on provided code. Returns ErrExist if
+		// code is already used.
+		Create(ctx context.Context, code string, s domain.Shortlink) error
+
+		// Get returns shortlink stored on provided code.
+		Get(ctx context.Context, code string) (*domain.Shortlink, error)
+
+		// Find returns shortlink of entry on provided path.
+		Find(ctx context.Context, path string) (*domain.Shortlink, error)
+
+		// Count returns number of codes which starts with provided
+		// prefix.
+		Count(ctx context.Context, prefix string) (int, error)
+
+		// Update replaces shortlink stored on provided code.
+		Update(ctx context.Context, code string, s domain.Shortlink) error
+	}
+
+	dummyRepository struct{}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+)
+
+var (
+	ErrExist    error = errors.New("this short code already used")
+	ErrNotExist error = errors.New("this short code is not exist")
+)
+
+// NewDummyShortlinkRepository creates an empty repository to satisfy
+// contracts. It is used in tests where repository working is not important.
+func NewDummyShortlinkRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, _ domain.Shortlink) error { return nil }
+func (dummyRepository) Update(_ context.Context, _ string, _ domain.Shortlink) error { return nil }
+func (dummyRepository) Count(_ context.Context, _ string) (int, error)               { return 0, nil }
+
+func (dummyRepository) Get(_ context.Context, _ string) (*domain.Shortlink, error) {
+	return nil, ErrNotExist
+}
+
+func (dummyRepository) Find(_ context.Context, _ string) (*domain.Shortlink, error) {
+	return nil, ErrNotExist
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+)
+
+type memoryShortlinkRepository struct {
+	mutex      *sync.RWMutex
+	shortlinks map[string]domain.Shortlink
+	paths      map[string]string // codes of entries paths
+}
+
+func NewMemoryShortlinkRepository() shortlink.Repository {
+	return &memoryShortlinkRepository{
+		mutex:      new(sync.RWMutex),
+		shortlinks: make(map[string]domain.Shortlink),
+		paths:      make(map[string]string),
+	}
+}
+
+// NOTE(toby3d): codes are case-sensitive, unlike paths of entries.
+func (repo *memoryShortlinkRepository) Create(_ context.Context, code string, s domain.Shortlink) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.shortlinks[code]; ok {
+		return shortlink.ErrExist
+	}
+
+	repo.shortlinks[code] = s
+	repo.paths[key(s)] = code
+
+	return nil
+}
+
+func (repo *memoryShortlinkRepository) Get(_ context.Context, code string) (*domain.Shortlink, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out, ok := repo.shortlinks[code]
+	if !ok {
+		return nil, shortlink.ErrNotExist
+	}
+
+	return &out, nil
+}
+
+func (repo *memoryShortlinkRepository) Find(_ context.Context, p string) (*domain.Shortlink, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	code, ok := repo.paths[path.Clean(strings.ToLower(p))]
+	if !ok {
+		return nil, shortlink.ErrNotExist
+	}
+
+	out := repo.shortlinks[code]
+
+	return &out, nil
+}
+
+func (repo *memoryShortlinkRepository) Count(_ context.Context, prefix string) (int, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	count := 0
+
+	for code := range repo.shortlinks {
+		if strings.HasPrefix(code, prefix) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (repo *memoryShortlinkRepository) Update(_ context.Context, code string, s domain.Shortlink) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	before, ok := repo.shortlinks[code]
+	if !ok {
+		return fmt.Errorf("cannot update shortlink: %w", shortlink.ErrNotExist)
+	}
+
+	delete(repo.paths, key(before))
+	repo.shortlinks[code] = s
+	repo.paths[key(s)] = code
+
+	return nil
+}
+
+func key(s domain.Shortlink) string {
+	if s.URL == nil {
+		return ""
+	}
+
+	return path.Clean(strings.ToLower(s.URL.RequestURI()))
+}
@@ -0,0 +1,84 @@
+package shortlink
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+)
+
+// Codes styles of generated short codes.
+const (
+	CodesDate     string = "date"
+	CodesSequence string = "sequence"
+)
+
+// NewHook creates a hook which generates shortlink of each created entry and
+// keeps it pointing to entry after it moves.
+func NewHook(shortlinks UseCase, logger *log.Logger) entry.Hook {
+	return entry.HookFunc(func(ctx context.Context, action domain.Action, before, after *domain.Entry) {
+		if after == nil || after.URL == nil {
+			return
+		}
+
+		// NOTE(toby3d): shortlinks must not fail already stored changes.
+		if action == domain.ActionCreate {
+			if _, err := shortlinks.Create(ctx, *after); err != nil {
+				logger.Printf("cannot create shortlink of %s: %s", after.URL, err)
+			}
+
+			return
+		}
+
+		if before == nil || before.URL == nil || before.URL.RequestURI() == after.URL.RequestURI() {
+			return
+		}
+
+		if err := shortlinks.Move(ctx, before.URL, after.URL); err != nil {
+			logger.Printf("cannot move shortlink of %s: %s", before.URL, err)
+		}
+	})
+}
+
+// BaseURL returns root of short URLs on short domain, or on instance host if
+// it is not configured.
+func BaseURL(config domain.Config) *url.URL {
+	out := config.HTTP.BaseURL()
+	if config.Shortlink.Host != "" {
+		out.Host = config.Shortlink.Host
+	}
+
+	if prefix := strings.Trim(config.Shortlink.Prefix, "/"); prefix != "" {
+		out.Path = "/" + prefix + "/"
+	}
+
+	return out
+}
+
+// URL returns short URL of provided code.
+func URL(config domain.Config, code string) *url.URL {
+	out := BaseURL(config)
+	out.Path += code
+
+	return out
+}
+
+// Match reports whether r requests a short URL. All requests of short domain
+// are matched, if it differs from instance host.
+func Match(config domain.Config, r *http.Request) bool {
+	base := BaseURL(config)
+	if !strings.EqualFold(base.Host, config.HTTP.Host) {
+		return strings.EqualFold(r.Host, base.Host)
+	}
+
+	return base.Path != "/" && strings.HasPrefix(r.URL.Path, base.Path)
+}
+
+// Code returns short code requested by r.
+func Code(config domain.Config, r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path, BaseURL(config).Path)
+}
@@ -0,0 +1,48 @@
+package shortlink_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		host, prefix string
+		target       string
+		expect       string // short URL of code, empty if not matched
+	}{
+		"prefix":       {prefix: "/s/", target: "https://example.com/s/5Ne1", expect: "https://example.com/s/5Ne1"},
+		"other path":   {prefix: "/s/", target: "https://example.com/samples/lipsum"},
+		"domain":       {host: "exam.pl", prefix: "/", target: "https://exam.pl/5Ne1", expect: "https://exam.pl/5Ne1"},
+		"other domain": {host: "exam.pl", prefix: "/", target: "https://example.com/5Ne1"},
+		"root":         {prefix: "/", target: "https://example.com/5Ne1"},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := domain.TestConfig(t)
+			config.Shortlink.Host, config.Shortlink.Prefix = tc.host, tc.prefix
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+
+			if !shortlink.Match(*config, req) {
+				if tc.expect != "" {
+					t.Errorf("expect %s matched", tc.target)
+				}
+
+				return
+			}
+
+			if out := shortlink.URL(*config, shortlink.Code(*config, req)).String(); out != tc.expect {
+				t.Errorf("expect short URL %s, got %s", tc.expect, out)
+			}
+		})
+	}
+}
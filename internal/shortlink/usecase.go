@@ -0,0 +1,75 @@
+package shortlink
+
+import (
+	"context"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Create generates a new short code of entry. Returns already
+		// generated one if entry has it.
+		Create(ctx context.Context, e domain.Entry) (*domain.Shortlink, error)
+
+		// Source returns shortlink of entry on provided URL.
+		Source(ctx context.Context, u *url.URL) (*domain.Shortlink, error)
+
+		// Resolve returns shortlink stored on provided code.
+		Resolve(ctx context.Context, code string) (*domain.Shortlink, error)
+
+		// Move changes permalink of shortlink of entry moved from one
+		// URL to another, so code keeps pointing to it.
+		Move(ctx context.Context, from, to *url.URL) error
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		shortlink *domain.Shortlink
+		err       error
+	}
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Create(_ context.Context, _ domain.Entry) (*domain.Shortlink, error) {
+	return nil, nil
+}
+
+func (dummyUseCase) Source(_ context.Context, _ *url.URL) (*domain.Shortlink, error) {
+	return nil, ErrNotExist
+}
+
+func (dummyUseCase) Resolve(_ context.Context, _ string) (*domain.Shortlink, error) {
+	return nil, ErrNotExist
+}
+
+func (dummyUseCase) Move(_ context.Context, _, _ *url.URL) error { return nil }
+
+// NewStubUseCase creates a stub use case what always returns provided
+// shortlink.
+func NewStubUseCase(s *domain.Shortlink, err error) UseCase {
+	return &stubUseCase{
+		shortlink: s,
+		err:       err,
+	}
+}
+
+func (ucase *stubUseCase) Create(_ context.Context, _ domain.Entry) (*domain.Shortlink, error) {
+	return ucase.shortlink, ucase.err
+}
+
+func (ucase *stubUseCase) Source(_ context.Context, _ *url.URL) (*domain.Shortlink, error) {
+	return ucase.shortlink, ucase.err
+}
+
+func (ucase *stubUseCase) Resolve(_ context.Context, _ string) (*domain.Shortlink, error) {
+	return ucase.shortlink, ucase.err
+}
+
+func (ucase *stubUseCase) Move(_ context.Context, _, _ *url.URL) error { return ucase.err }
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+)
+
+type shortlinkUseCase struct {
+	shortlinks shortlink.Repository
+	config     domain.Config
+}
+
+// attempts is a number of tries to take a free code if it was taken by
+// concurrent creation.
+const attempts int = 10
+
+// NewShortlinkUseCase creates a new shortlink use case.
+func NewShortlinkUseCase(shortlinks shortlink.Repository, config domain.Config) shortlink.UseCase {
+	return &shortlinkUseCase{
+		shortlinks: shortlinks,
+		config:     config,
+	}
+}
+
+// Create implements shortlink.UseCase.
+func (ucase *shortlinkUseCase) Create(ctx context.Context, e domain.Entry) (*domain.Shortlink, error) {
+	if out, err := ucase.shortlinks.Find(ctx, e.URL.RequestURI()); err == nil {
+		return ucase.short(out), nil
+	} else if !errors.Is(err, shortlink.ErrNotExist) {
+		return nil, fmt.Errorf("cannot find shortlink of entry: %w", err)
+	}
+
+	var prefix string
+	if ucase.config.Shortlink.Codes != shortlink.CodesSequence {
+		prefix = shortlink.FormatDate(e.Date())
+	}
+
+	count, err := ucase.shortlinks.Count(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot count shortlinks: %w", err)
+	}
+
+	out := domain.Shortlink{
+		CreatedAt: time.Now().UTC(),
+		URL:       e.URL,
+	}
+
+	// NOTE(toby3d): ordinal numbers starts from 1.
+	for i := 1; i <= attempts; i++ {
+		out.Code = prefix + shortlink.FormatNewBase60(uint64(count+i))
+
+		if err = ucase.shortlinks.Create(ctx, out.Code, out); err == nil {
+			return ucase.short(&out), nil
+		}
+
+		if !errors.Is(err, shortlink.ErrExist) {
+			return nil, fmt.Errorf("cannot create shortlink: %w", err)
+		}
+	}
+
+	return nil, fmt.Errorf("cannot create shortlink: %w", err)
+}
+
+// Source implements shortlink.UseCase.
+func (ucase *shortlinkUseCase) Source(ctx context.Context, u *url.URL) (*domain.Shortlink, error) {
+	out, err := ucase.shortlinks.Find(ctx, u.RequestURI())
+	if err != nil {
+		return nil, fmt.Errorf("cannot find shortlink of entry: %w", err)
+	}
+
+	return ucase.short(out), nil
+}
+
+// Resolve implements shortlink.UseCase.
+func (ucase *shortlinkUseCase) Resolve(ctx context.Context, code string) (*domain.Shortlink, error) {
+	out, err := ucase.shortlinks.Get(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve shortlink: %w", err)
+	}
+
+	return ucase.short(out), nil
+}
+
+// Move implements shortlink.UseCase.
+func (ucase *shortlinkUseCase) Move(ctx context.Context, from, to *url.URL) error {
+	s, err := ucase.shortlinks.Find(ctx, from.RequestURI())
+	if err != nil {
+		if errors.Is(err, shortlink.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("cannot find shortlink of entry: %w", err)
+	}
+
+	s.URL = to
+
+	if err = ucase.shortlinks.Update(ctx, s.Code, *s); err != nil {
+		return fmt.Errorf("cannot move shortlink: %w", err)
+	}
+
+	return nil
+}
+
+// short sets short URL of s on the currently configured domain.
+func (ucase *shortlinkUseCase) short(s *domain.Shortlink) *domain.Shortlink {
+	s.Short = shortlink.URL(ucase.config, s.Code)
+
+	return s
+}
@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+	shortlinkmemoryrepo "source.toby3d.me/toby3d/pub/internal/shortlink/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/shortlink/usecase"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	published := time.Date(2023, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		codes  string
+		expect []string
+	}{
+		"date":     {codes: shortlink.CodesDate, expect: []string{"5Ne1", "5Ne2", "5Nf1"}},
+		"sequence": {codes: shortlink.CodesSequence, expect: []string{"1", "2", "3"}},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := *domain.TestConfig(t)
+			config.Shortlink.Codes = tc.codes
+			ucase := usecase.NewShortlinkUseCase(shortlinkmemoryrepo.NewMemoryShortlinkRepository(), config)
+
+			out := make([]string, 0, len(tc.expect))
+
+			for i, p := range []string{"/notes/first", "/notes/second", "/notes/third"} {
+				e := domain.TestEntry(t)
+				e.URL = &url.URL{Path: p}
+				e.PublishedAt = published.AddDate(0, 0, i/2)
+
+				s, err := ucase.Create(context.Background(), *e)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// NOTE(toby3d): entry always keeps the same code.
+				again, err := ucase.Create(context.Background(), *e)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if again.Code != s.Code {
+					t.Errorf("expect the same code %s for %s, got %s", s.Code, p, again.Code)
+				}
+
+				if expect := "https://example.com/s/" + s.Code; s.Short.String() != expect {
+					t.Errorf("expect short URL %s, got %s", expect, s.Short)
+				}
+
+				out = append(out, s.Code)
+			}
+
+			if diff := cmp.Diff(out, tc.expect); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shortlinks := usecase.NewShortlinkUseCase(shortlinkmemoryrepo.NewMemoryShortlinkRepository(),
+		*domain.TestConfig(t))
+	entries := entryucase.NewEntryUseCase(entrymemoryrepo.NewMemoryEntryRepository(),
+		shortlink.NewHook(shortlinks, log.New(io.Discard, "", 0)))
+
+	e := domain.TestEntry(t)
+	if _, err := entries.Create(ctx, *e); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := shortlinks.Source(ctx, e.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved := *e
+	moved.URL = &url.URL{Path: "/samples/moved"}
+
+	if _, err = entries.Update(ctx, e.URL, entry.UpdateOptions{Replace: &moved}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := shortlinks.Resolve(ctx, created.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.URL.Path != moved.URL.Path {
+		t.Errorf("expect %s pointing to %s, got %s", out.Code, moved.URL, out.URL)
+	}
+}
@@ -0,0 +1,45 @@
+// Package syndication provides a POSSE (Publish on your Own Site, Syndicate
+// Elsewhere) of entries into third-party services.
+//
+// See: https://indieweb.org/POSSE
+package syndication
+
+import (
+	"context"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	// Target publishes copies of entries into some third-party service.
+	Target interface {
+		// Syndicator returns description of target for clients.
+		Syndicator() domain.Syndicator
+
+		// Syndicate publishes a copy of entry on target and returns
+		// URL of this copy.
+		Syndicate(ctx context.Context, e domain.Entry) (*url.URL, error)
+	}
+
+	stubTarget struct {
+		output     *url.URL
+		err        error
+		syndicator domain.Syndicator
+	}
+)
+
+// NewStubTarget creates a target which always returns provided output.
+func NewStubTarget(syndicator domain.Syndicator, output *url.URL, err error) Target {
+	return &stubTarget{
+		syndicator: syndicator,
+		output:     output,
+		err:        err,
+	}
+}
+
+func (t *stubTarget) Syndicator() domain.Syndicator { return t.syndicator }
+
+func (t *stubTarget) Syndicate(_ context.Context, _ domain.Entry) (*url.URL, error) {
+	return t.output, t.err
+}
@@ -0,0 +1,152 @@
+// Package mastodon provides a syndication target which publishes entries as
+// statuses through Mastodon-API-compatible instances.
+//
+// See: https://docs.joinmastodon.org/methods/statuses/#create
+package mastodon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
+)
+
+type (
+	mastodonTarget struct {
+		client   *http.Client
+		instance *url.URL
+		config   domain.Config
+	}
+
+	responseStatus struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+)
+
+const (
+	// maxStatusLength is a default limit of status characters.
+	maxStatusLength int = 500
+
+	// urlLength is a number of characters which Mastodon counts for any
+	// URL regardless of it's real length.
+	urlLength int = 23
+
+	maxResponseSize int64 = 1024 * 1024 // 1mb
+)
+
+var ErrInstanceSyntax error = errors.New("mastodon instance MUST be a valid http or https URL")
+
+// NewMastodon creates syndication target for configured Mastodon instance.
+func NewMastodon(client *http.Client, config domain.Config) (syndication.Target, error) {
+	instance, err := url.Parse(config.Syndication.Mastodon.Instance)
+	if err != nil || (instance.Scheme != "http" && instance.Scheme != "https") || instance.Host == "" {
+		return nil, ErrInstanceSyntax
+	}
+
+	return &mastodonTarget{
+		client:   client,
+		instance: instance,
+		config:   config,
+	}, nil
+}
+
+// Syndicator implements syndication.Target.
+func (t *mastodonTarget) Syndicator() domain.Syndicator {
+	out := domain.Syndicator{
+		UID:  t.instance,
+		Name: t.config.Syndication.Mastodon.Name,
+	}
+
+	if out.Name == "" {
+		out.Name = "Mastodon (" + t.instance.Host + ")"
+	}
+
+	return out
+}
+
+// Syndicate implements syndication.Target.
+func (t *mastodonTarget) Syndicate(ctx context.Context, e domain.Entry) (*url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.config.Syndication.Timeout)
+	defer cancel()
+
+	permalink := t.config.HTTP.BaseURL().ResolveReference(e.URL).String()
+	body := url.Values{"status": []string{Status(e, permalink)}}
+
+	endpoint := t.instance.JoinPath("api", "v1", "statuses")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create status request: %w", err)
+	}
+
+	// NOTE(toby3d): repeated syndication of the same entry does not
+	// creates duplicated statuses.
+	key := sha256.Sum256([]byte(permalink))
+
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+	req.Header.Set(common.HeaderAuthorization, "Bearer "+t.config.Syndication.Mastodon.Token)
+	req.Header.Set(common.HeaderIdempotencyKey, hex.EncodeToString(key[:]))
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot publish status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	src, err := httputil.ReadAll(resp.Body, maxResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read status response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot publish status: got %d status code", resp.StatusCode)
+	}
+
+	out := new(responseStatus)
+	if err = json.Unmarshal(src, out); err != nil {
+		return nil, fmt.Errorf("cannot decode status response: %w", err)
+	}
+
+	u, err := url.Parse(out.URL)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("got invalid status URL '%s'", out.URL)
+	}
+
+	return u, nil
+}
+
+// Status returns text of status for entry, truncated to fit into status
+// length limit together with link to permalink.
+func Status(e domain.Entry, permalink string) string {
+	text := e.Title
+	if text == "" {
+		text = e.Content.Text
+	}
+
+	if text == "" {
+		text = e.Description
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return permalink
+	}
+
+	limit := maxStatusLength - urlLength - 2
+	if runes := []rune(text); len(runes) > limit {
+		text = strings.TrimSpace(string(runes[:limit-1])) + "…"
+	}
+
+	return text + "\n\n" + permalink
+}
@@ -0,0 +1,99 @@
+package mastodon_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/syndication/target/mastodon"
+)
+
+func TestSyndicate(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := domain.TestEntry(t)
+	permalink := config.HTTP.BaseURL().ResolveReference(e.URL).String()
+
+	// NOTE(toby3d): local stand-in of Mastodon instance.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/statuses" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if r.Header.Get(common.HeaderAuthorization) != "Bearer secret" {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.Header.Get(common.HeaderIdempotencyKey) == "" {
+			http.Error(w, "missing idempotency key", http.StatusBadRequest)
+
+			return
+		}
+
+		if status := r.PostFormValue("status"); !strings.HasSuffix(status, "\n\n"+permalink) {
+			http.Error(w, "status does not contain permalink: "+status, http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id":  "42",
+			"url": "https://mastodon.example/@alice/42",
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	config.Syndication.Mastodon.Instance = srv.URL
+	config.Syndication.Mastodon.Token = "secret"
+
+	target, err := mastodon.NewMastodon(srv.Client(), *config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := target.Syndicator(); s.UID.String() != srv.URL || s.Name == "" {
+		t.Errorf("unexpected syndicator: %+v", s)
+	}
+
+	out, err := target.Syndicate(context.Background(), *e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := "https://mastodon.example/@alice/42"; out.String() != expect {
+		t.Errorf("Syndicate() = %s, want %s", out, expect)
+	}
+
+	config.Syndication.Mastodon.Token = "invalid"
+
+	if target, err = mastodon.NewMastodon(srv.Client(), *config); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = target.Syndicate(context.Background(), *e); err == nil {
+		t.Error("expect error for rejected token, got nil")
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	e := domain.TestEntry(t)
+	e.Title = strings.Repeat("a", 1000)
+
+	out := mastodon.Status(*e, "https://example.com/samples/lipsum")
+	if n := len([]rune(strings.TrimSuffix(out, "\n\nhttps://example.com/samples/lipsum"))); n > 500-23-2 {
+		t.Errorf("expect status text fits into limit, got %d characters", n)
+	}
+}
@@ -0,0 +1,62 @@
+package syndication
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Targets returns all configured syndication targets.
+		Targets(ctx context.Context) ([]domain.Syndicator, error)
+
+		// Syndicate publishes entry on provided URL into targets with
+		// provided uids and stores resulting URLs into entry
+		// syndications. Returns updated entry even if some targets are
+		// failed.
+		Syndicate(ctx context.Context, u *url.URL, uids ...string) (*domain.Entry, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		entry   *domain.Entry
+		err     error
+		targets []domain.Syndicator
+	}
+)
+
+var ErrUnknownTarget error = errors.New("unknown syndication target")
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Targets(_ context.Context) ([]domain.Syndicator, error) {
+	return make([]domain.Syndicator, 0), nil
+}
+
+func (dummyUseCase) Syndicate(_ context.Context, _ *url.URL, _ ...string) (*domain.Entry, error) {
+	return nil, nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(targets []domain.Syndicator, e *domain.Entry, err error) UseCase {
+	return &stubUseCase{
+		targets: targets,
+		entry:   e,
+		err:     err,
+	}
+}
+
+func (ucase *stubUseCase) Targets(_ context.Context) ([]domain.Syndicator, error) {
+	return ucase.targets, ucase.err
+}
+
+func (ucase *stubUseCase) Syndicate(_ context.Context, _ *url.URL, _ ...string) (*domain.Entry, error) {
+	return ucase.entry, ucase.err
+}
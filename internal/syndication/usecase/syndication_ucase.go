@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
+)
+
+type syndicationUseCase struct {
+	entries entry.Repository
+	targets []syndication.Target
+}
+
+func NewSyndicationUseCase(entries entry.Repository, targets ...syndication.Target) syndication.UseCase {
+	return &syndicationUseCase{
+		entries: entries,
+		targets: targets,
+	}
+}
+
+// Targets implements syndication.UseCase.
+func (ucase *syndicationUseCase) Targets(_ context.Context) ([]domain.Syndicator, error) {
+	out := make([]domain.Syndicator, 0, len(ucase.targets))
+	for i := range ucase.targets {
+		out = append(out, ucase.targets[i].Syndicator())
+	}
+
+	return out, nil
+}
+
+// Syndicate implements syndication.UseCase.
+func (ucase *syndicationUseCase) Syndicate(ctx context.Context, u *url.URL, uids ...string) (*domain.Entry, error) {
+	e, err := ucase.entries.Get(ctx, u.RequestURI())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get entry for syndication: %w", err)
+	}
+
+	errs := make([]error, 0)
+	syndications := make([]*url.URL, 0, len(uids))
+
+	for _, uid := range uids {
+		target := ucase.find(uid)
+		if target == nil {
+			errs = append(errs, fmt.Errorf("%w: %s", syndication.ErrUnknownTarget, uid))
+
+			continue
+		}
+
+		out, err := target.Syndicate(ctx, *e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot syndicate into %s: %w", uid, err))
+
+			continue
+		}
+
+		syndications = append(syndications, out)
+	}
+
+	if len(syndications) == 0 {
+		return e, errors.Join(errs...)
+	}
+
+	result, err := ucase.entries.Update(ctx, u.RequestURI(), func(_ context.Context, e *domain.Entry) (
+		*domain.Entry, error,
+	) {
+		for _, s := range syndications {
+			if !contains(e.Syndications, s) {
+				e.Syndications = append(e.Syndications, s)
+			}
+		}
+
+		e.UpdatedAt = time.Now().UTC()
+
+		return e, nil
+	})
+	if err != nil {
+		return e, fmt.Errorf("cannot store syndications: %w", errors.Join(append(errs, err)...))
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (ucase *syndicationUseCase) find(uid string) syndication.Target {
+	for i := range ucase.targets {
+		if s := ucase.targets[i].Syndicator(); s.UID != nil && s.UID.String() == uid {
+			return ucase.targets[i]
+		}
+	}
+
+	return nil
+}
+
+func contains(list []*url.URL, u *url.URL) bool {
+	for i := range list {
+		if list[i].String() == u.String() {
+			return true
+		}
+	}
+
+	return false
+}
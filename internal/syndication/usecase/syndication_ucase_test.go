@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
+	"source.toby3d.me/toby3d/pub/internal/syndication/usecase"
+)
+
+func TestSyndicate(t *testing.T) {
+	t.Parallel()
+
+	e := domain.TestEntry(t)
+	e.Syndications = nil
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+
+	if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	syndicator := domain.TestSyndicator(t)
+	remote := &url.URL{Scheme: "https", Host: "mastodon.example", Path: "/@alice/42"}
+	ucase := usecase.NewSyndicationUseCase(entries, syndication.NewStubTarget(*syndicator, remote, nil))
+
+	targets, err := ucase.Targets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(targets, []domain.Syndicator{*syndicator}); diff != "" {
+		t.Error(diff)
+	}
+
+	out, err := ucase.Syndicate(context.Background(), e.URL, syndicator.UID.String(), "https://unknown.example/")
+	if !errors.Is(err, syndication.ErrUnknownTarget) {
+		t.Errorf("expect %v error for unknown target, got %v", syndication.ErrUnknownTarget, err)
+	}
+
+	if diff := cmp.Diff(out.Syndications, []*url.URL{remote}); diff != "" {
+		t.Error(diff)
+	}
+
+	stored, err := entries.Get(context.Background(), e.URL.RequestURI())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(stored.Syndications, []*url.URL{remote}); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,12 @@
+// Package provides a webmention HTTP endpoints.
+//
+// Upon receipt of a POST request containing the source and target parameters,
+// the receiver verifies the parameters and then asynchronously verifies that
+// the source links to the target, responding with HTTP 202 Accepted.
+//
+// Received mentions are available for GET requests with target query only
+// after approval, unless moderation is disabled. Mentions of any status are
+// listed by the status query and moderated by POST requests with action and
+// id parameters only on moderation endpoint, which is served next to the
+// editor.
+package http
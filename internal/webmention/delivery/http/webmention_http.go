@@ -0,0 +1,317 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+)
+
+type (
+	// Handler serves public receiving of webmentions and listing of
+	// approved ones.
+	Handler struct {
+		webmentions webmention.UseCase
+	}
+
+	// ModerationHandler serves listing of mentions by any status and
+	// moderation of them. It MUST NOT be served on public endpoint.
+	ModerationHandler struct {
+		webmentions webmention.UseCase
+	}
+
+	Error struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}
+
+	RequestReceive struct {
+		Source *url.URL
+		Target *url.URL
+	}
+
+	RequestModerate struct {
+		ID     string
+		Status domain.MentionStatus
+	}
+
+	RequestFetch struct {
+		Target *url.URL
+		Status domain.MentionStatus
+	}
+
+	ResponseFetch struct {
+		Items []ResponseMention `json:"items"`
+	}
+
+	ResponseMention struct {
+		Author    *ResponseAuthor `json:"author,omitempty"`
+		ID        string          `json:"id"`
+		Source    string          `json:"source"`
+		Target    string          `json:"target"`
+		Type      string          `json:"type"`
+		Status    string          `json:"status"`
+		Name      string          `json:"name,omitempty"`
+		Content   string          `json:"content,omitempty"`
+		Published string          `json:"published,omitempty"`
+		Received  string          `json:"received"`
+	}
+
+	ResponseAuthor struct {
+		Name  string `json:"name,omitempty"`
+		URL   string `json:"url,omitempty"`
+		Photo string `json:"photo,omitempty"`
+	}
+)
+
+func NewHandler(webmentions webmention.UseCase) *Handler {
+	return &Handler{
+		webmentions: webmentions,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	default:
+		WriteError(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	case "", http.MethodGet:
+		req := new(RequestFetch)
+		if err := req.bind(r, domain.MentionStatusApproved); err != nil {
+			WriteError(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		// NOTE(toby3d): only moderated mentions are public.
+		if req.Status != domain.MentionStatusApproved {
+			WriteError(w, "only approved mentions are public", http.StatusForbidden)
+
+			return
+		}
+
+		fetch(w, r, h.webmentions, req)
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			WriteError(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if r.PostForm.Has("action") {
+			WriteError(w, "mentions cannot be moderated on public endpoint", http.StatusForbidden)
+
+			return
+		}
+
+		h.handleReceive(w, r)
+	}
+}
+
+func NewModerationHandler(webmentions webmention.UseCase) *ModerationHandler {
+	return &ModerationHandler{
+		webmentions: webmentions,
+	}
+}
+
+func (h *ModerationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	default:
+		WriteError(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	case "", http.MethodGet:
+		req := new(RequestFetch)
+		if err := req.bind(r, domain.MentionStatusPending); err != nil {
+			WriteError(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		fetch(w, r, h.webmentions, req)
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			WriteError(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		h.handleModerate(w, r)
+	}
+}
+
+func (h *Handler) handleReceive(w http.ResponseWriter, r *http.Request) {
+	req := new(RequestReceive)
+	if err := req.bind(r); err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.webmentions.Receive(r.Context(), req.Source, req.Target); err != nil {
+		writeUseCaseError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *ModerationHandler) handleModerate(w http.ResponseWriter, r *http.Request) {
+	req := new(RequestModerate)
+	if err := req.bind(r); err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	out, err := h.webmentions.Moderate(r.Context(), req.ID, req.Status)
+	if err != nil {
+		if errors.Is(err, webmention.ErrNotExist) {
+			WriteError(w, err.Error(), http.StatusNotFound)
+
+			return
+		}
+
+		writeUseCaseError(w, err)
+
+		return
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	_ = json.NewEncoder(w).Encode(NewResponseMention(*out))
+}
+
+func fetch(w http.ResponseWriter, r *http.Request, webmentions webmention.UseCase, req *RequestFetch) {
+	out, err := webmentions.Fetch(r.Context(), req.Target, req.Status)
+	if err != nil {
+		WriteError(w, "cannot fetch mentions: "+err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	resp := &ResponseFetch{Items: make([]ResponseMention, 0, len(out))}
+	for i := range out {
+		resp.Items = append(resp.Items, NewResponseMention(out[i]))
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (r *RequestReceive) bind(req *http.Request) error {
+	var err error
+	if r.Source, err = url.Parse(req.PostForm.Get("source")); err != nil || req.PostForm.Get("source") == "" {
+		return fmt.Errorf("'source' MUST be a valid URL, got '%s'", req.PostForm.Get("source"))
+	}
+
+	if r.Target, err = url.Parse(req.PostForm.Get("target")); err != nil || req.PostForm.Get("target") == "" {
+		return fmt.Errorf("'target' MUST be a valid URL, got '%s'", req.PostForm.Get("target"))
+	}
+
+	return nil
+}
+
+func (r *RequestModerate) bind(req *http.Request) error {
+	switch action := req.PostForm.Get("action"); action {
+	default:
+		return fmt.Errorf("'action' MUST be 'approve' or 'reject', got '%s'", action)
+	case "approve":
+		r.Status = domain.MentionStatusApproved
+	case "reject":
+		r.Status = domain.MentionStatusRejected
+	}
+
+	if r.ID = req.PostForm.Get("id"); r.ID == "" {
+		return errors.New("'id' MUST be provided")
+	}
+
+	return nil
+}
+
+// bind reads fetch request, status is used if status query is not provided.
+func (r *RequestFetch) bind(req *http.Request, status domain.MentionStatus) error {
+	query := req.URL.Query()
+	r.Status = status
+
+	if query.Has("status") {
+		var err error
+		if r.Status, err = domain.ParseMentionStatus(query.Get("status")); err != nil {
+			return err
+		}
+	}
+
+	if !query.Has("target") {
+		return nil
+	}
+
+	var err error
+	if r.Target, err = url.Parse(query.Get("target")); err != nil {
+		return fmt.Errorf("'target' query MUST be a valid URL, got '%s'", query.Get("target"))
+	}
+
+	return nil
+}
+
+func NewResponseMention(src domain.Mention) ResponseMention {
+	out := ResponseMention{
+		ID:       src.ID(),
+		Source:   src.Source.String(),
+		Target:   src.Target.String(),
+		Type:     src.Type.String(),
+		Status:   src.Status.String(),
+		Name:     src.Title,
+		Content:  src.Content,
+		Received: src.CreatedAt.Format(time.RFC3339),
+	}
+
+	if !src.PublishedAt.IsZero() {
+		out.Published = src.PublishedAt.Format(time.RFC3339)
+	}
+
+	if src.Author.Name != "" || src.Author.URL != nil || src.Author.Photo != nil {
+		out.Author = &ResponseAuthor{Name: src.Author.Name}
+
+		if src.Author.URL != nil {
+			out.Author.URL = src.Author.URL.String()
+		}
+
+		if src.Author.Photo != nil {
+			out.Author.Photo = src.Author.Photo.String()
+		}
+	}
+
+	return out
+}
+
+func writeUseCaseError(w http.ResponseWriter, err error) {
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		WriteError(w, domainErr.Description, domainErr.Code)
+
+		return
+	}
+
+	WriteError(w, err.Error(), http.StatusInternalServerError)
+}
+
+func WriteError(w http.ResponseWriter, description string, status int) {
+	out := &Error{ErrorDescription: description}
+
+	switch status {
+	case http.StatusBadRequest:
+		out.Error = "invalid_request"
+	case http.StatusForbidden:
+		out.Error = "forbidden"
+	case http.StatusNotFound:
+		out.Error = "not_found"
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(out)
+}
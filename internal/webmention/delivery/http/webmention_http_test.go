@@ -0,0 +1,168 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+	delivery "source.toby3d.me/toby3d/pub/internal/webmention/delivery/http"
+)
+
+func TestHandler_Receive(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		body   url.Values
+		err    error
+		expect int
+	}{
+		"accepted": {
+			body:   url.Values{"source": {"https://example.net/"}, "target": {"https://example.com/"}},
+			expect: http.StatusAccepted,
+		},
+		"missing": {
+			body:   url.Values{"source": {"https://example.net/"}},
+			expect: http.StatusBadRequest,
+		},
+		"rejected": {
+			body:   url.Values{"source": {"https://example.net/"}, "target": {"https://example.com/404"}},
+			err:    webmention.ErrTargetNotFound,
+			expect: http.StatusBadRequest,
+		},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/webmention",
+				strings.NewReader(tc.body.Encode()))
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+			w := httptest.NewRecorder()
+			delivery.NewHandler(webmention.NewStubUseCase(nil, nil, tc.err)).ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != tc.expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, tc.expect)
+			}
+		})
+	}
+}
+
+func TestHandler_Fetch(t *testing.T) {
+	t.Parallel()
+
+	m := domain.TestMention(t)
+	m.Status = domain.MentionStatusApproved
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/webmention?target="+
+		url.QueryEscape(m.Target.String()), nil)
+	w := httptest.NewRecorder()
+
+	delivery.NewHandler(webmention.NewStubUseCase([]domain.Mention{*m}, nil, nil)).ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+	}
+
+	out := new(delivery.ResponseFetch)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &delivery.ResponseFetch{Items: []delivery.ResponseMention{delivery.NewResponseMention(*m)}}
+	if diff := cmp.Diff(out, expect); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHandler_Moderate(t *testing.T) {
+	t.Parallel()
+
+	m := domain.TestMention(t)
+
+	for action, expect := range map[string]int{
+		"approve": http.StatusOK,
+		"reject":  http.StatusOK,
+		"ignore":  http.StatusBadRequest,
+	} {
+		action, expect := action, expect
+
+		t.Run(action, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "https://example.com/editor/webmention",
+				strings.NewReader(url.Values{"action": {action}, "id": {m.ID()}}.Encode()))
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+			w := httptest.NewRecorder()
+			delivery.NewModerationHandler(webmention.NewStubUseCase(nil, m, nil)).ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != expect {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, expect)
+			}
+		})
+	}
+}
+
+func TestHandler_Public(t *testing.T) {
+	t.Parallel()
+
+	m := domain.TestMention(t)
+	handler := delivery.NewHandler(webmention.NewStubUseCase([]domain.Mention{*m}, m, nil))
+
+	for name, req := range map[string]*http.Request{
+		"moderate": httptest.NewRequest(http.MethodPost, "https://example.com/webmention",
+			strings.NewReader(url.Values{"action": {"approve"}, "id": {m.ID()}}.Encode())),
+		"pending": httptest.NewRequest(http.MethodGet, "https://example.com/webmention?status=pending", nil),
+	} {
+		name, req := name, req
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if resp := w.Result(); resp.StatusCode != http.StatusForbidden {
+				t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestModerationHandler_Fetch(t *testing.T) {
+	t.Parallel()
+
+	m := domain.TestMention(t)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/editor/webmention?status=pending", nil)
+	w := httptest.NewRecorder()
+
+	delivery.NewModerationHandler(webmention.NewStubUseCase([]domain.Mention{*m}, nil, nil)).ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusOK)
+	}
+
+	out := new(delivery.ResponseFetch)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Items) != 1 || out.Items[0].ID != m.ID() {
+		t.Errorf("expect pending mention %s listed, got %+v", m.ID(), out.Items)
+	}
+}
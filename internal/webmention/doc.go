@@ -0,0 +1,6 @@
+// Package webmention provides a simple way to notify any URL when you mention
+// it on your site. From the receiver's perspective, it's a way to request
+// notifications when other sites mention it.
+//
+// See: https://www.w3.org/TR/webmention/
+package webmention
@@ -0,0 +1,157 @@
+package webmention
+
+import (
+	"context"
+	"errors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UpdateFunc func(ctx context.Context, input *domain.Mention) (*domain.Mention, error)
+
+	Repository interface {
+		// Create save provided mention into the store. Returns error if
+		// mention with the same id already exists.
+		Create(ctx context.Context, id string, m domain.Mention) error
+
+		// Get returns a early stored mention. Returns error if mention
+		// is not exist.
+		Get(ctx context.Context, id string) (*domain.Mention, error)
+
+		// Fetch returns all mentions of provided target. URLs are
+		// compared after normalization, empty target means any target.
+		Fetch(ctx context.Context, target string) ([]domain.Mention, int, error)
+
+		// Update updates exists mention by provided update func.
+		Update(ctx context.Context, id string, update UpdateFunc) (*domain.Mention, error)
+
+		// Delete removes mention from the store.
+		Delete(ctx context.Context, id string) (bool, error)
+	}
+
+	dummyRepository struct{}
+
+	stubRepository struct {
+		outputs []domain.Mention
+		output  *domain.Mention
+		err     error
+		ok      bool
+	}
+
+	spyRepository struct {
+		subRepository Repository
+		Creates       int
+		Deletes       int
+		Fetches       int
+		Gets          int
+		Updates       int
+	}
+
+	// NOTE(toby3d): fakeRepository is already provided by memory sub-package.
+	// NOTE(toby3d): mockRepository is complicated. Mocking too much is bad.
+)
+
+var (
+	ErrExist    error = errors.New("this mention already exist")
+	ErrNotExist error = errors.New("this mention is not exist")
+)
+
+// NewDummyWebmentionRepository creates an empty repository to satisfy
+// contracts. It is used in tests where repository working is not important.
+func NewDummyWebmentionRepository() Repository {
+	return &dummyRepository{}
+}
+
+func (dummyRepository) Create(_ context.Context, _ string, _ domain.Mention) error { return nil }
+func (dummyRepository) Delete(_ context.Context, _ string) (bool, error)           { return false, nil }
+func (dummyRepository) Get(_ context.Context, _ string) (*domain.Mention, error)   { return nil, nil }
+
+func (dummyRepository) Fetch(_ context.Context, _ string) ([]domain.Mention, int, error) {
+	return make([]domain.Mention, 0), 0, nil
+}
+
+func (dummyRepository) Update(_ context.Context, _ string, _ UpdateFunc) (*domain.Mention, error) {
+	return nil, nil
+}
+
+// NewStubWebmentionRepository creates a repository that always returns input
+// as a output. It is used in tests where some dependency on the repository is
+// required.
+func NewStubWebmentionRepository(outputs []domain.Mention, output *domain.Mention, err error, ok bool,
+) Repository {
+	return &stubRepository{
+		outputs: outputs,
+		output:  output,
+		err:     err,
+		ok:      ok,
+	}
+}
+
+func (repo *stubRepository) Create(_ context.Context, _ string, _ domain.Mention) error {
+	return repo.err
+}
+
+func (repo *stubRepository) Delete(_ context.Context, _ string) (bool, error) {
+	return repo.ok, repo.err
+}
+
+func (repo *stubRepository) Fetch(_ context.Context, _ string) ([]domain.Mention, int, error) {
+	return repo.outputs, len(repo.outputs), repo.err
+}
+
+func (repo *stubRepository) Get(_ context.Context, _ string) (*domain.Mention, error) {
+	return repo.output, repo.err
+}
+
+func (repo *stubRepository) Update(_ context.Context, _ string, _ UpdateFunc) (*domain.Mention, error) {
+	return repo.output, repo.err
+}
+
+// NewSpyWebmentionRepository creates a spy repository which count outside
+// calls, based on provided subRepo. If subRepo is nil, then DummyRepository
+// will be used.
+func NewSpyWebmentionRepository(subRepo Repository) *spyRepository {
+	if subRepo == nil {
+		subRepo = NewDummyWebmentionRepository()
+	}
+
+	return &spyRepository{
+		subRepository: subRepo,
+		Creates:       0,
+		Updates:       0,
+		Gets:          0,
+		Fetches:       0,
+		Deletes:       0,
+	}
+}
+
+func (repo *spyRepository) Create(ctx context.Context, id string, m domain.Mention) error {
+	repo.Creates++
+
+	return repo.subRepository.Create(ctx, id, m)
+}
+
+func (repo *spyRepository) Delete(ctx context.Context, id string) (bool, error) {
+	repo.Deletes++
+
+	return repo.subRepository.Delete(ctx, id)
+}
+
+func (repo *spyRepository) Fetch(ctx context.Context, target string) ([]domain.Mention, int, error) {
+	repo.Fetches++
+
+	return repo.subRepository.Fetch(ctx, target)
+}
+
+func (repo *spyRepository) Get(ctx context.Context, id string) (*domain.Mention, error) {
+	repo.Gets++
+
+	return repo.subRepository.Get(ctx, id)
+}
+
+func (repo *spyRepository) Update(ctx context.Context, id string, update UpdateFunc) (*domain.Mention, error) {
+	repo.Updates++
+
+	return repo.subRepository.Update(ctx, id, update)
+}
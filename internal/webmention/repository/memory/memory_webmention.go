@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+)
+
+type memoryWebmentionRepository struct {
+	mutex    *sync.RWMutex
+	mentions map[string]domain.Mention
+}
+
+func NewMemoryWebmentionRepository() webmention.Repository {
+	return &memoryWebmentionRepository{
+		mutex:    new(sync.RWMutex),
+		mentions: make(map[string]domain.Mention),
+	}
+}
+
+func (repo *memoryWebmentionRepository) Create(_ context.Context, id string, m domain.Mention) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.mentions[id]; ok {
+		return webmention.ErrExist
+	}
+
+	repo.mentions[id] = m
+
+	return nil
+}
+
+func (repo *memoryWebmentionRepository) Get(_ context.Context, id string) (*domain.Mention, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if out, ok := repo.mentions[id]; ok {
+		return &out, nil
+	}
+
+	return nil, webmention.ErrNotExist
+}
+
+func (repo *memoryWebmentionRepository) Fetch(_ context.Context, target string) ([]domain.Mention, int, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	out := make([]domain.Mention, 0)
+	target = normalize(target)
+
+	for _, m := range repo.mentions {
+		if m.Target == nil || target != "" && normalize(m.Target.String()) != target {
+			continue
+		}
+
+		out = append(out, m)
+	}
+
+	return out, len(out), nil
+}
+
+func (repo *memoryWebmentionRepository) Update(ctx context.Context, id string, update webmention.UpdateFunc,
+) (*domain.Mention, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	in, ok := repo.mentions[id]
+	if !ok {
+		return nil, fmt.Errorf("cannot update mention: %w", webmention.ErrNotExist)
+	}
+
+	out, err := update(ctx, &in)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update mention: %w", err)
+	}
+
+	repo.mentions[id] = *out
+
+	return out, nil
+}
+
+func (repo *memoryWebmentionRepository) Delete(_ context.Context, id string) (bool, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.mentions[id]; !ok {
+		return false, nil
+	}
+
+	delete(repo.mentions, id)
+
+	return true, nil
+}
+
+// normalize returns raw URL in form which is equal for all URLs of the same
+// entry: scheme and host are case-insensitive, paths of entries too, fragments
+// points into the same page.
+func normalize(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) +
+		path.Clean("/"+strings.ToLower(u.EscapedPath()))
+}
@@ -0,0 +1,42 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/webmention/repository/memory"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	repo := memory.NewMemoryWebmentionRepository()
+
+	for _, target := range []string{
+		"https://example.com/notes/1",
+		"https://example.com/notes/10",
+		"https://example.com/notes/1/replies",
+		"https://EXAMPLE.com/Notes/1/#comments",
+	} {
+		m := domain.TestMention(t)
+		m.Target = domain.TestURL(t, target)
+
+		if err := repo.Create(context.Background(), m.ID(), *m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for target, expect := range map[string]int{
+		"":                             4,
+		"https://example.com/notes/1":  2,
+		"https://example.com/notes/10": 1,
+		"https://example.com/notes":    0,
+	} {
+		if _, count, err := repo.Fetch(context.Background(), target); err != nil {
+			t.Error(err)
+		} else if count != expect {
+			t.Errorf("Fetch(%q) = %d, want %d", target, count, expect)
+		}
+	}
+}
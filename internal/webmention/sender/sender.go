@@ -0,0 +1,351 @@
+// Package sender provides a webmention sender which notifies pages linked from
+// created, updated and deleted entries.
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+)
+
+type (
+	Sender struct {
+		client *http.Client
+		logger *log.Logger
+		queue  chan job
+		config domain.Config
+	}
+
+	job struct {
+		source  *url.URL
+		target  *url.URL
+		attempt int
+	}
+)
+
+const (
+	queueSize       int   = 256
+	maxDiscoverSize int64 = 1024 * 1024 // 1mb
+)
+
+var (
+	ErrNoEndpoint error = errors.New("webmention endpoint is not found")
+	ErrRejected   error = errors.New("webmention rejected by receiver")
+)
+
+func NewSender(client *http.Client, config domain.Config, logger *log.Logger) *Sender {
+	return &Sender{
+		client: client,
+		logger: logger,
+		config: config,
+		queue:  make(chan job, queueSize),
+	}
+}
+
+// Handle implements entry.Hook. It enqueues webmentions for all links of
+// published entry before and after change, so removed links also receive
+// notification about updated source.
+func (s *Sender) Handle(_ context.Context, _ domain.Action, before, after *domain.Entry) {
+	e := after
+	if e == nil {
+		e = before
+	}
+
+	if e == nil || e.URL == nil {
+		return
+	}
+
+	source := s.config.HTTP.BaseURL().ResolveReference(e.URL)
+	targets := make(map[string]struct{})
+
+	// NOTE(toby3d): drafts and deleted states do not link anywhere, but
+	// targets of published state are notified when it goes away.
+	for _, state := range []*domain.Entry{before, after} {
+		if state == nil || !state.IsPublished() {
+			continue
+		}
+
+		for _, target := range Links(*state) {
+			if _, ok := targets[target.String()]; ok || strings.EqualFold(target.Host, s.config.HTTP.Host) {
+				continue
+			}
+
+			targets[target.String()] = struct{}{}
+
+			s.enqueue(job{source: source, target: target})
+		}
+	}
+}
+
+// Run sends enqueued webmentions until ctx is done. Failed deliveries are
+// retried with exponential backoff.
+func (s *Sender) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-s.queue:
+			err := s.Send(ctx, j.source, j.target)
+			if err == nil || errors.Is(err, ErrNoEndpoint) || errors.Is(err, ErrRejected) {
+				continue
+			}
+
+			if j.attempt >= s.config.Webmention.Retries {
+				s.logger.Printf("cannot send webmention from %s to %s, give up: %s", j.source, j.target, err)
+
+				continue
+			}
+
+			delay := s.config.Webmention.Backoff << j.attempt
+			j.attempt++
+
+			time.AfterFunc(delay, func() { s.enqueue(j) })
+		}
+	}
+}
+
+// Send discovers webmention endpoint of target and notifies it about source.
+func (s *Sender) Send(ctx context.Context, source, target *url.URL) error {
+	endpoint, err := s.Discover(ctx, target)
+	if err != nil {
+		return fmt.Errorf("cannot send webmention: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Webmention.Timeout)
+	defer cancel()
+
+	body := url.Values{
+		"source": []string{source.String()},
+		"target": []string{target.String()},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(),
+		strings.NewReader(body.Encode()))
+	if err != nil {
+		return fmt.Errorf("cannot create webmention request: %w", err)
+	}
+
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot send webmention: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscoverSize))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests:
+		return fmt.Errorf("%w: got %d status code", ErrRejected, resp.StatusCode)
+	default:
+		return fmt.Errorf("cannot send webmention: got %d status code", resp.StatusCode)
+	}
+}
+
+// Discover returns webmention endpoint of target from HTTP Link header or
+// first <link> or <a> HTML element with webmention rel value.
+func (s *Sender) Discover(ctx context.Context, target *url.URL) (*url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Webmention.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create discovery request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch target for discovery: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// NOTE(toby3d): relative endpoints resolves against final URL after
+	// redirects.
+	base := resp.Request.URL
+
+	for _, header := range resp.Header.Values(common.HeaderLink) {
+		if href, ok := ParseLinkHeader(header)["webmention"]; ok {
+			return resolve(base, href)
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(common.HeaderContentType))
+	if mediaType != common.MIMETextHTML {
+		return nil, ErrNoEndpoint
+	}
+
+	body, err := httputil.ReadAll(resp.Body, maxDiscoverSize)
+	if err != nil && !errors.Is(err, httputil.ErrTooLarge) {
+		return nil, fmt.Errorf("cannot read target body: %w", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse target body: %w", err)
+	}
+
+	if href, ok := findRel(doc, "webmention"); ok {
+		return resolve(base, href)
+	}
+
+	return nil, ErrNoEndpoint
+}
+
+func (s *Sender) enqueue(j job) {
+	select {
+	case s.queue <- j:
+	default:
+		s.logger.Printf("webmention queue is full, drop %s to %s", j.source, j.target)
+	}
+}
+
+// Links returns all absolute HTTP URLs which entry refers to in it's content
+// and response properties.
+func Links(e domain.Entry) []*url.URL {
+	out := make([]*url.URL, 0)
+	unique := make(map[string]struct{})
+
+	add := func(u *url.URL) {
+		if u == nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return
+		}
+
+		if _, ok := unique[u.String()]; ok {
+			return
+		}
+
+		unique[u.String()] = struct{}{}
+		out = append(out, u)
+	}
+
+	for _, list := range [][]*url.URL{e.InReplyTo, e.LikeOf, e.RepostOf, e.BookmarkOf} {
+		for i := range list {
+			add(list[i])
+		}
+	}
+
+	if e.Content.HTML == nil {
+		return out
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if u, err := url.Parse(attr(n, "href")); err == nil {
+				add(u)
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(e.Content.HTML)
+
+	return out
+}
+
+// ParseLinkHeader parses RFC 8288 Link header value into map of rel values to
+// first matching target.
+func ParseLinkHeader(v string) map[string]string {
+	out := make(map[string]string)
+
+	for v = strings.TrimSpace(v); strings.HasPrefix(v, "<"); v = strings.TrimSpace(v) {
+		end := strings.IndexByte(v, '>')
+		if end < 0 {
+			break
+		}
+
+		target := v[1:end]
+		v = v[end+1:]
+
+		// NOTE(toby3d): params lasts up to the next link value.
+		params := v
+		if next := strings.Index(v, ",<"); next >= 0 {
+			params, v = v[:next], v[next+1:]
+		} else if next = strings.Index(v, ", <"); next >= 0 {
+			params, v = v[:next], v[next+1:]
+		} else {
+			v = ""
+		}
+
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				if _, ok := out[strings.ToLower(rel)]; !ok {
+					out[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// findRel returns href of first <link> or <a> element in document order which
+// has provided rel value.
+func findRel(n *html.Node, rel string) (string, bool) {
+	if n.Type == html.ElementNode && (n.Data == "link" || n.Data == "a") {
+		if href, ok := attrOK(n, "href"); ok {
+			for _, v := range strings.Fields(attr(n, "rel")) {
+				if strings.EqualFold(v, rel) {
+					return href, true
+				}
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if href, ok := findRel(c, rel); ok {
+			return href, true
+		}
+	}
+
+	return "", false
+}
+
+func attr(n *html.Node, key string) string {
+	v, _ := attrOK(n, key)
+
+	return v
+}
+
+func attrOK(n *html.Node, key string) (string, bool) {
+	for i := range n.Attr {
+		if n.Attr[i].Namespace == "" && n.Attr[i].Key == key {
+			return n.Attr[i].Val, true
+		}
+	}
+
+	return "", false
+}
+
+func resolve(base *url.URL, href string) (*url.URL, error) {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse endpoint URL: %w", err)
+	}
+
+	return base.ResolveReference(ref), nil
+}
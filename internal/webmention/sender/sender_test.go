@@ -0,0 +1,242 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/webmention/sender"
+)
+
+type testReceiver struct {
+	mutex    *sync.Mutex
+	received []url.Values
+	failures int
+}
+
+func TestSender_Discover(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t, 0)
+
+	for name, expect := range map[string]string{
+		"header": srv.URL + "/endpoint?via=header",
+		"link":   srv.URL + "/endpoint?via=link",
+		"anchor": srv.URL + "/endpoint?via=anchor",
+		"empty":  srv.URL + "/empty",
+	} {
+		name, expect := name, expect
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := sender.NewSender(srv.Client(), *domain.TestConfig(t), log.New(io.Discard, "", 0)).
+				Discover(context.Background(), domain.TestURL(t, srv.URL+"/"+name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if out.String() != expect {
+				t.Errorf("Discover(%s) = %s, want %s", name, out, expect)
+			}
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sender.NewSender(srv.Client(), *domain.TestConfig(t), log.New(io.Discard, "", 0)).
+			Discover(context.Background(), domain.TestURL(t, srv.URL+"/none"))
+		if !errors.Is(err, sender.ErrNoEndpoint) {
+			t.Errorf("Discover(none) = %v, want %v", err, sender.ErrNoEndpoint)
+		}
+	})
+}
+
+func TestSender_Handle(t *testing.T) {
+	t.Parallel()
+
+	srv, receiver := newTestServer(t, 1)
+
+	config := domain.TestConfig(t)
+	content, err := html.Parse(strings.NewReader(`<p>See <a href="` + srv.URL + `/link">this</a> and ` +
+		`<a href="/local">that</a>.</p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := domain.TestEntry(t)
+	before.InReplyTo = []*url.URL{domain.TestURL(t, srv.URL+"/header")}
+
+	after := domain.TestEntry(t)
+	after.Content.HTML = content
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := sender.NewSender(srv.Client(), *config, log.New(io.Discard, "", 0))
+	go s.Run(ctx)
+
+	s.Handle(ctx, domain.ActionUpdate, before, after)
+
+	source := config.HTTP.BaseURL().ResolveReference(after.URL).String()
+	expect := []url.Values{
+		{"source": {source}, "target": {srv.URL + "/header"}},
+		{"source": {source}, "target": {srv.URL + "/link"}},
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(receiver.Received()) < len(expect) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if diff := cmp.Diff(receiver.Received(), expect); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSender_Handle_Draft(t *testing.T) {
+	t.Parallel()
+
+	srv, receiver := newTestServer(t, 0)
+
+	config := domain.TestConfig(t)
+
+	published := domain.TestEntry(t)
+	published.InReplyTo = []*url.URL{domain.TestURL(t, srv.URL+"/header")}
+
+	draft := domain.TestEntry(t)
+	draft.Status = domain.PostStatusDraft
+	draft.InReplyTo = []*url.URL{domain.TestURL(t, srv.URL+"/link")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := sender.NewSender(srv.Client(), *config, log.New(io.Discard, "", 0))
+	go s.Run(ctx)
+
+	s.Handle(ctx, domain.ActionCreate, nil, draft)
+	s.Handle(ctx, domain.ActionUpdate, published, draft)
+
+	source := config.HTTP.BaseURL().ResolveReference(published.URL).String()
+	expect := []url.Values{{"source": {source}, "target": {srv.URL + "/header"}}}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(receiver.Received()) < len(expect) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if diff := cmp.Diff(receiver.Received(), expect); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	t.Parallel()
+
+	out := sender.ParseLinkHeader(`<https://example.com/a,b>; rel="hub", ` +
+		`</webmention>; rel="webmention alternate", <https://example.org/>; rel=webmention`)
+	expect := map[string]string{
+		"hub":        "https://example.com/a,b",
+		"webmention": "/webmention",
+		"alternate":  "/webmention",
+	}
+
+	if diff := cmp.Diff(out, expect); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func newTestServer(tb testing.TB, failures int) (*httptest.Server, *testReceiver) {
+	tb.Helper()
+
+	receiver := &testReceiver{
+		mutex:    new(sync.Mutex),
+		received: make([]url.Values, 0),
+		failures: failures,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/header", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add(common.HeaderLink, `<https://example.org/>; rel="alternate"`)
+		w.Header().Add(common.HeaderLink, `</endpoint?via=header>; rel="webmention"`)
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<link rel="webmention" href="/endpoint?via=link">`)
+	})
+	mux.HandleFunc("/link", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<html><head><link rel="stylesheet" href="/style.css">`+
+			`<link rel="webmention" href="/endpoint?via=link"></head>`+
+			`<body><a rel="webmention" href="/endpoint?via=anchor">wm</a></body></html>`)
+	})
+	mux.HandleFunc("/anchor", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<a href="/endpoint?via=nothing">no</a>`+
+			`<a rel="nofollow webmention" href="/endpoint?via=anchor">wm</a>`)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<link rel="webmention" href="">`)
+	})
+	mux.HandleFunc("/none", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<p>Nothing here</p>`)
+	})
+	mux.HandleFunc("/endpoint", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		receiver.mutex.Lock()
+		defer receiver.mutex.Unlock()
+
+		if receiver.failures > 0 {
+			receiver.failures--
+
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_ = r.ParseForm()
+		receiver.received = append(receiver.received, r.PostForm)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	return srv, receiver
+}
+
+// Received returns webmentions sorted by target.
+func (r *testReceiver) Received() []url.Values {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	out := append(make([]url.Values, 0, len(r.received)), r.received...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Get("target") < out[j].Get("target")
+	})
+
+	return out
+}
@@ -0,0 +1,113 @@
+package webmention
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/xerrors"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+type (
+	UseCase interface {
+		// Receive validates received webmention and enqueues
+		// asynchronous verification of it's source.
+		Receive(ctx context.Context, source, target *url.URL) error
+
+		// Run verifies enqueued webmentions until ctx is done.
+		Run(ctx context.Context)
+
+		// Verify fetches source and creates, updates or deletes stored
+		// mention of target based on it's contents.
+		Verify(ctx context.Context, source, target *url.URL) error
+
+		// Fetch returns mentions of target entry which has provided
+		// status. Nil target means any target, MentionStatusUnd means
+		// any status.
+		Fetch(ctx context.Context, target *url.URL, status domain.MentionStatus) ([]domain.Mention, error)
+
+		// Moderate changes status of mention with provided id.
+		Moderate(ctx context.Context, id string, status domain.MentionStatus) (*domain.Mention, error)
+	}
+
+	dummyUseCase struct{}
+
+	stubUseCase struct {
+		mention  *domain.Mention
+		err      error
+		mentions []domain.Mention
+	}
+)
+
+var (
+	ErrSourceSyntax error = domain.Error{
+		Description: "source MUST be a valid http or https URL",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+	ErrTargetSyntax error = domain.Error{
+		Description: "target MUST be a valid http or https URL",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+	ErrSameURL error = domain.Error{
+		Description: "source MUST NOT be the same as target",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+	ErrTargetNotFound error = domain.Error{
+		Description: "target is not a valid resource for which webmentions accepted",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+	ErrNoLink error = domain.Error{
+		Description: "source does not link to target",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusBadRequest,
+	}
+	ErrQueueFull error = domain.Error{
+		Description: "too many webmentions are waiting for verification, try again later",
+		Frame:       xerrors.Caller(1),
+		Code:        http.StatusServiceUnavailable,
+	}
+)
+
+// NewDummyUseCase creates a dummy use case what does nothing.
+func NewDummyUseCase() UseCase {
+	return &dummyUseCase{}
+}
+
+func (dummyUseCase) Receive(_ context.Context, _, _ *url.URL) error { return nil }
+func (dummyUseCase) Verify(_ context.Context, _, _ *url.URL) error  { return nil }
+func (dummyUseCase) Run(_ context.Context)                          {}
+
+func (dummyUseCase) Fetch(_ context.Context, _ *url.URL, _ domain.MentionStatus) ([]domain.Mention, error) {
+	return make([]domain.Mention, 0), nil
+}
+
+func (dummyUseCase) Moderate(_ context.Context, _ string, _ domain.MentionStatus) (*domain.Mention, error) {
+	return nil, nil
+}
+
+// NewStubUseCase creates a stub use case what always returns provided outputs.
+func NewStubUseCase(mentions []domain.Mention, mention *domain.Mention, err error) UseCase {
+	return &stubUseCase{
+		mentions: mentions,
+		mention:  mention,
+		err:      err,
+	}
+}
+
+func (ucase *stubUseCase) Receive(_ context.Context, _, _ *url.URL) error { return ucase.err }
+func (ucase *stubUseCase) Verify(_ context.Context, _, _ *url.URL) error  { return ucase.err }
+func (ucase *stubUseCase) Run(_ context.Context)                          {}
+
+func (ucase *stubUseCase) Fetch(_ context.Context, _ *url.URL, _ domain.MentionStatus) ([]domain.Mention, error) {
+	return ucase.mentions, ucase.err
+}
+
+func (ucase *stubUseCase) Moderate(_ context.Context, _ string, _ domain.MentionStatus) (*domain.Mention, error) {
+	return ucase.mention, ucase.err
+}
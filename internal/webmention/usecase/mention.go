@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/mf2"
+)
+
+// mentionTypes maps response properties of source h-entry into mention types
+// in order of priority.
+var mentionTypes = []struct {
+	property    string
+	mentionType domain.MentionType
+}{
+	{"in-reply-to", domain.MentionTypeReply},
+	{"like-of", domain.MentionTypeLike},
+	{"repost-of", domain.MentionTypeRepost},
+	{"bookmark-of", domain.MentionTypeBookmark},
+}
+
+// publishedLayouts contains supported dt-published value formats.
+var publishedLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05Z07:00", "2006-01-02"}
+
+// parseMention populates dst with properties of first h-entry in doc and
+// reports whether doc links to dst.Target.
+func parseMention(doc *html.Node, base *url.URL, dst *domain.Mention) bool {
+	target := dst.Target.String()
+	if !links(doc, base, target) {
+		return false
+	}
+
+	item := mf2.ParseNode(doc, base).Find("h-entry")
+	if item == nil {
+		return true
+	}
+
+	dst.Title = normalize(item.String("name"))
+	dst.Content = normalize(item.String("content"))
+
+	// NOTE(toby3d): implied name is the same as content, which is useless.
+	if dst.Title != "" && strings.HasPrefix(dst.Content, dst.Title) {
+		dst.Title = ""
+	}
+
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout, item.String("published")); err == nil {
+			dst.PublishedAt = t.UTC()
+
+			break
+		}
+	}
+
+	if author := item.Item("author"); author != nil {
+		dst.Author = domain.Card{
+			Name:  normalize(author.String("name")),
+			URL:   resolve(base, author.String("url")),
+			Photo: resolve(base, author.String("photo")),
+		}
+	} else if author := item.String("author"); author != "" {
+		if u, err := url.Parse(author); err == nil && u.IsAbs() {
+			dst.Author.URL = u
+		} else {
+			dst.Author.Name = normalize(author)
+		}
+	}
+
+	for _, mt := range mentionTypes {
+		for _, v := range item.Strings(mt.property) {
+			if u := resolve(base, v); u != nil && u.String() == target {
+				dst.Type = mt.mentionType
+
+				return true
+			}
+		}
+	}
+
+	return true
+}
+
+// links reports whether any element of n refers to target.
+func links(n *html.Node, base *url.URL, target string) bool {
+	if n.Type == html.ElementNode {
+		for i := range n.Attr {
+			if n.Attr[i].Namespace != "" || (n.Attr[i].Key != "href" && n.Attr[i].Key != "src") {
+				continue
+			}
+
+			if u := resolve(base, n.Attr[i].Val); u != nil && u.String() == target {
+				return true
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if links(c, base, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resolve(base *url.URL, href string) *url.URL {
+	if href = strings.TrimSpace(href); href == "" {
+		return nil
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return nil
+	}
+
+	return base.ResolveReference(ref)
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// sortMentions sorts mentions in order of receiving.
+func sortMentions(mentions []domain.Mention) {
+	sort.SliceStable(mentions, func(i, j int) bool {
+		if !mentions[i].CreatedAt.Equal(mentions[j].CreatedAt) {
+			return mentions[i].CreatedAt.Before(mentions[j].CreatedAt)
+		}
+
+		return mentions[i].Source.String() < mentions[j].Source.String()
+	})
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/entry"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+)
+
+type (
+	webmentionUseCase struct {
+		mentions webmention.Repository
+		entries  entry.Repository
+		client   *http.Client
+		logger   *log.Logger
+		queue    chan job
+		config   domain.Config
+	}
+
+	job struct {
+		source *url.URL
+		target *url.URL
+	}
+)
+
+const (
+	queueSize     int   = 256
+	workers       int   = 4
+	maxSourceSize int64 = 1024 * 1024 // 1mb
+)
+
+func NewWebmentionUseCase(mentions webmention.Repository, entries entry.Repository, client *http.Client,
+	config domain.Config, logger *log.Logger,
+) webmention.UseCase {
+	return &webmentionUseCase{
+		mentions: mentions,
+		entries:  entries,
+		client:   client,
+		logger:   logger,
+		config:   config,
+		queue:    make(chan job, queueSize),
+	}
+}
+
+// Receive implements webmention.UseCase.
+func (ucase *webmentionUseCase) Receive(ctx context.Context, source, target *url.URL) error {
+	if !isHTTP(source) {
+		return webmention.ErrSourceSyntax
+	}
+
+	if !isHTTP(target) {
+		return webmention.ErrTargetSyntax
+	}
+
+	if source.String() == target.String() {
+		return webmention.ErrSameURL
+	}
+
+	if !strings.EqualFold(target.Host, ucase.config.HTTP.Host) {
+		return webmention.ErrTargetNotFound
+	}
+
+	e, err := ucase.entries.Get(ctx, target.RequestURI())
+	if err != nil || e == nil || !e.DeletedAt.IsZero() {
+		return webmention.ErrTargetNotFound
+	}
+
+	// NOTE(toby3d): verification must be outlive the request which
+	// received webmention, senders retries rejected ones later.
+	select {
+	case ucase.queue <- job{source: source, target: target}:
+		return nil
+	default:
+		return webmention.ErrQueueFull
+	}
+}
+
+// Run implements webmention.UseCase.
+func (ucase *webmentionUseCase) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-ucase.queue:
+					if err := ucase.Verify(ctx, j.source, j.target); err != nil {
+						ucase.logger.Printf("cannot verify webmention from %s to %s: %s", j.source, j.target, err)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// Verify implements webmention.UseCase.
+func (ucase *webmentionUseCase) Verify(ctx context.Context, source, target *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, ucase.config.Webmention.Timeout)
+	defer cancel()
+
+	id := domain.Mention{Source: source, Target: target}.ID()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.String(), nil)
+	if err != nil {
+		return fmt.Errorf("cannot create source request: %w", err)
+	}
+
+	req.Header.Set(common.HeaderAccept, common.MIMETextHTML)
+
+	resp, err := ucase.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot fetch source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusGone, resp.StatusCode == http.StatusNotFound:
+		// NOTE(toby3d): source was deleted, so as mention of it.
+		if _, err = ucase.mentions.Delete(ctx, id); err != nil {
+			return fmt.Errorf("cannot delete mention: %w", err)
+		}
+
+		return nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("cannot fetch source: got %d status code", resp.StatusCode)
+	}
+
+	body, err := httputil.ReadAll(resp.Body, maxSourceSize)
+	if err != nil && !errors.Is(err, httputil.ErrTooLarge) {
+		return fmt.Errorf("cannot read source body: %w", err)
+	}
+
+	in := domain.Mention{
+		Source: source,
+		Target: target,
+		Type:   domain.MentionTypeMention,
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(common.HeaderContentType))
+
+	var linked bool
+	if mediaType == common.MIMETextHTML {
+		doc, err := html.Parse(strings.NewReader(string(body)))
+		if err != nil {
+			return fmt.Errorf("cannot parse source body: %w", err)
+		}
+
+		linked = parseMention(doc, resp.Request.URL, &in)
+	} else {
+		linked = strings.Contains(string(body), target.String())
+	}
+
+	if !linked {
+		if _, err = ucase.mentions.Delete(ctx, id); err != nil {
+			return fmt.Errorf("cannot delete mention: %w", err)
+		}
+
+		return webmention.ErrNoLink
+	}
+
+	now := time.Now().UTC()
+
+	if _, err = ucase.mentions.Update(ctx, id, func(_ context.Context, m *domain.Mention) (*domain.Mention, error) {
+		// NOTE(toby3d): updates keeps moderation decision.
+		in.CreatedAt, in.Status, in.UpdatedAt = m.CreatedAt, m.Status, now
+
+		return &in, nil
+	}); err == nil {
+		return nil
+	} else if !errors.Is(err, webmention.ErrNotExist) {
+		return fmt.Errorf("cannot update mention: %w", err)
+	}
+
+	in.CreatedAt, in.UpdatedAt = now, now
+	in.Status = domain.MentionStatusApproved
+
+	if ucase.config.Webmention.Moderate {
+		in.Status = domain.MentionStatusPending
+	}
+
+	if err = ucase.mentions.Create(ctx, id, in); err != nil {
+		return fmt.Errorf("cannot create mention: %w", err)
+	}
+
+	return nil
+}
+
+// Fetch implements webmention.UseCase.
+func (ucase *webmentionUseCase) Fetch(ctx context.Context, target *url.URL, status domain.MentionStatus,
+) ([]domain.Mention, error) {
+	var u string
+	if target != nil {
+		u = target.String()
+	}
+
+	mentions, _, err := ucase.mentions.Fetch(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch mentions: %w", err)
+	}
+
+	out := make([]domain.Mention, 0, len(mentions))
+
+	for i := range mentions {
+		if status != domain.MentionStatusUnd && mentions[i].Status != status {
+			continue
+		}
+
+		out = append(out, mentions[i])
+	}
+
+	sortMentions(out)
+
+	return out, nil
+}
+
+// Moderate implements webmention.UseCase.
+func (ucase *webmentionUseCase) Moderate(ctx context.Context, id string, status domain.MentionStatus,
+) (*domain.Mention, error) {
+	if status == domain.MentionStatusUnd {
+		return nil, domain.ErrMentionStatusSyntax
+	}
+
+	out, err := ucase.mentions.Update(ctx, id, func(_ context.Context, m *domain.Mention) (*domain.Mention, error) {
+		m.Status = status
+		m.UpdatedAt = time.Now().UTC()
+
+		return m, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot moderate mention: %w", err)
+	}
+
+	return out, nil
+}
+
+func isHTTP(u *url.URL) bool {
+	return u != nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
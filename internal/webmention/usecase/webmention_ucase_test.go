@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/webmention"
+	webmentionmemoryrepo "source.toby3d.me/toby3d/pub/internal/webmention/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/webmention/usecase"
+)
+
+func TestReceive(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	e := domain.TestEntry(t)
+
+	if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	target := config.HTTP.BaseURL().ResolveReference(e.URL)
+
+	for name, tc := range map[string]struct {
+		source string
+		target string
+		expect error
+	}{
+		"source":    {"ftp://example.net/", target.String(), webmention.ErrSourceSyntax},
+		"target":    {"https://example.net/", "/samples/lipsum", webmention.ErrTargetSyntax},
+		"same":      {target.String(), target.String(), webmention.ErrSameURL},
+		"host":      {"https://example.net/", "https://example.org/samples/lipsum", webmention.ErrTargetNotFound},
+		"not found": {"https://example.net/", "https://example.com/samples/404", webmention.ErrTargetNotFound},
+	} {
+		name, tc := name, tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := usecase.NewWebmentionUseCase(webmention.NewDummyWebmentionRepository(), entries,
+				http.DefaultClient, *config, log.New(io.Discard, "", 0)).
+				Receive(context.Background(), domain.TestURL(t, tc.source), domain.TestURL(t, tc.target))
+			if !errors.Is(err, tc.expect) {
+				t.Errorf("Receive(%s, %s) = %v, want %v", tc.source, tc.target, err, tc.expect)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	target := config.HTTP.BaseURL().ResolveReference(domain.TestEntry(t).URL)
+	body := `<div class="h-entry">
+	<a class="p-author h-card" href="/"><img src="/photo.jpg" alt="">Jane Doe</a>
+	<a class="u-in-reply-to" href="` + target.String() + `">in reply to</a>
+	<time class="dt-published" datetime="2023-01-02T03:04:05Z">Jan 2</time>
+	<div class="e-content">Nice <b>post</b>!</div>
+</div>`
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	source := domain.TestURL(t, srv.URL+"/replies/1")
+	mentions := webmentionmemoryrepo.NewMemoryWebmentionRepository()
+	ucase := usecase.NewWebmentionUseCase(mentions, entrymemoryrepo.NewMemoryEntryRepository(), srv.Client(),
+		*config, log.New(io.Discard, "", 0))
+
+	if err := ucase.Verify(context.Background(), source, target); err != nil {
+		t.Fatal(err)
+	}
+
+	id := domain.Mention{Source: source, Target: target}.ID()
+
+	out, err := mentions.Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := &domain.Mention{
+		CreatedAt:   out.CreatedAt,
+		UpdatedAt:   out.UpdatedAt,
+		PublishedAt: time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Source:      source,
+		Target:      target,
+		Author: domain.Card{
+			URL:   domain.TestURL(t, srv.URL+"/"),
+			Photo: domain.TestURL(t, srv.URL+"/photo.jpg"),
+			Name:  "Jane Doe",
+		},
+		Type:    domain.MentionTypeReply,
+		Status:  domain.MentionStatusPending,
+		Content: "Nice post!",
+	}
+
+	if diff := cmp.Diff(out, expect, cmp.AllowUnexported(domain.MentionType{}, domain.MentionStatus{})); diff != "" {
+		t.Error(diff)
+	}
+
+	approved, err := ucase.Fetch(context.Background(), target, domain.MentionStatusApproved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(approved) != 0 {
+		t.Errorf("expect no approved mentions before moderation, got %d", len(approved))
+	}
+
+	if _, err = ucase.Moderate(context.Background(), id, domain.MentionStatusApproved); err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE(toby3d): update keeps moderation status.
+	body = `<p class="h-entry"><span class="e-content">Mentioned <a href="` + target.String() + `">here</a></span></p>`
+
+	if err = ucase.Verify(context.Background(), source, target); err != nil {
+		t.Fatal(err)
+	}
+
+	if approved, err = ucase.Fetch(context.Background(), target, domain.MentionStatusApproved); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(approved) != 1 || approved[0].Type != domain.MentionTypeMention || approved[0].Content != "Mentioned here" {
+		t.Errorf("expect one updated approved mention, got %+v", approved)
+	}
+
+	body = `<p>No links anymore</p>`
+
+	if err = ucase.Verify(context.Background(), source, target); !errors.Is(err, webmention.ErrNoLink) {
+		t.Errorf("Verify() = %v, want %v", err, webmention.ErrNoLink)
+	}
+
+	if _, err = mentions.Get(context.Background(), id); !errors.Is(err, webmention.ErrNotExist) {
+		t.Errorf("expect deleted mention, got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	config := domain.TestConfig(t)
+	e := domain.TestEntry(t)
+	target := config.HTTP.BaseURL().ResolveReference(e.URL)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, `<p class="h-entry"><a class="u-like-of" href="`+target.String()+`">like</a></p>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	entries := entrymemoryrepo.NewMemoryEntryRepository()
+	if err := entries.Create(context.Background(), e.URL.RequestURI(), *e); err != nil {
+		t.Fatal(err)
+	}
+
+	ucase := usecase.NewWebmentionUseCase(webmentionmemoryrepo.NewMemoryWebmentionRepository(), entries,
+		srv.Client(), *config, log.New(io.Discard, "", 0))
+
+	// NOTE(toby3d): nothing is verified without workers, so queue is full
+	// at some point.
+	var err error
+	for i := 0; err == nil; i++ {
+		if i > 1024 {
+			t.Fatal("expect full queue of received webmentions")
+		}
+
+		err = ucase.Receive(context.Background(), domain.TestURL(t, srv.URL+"/likes/"+strconv.Itoa(i)), target)
+	}
+
+	if !errors.Is(err, webmention.ErrQueueFull) {
+		t.Fatalf("Receive() = %v, want %v", err, webmention.ErrQueueFull)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go ucase.Run(ctx)
+
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		mentions, err := ucase.Fetch(context.Background(), target, domain.MentionStatusUnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(mentions) > 0 {
+			break
+		}
+
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("expect verified mentions of enqueued webmentions")
+		}
+	}
+
+	if err = ucase.Receive(context.Background(), domain.TestURL(t, srv.URL+"/likes/new"), target); err != nil {
+		t.Errorf("Receive() = %v, want nil", err)
+	}
+}
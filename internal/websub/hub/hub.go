@@ -0,0 +1,361 @@
+// Package hub provides a minimal built-in WebSub hub which distributes own
+// feeds to subscribers, so single-node setups do not need third-party hubs.
+package hub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+)
+
+type (
+	Hub struct {
+		mutex         *sync.RWMutex
+		subscriptions map[string]subscription
+		client        *http.Client
+		fetcher       *http.Client
+		logger        *log.Logger
+		config        domain.Config
+	}
+
+	subscription struct {
+		expiresAt time.Time
+		callback  *url.URL
+		topic     *url.URL
+		secret    string
+	}
+
+	request struct {
+		callback *url.URL
+		topic    *url.URL
+		mode     string
+		secret   string
+		lease    time.Duration
+	}
+
+	Error struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}
+)
+
+const (
+	maxSecretSize  int   = 200
+	maxContentSize int64 = 10 * 1024 * 1024 // 10mb
+)
+
+// HeaderXHubSignature contains HMAC signature of distributed content.
+const HeaderXHubSignature string = "X-Hub-Signature"
+
+var ErrForbiddenTopic error = errors.New("topic is not served by this hub")
+
+// NewHub creates a new built-in hub. Client used for subscribers callbacks,
+// fetcher used for requesting own topics contents.
+func NewHub(client, fetcher *http.Client, config domain.Config, logger *log.Logger) *Hub {
+	return &Hub{
+		mutex:         new(sync.RWMutex),
+		subscriptions: make(map[string]subscription),
+		client:        client,
+		fetcher:       fetcher,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteError(w, "method MUST be "+http.MethodPost, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := new(request)
+	if err := req.bind(r, h.config); err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	// NOTE(toby3d): intent verification and content distribution must be
+	// outlive the request.
+	ctx := context.WithoutCancel(r.Context())
+
+	switch req.mode {
+	case "publish":
+		go func() {
+			if err := h.Publish(ctx, req.topic); err != nil {
+				h.logger.Printf("cannot publish %s: %s", req.topic, err)
+			}
+		}()
+	case "subscribe", "unsubscribe":
+		go func() {
+			if err := h.verify(ctx, *req); err != nil {
+				h.logger.Printf("cannot verify %s of %s to %s: %s", req.mode, req.callback, req.topic, err)
+			}
+		}()
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Publish implements websub.Hub. It fetches topic content and distributes it
+// to all active subscribers of topic.
+func (h *Hub) Publish(ctx context.Context, topic *url.URL) error {
+	if !strings.EqualFold(topic.Host, h.config.HTTP.Host) {
+		return ErrForbiddenTopic
+	}
+
+	subscribers := h.subscribers(topic)
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	content, contentType, err := h.fetch(ctx, topic)
+	if err != nil {
+		return fmt.Errorf("cannot fetch topic content: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for i := range subscribers {
+		if err = h.deliver(ctx, subscribers[i], content, contentType); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *Hub) verify(ctx context.Context, req request) error {
+	ctx, cancel := context.WithTimeout(ctx, h.config.WebSub.Timeout)
+	defer cancel()
+
+	challenge, err := newChallenge()
+	if err != nil {
+		return fmt.Errorf("cannot create challenge: %w", err)
+	}
+
+	callback := *req.callback
+	query := callback.Query()
+	query.Set("hub.mode", req.mode)
+	query.Set("hub.topic", req.topic.String())
+	query.Set("hub.challenge", challenge)
+
+	if req.mode == "subscribe" {
+		query.Set("hub.lease_seconds", strconv.Itoa(int(req.lease.Seconds())))
+	}
+
+	callback.RawQuery = query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, callback.String(), nil)
+	if err != nil {
+		return fmt.Errorf("cannot create verification request: %w", err)
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("cannot verify intent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := httputil.ReadAll(resp.Body, int64(len(challenge)))
+	if err != nil {
+		return fmt.Errorf("cannot read verification response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || string(body) != challenge {
+		return errors.New("subscriber does not confirm intent")
+	}
+
+	key := req.topic.String() + " " + req.callback.String()
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if req.mode == "unsubscribe" {
+		delete(h.subscriptions, key)
+
+		return nil
+	}
+
+	h.subscriptions[key] = subscription{
+		expiresAt: time.Now().UTC().Add(req.lease),
+		callback:  req.callback,
+		topic:     req.topic,
+		secret:    req.secret,
+	}
+
+	return nil
+}
+
+func (h *Hub) subscribers(topic *url.URL) []subscription {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	now := time.Now().UTC()
+	out := make([]subscription, 0)
+
+	for _, s := range h.subscriptions {
+		if s.topic.String() != topic.String() || now.After(s.expiresAt) {
+			continue
+		}
+
+		out = append(out, s)
+	}
+
+	return out
+}
+
+func (h *Hub) fetch(ctx context.Context, topic *url.URL) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.config.WebSub.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, topic.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot create topic request: %w", err)
+	}
+
+	resp, err := h.fetcher.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot fetch topic: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("cannot fetch topic: got %d status code", resp.StatusCode)
+	}
+
+	content, err := httputil.ReadAll(resp.Body, maxContentSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot read topic: %w", err)
+	}
+
+	return content, resp.Header.Get(common.HeaderContentType), nil
+}
+
+func (h *Hub) deliver(ctx context.Context, s subscription, content []byte, contentType string) error {
+	ctx, cancel := context.WithTimeout(ctx, h.config.WebSub.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.callback.String(),
+		strings.NewReader(string(content)))
+	if err != nil {
+		return fmt.Errorf("cannot create distribution request: %w", err)
+	}
+
+	req.Header.Set(common.HeaderContentType, contentType)
+	websub.Advertise(req.Header, s.topic, h.config.HTTP.BaseURL().JoinPath("websub"))
+
+	if s.secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.secret))
+		_, _ = mac.Write(content)
+		req.Header.Set(HeaderXHubSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot distribute content to %s: %w", s.callback, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusGone:
+		// NOTE(toby3d): subscriber does not want updates anymore.
+		h.mutex.Lock()
+		delete(h.subscriptions, s.topic.String()+" "+s.callback.String())
+		h.mutex.Unlock()
+
+		return nil
+	default:
+		return fmt.Errorf("cannot distribute content to %s: got %d status code", s.callback, resp.StatusCode)
+	}
+}
+
+func (r *request) bind(req *http.Request, config domain.Config) error {
+	if err := req.ParseForm(); err != nil {
+		return fmt.Errorf("cannot parse form: %w", err)
+	}
+
+	rawTopic := req.PostForm.Get("hub.topic")
+
+	switch r.mode = req.PostForm.Get("hub.mode"); r.mode {
+	default:
+		return fmt.Errorf("'hub.mode' MUST be 'subscribe', 'unsubscribe' or 'publish', got '%s'", r.mode)
+	case "publish":
+		if req.PostForm.Has("hub.url") {
+			rawTopic = req.PostForm.Get("hub.url")
+		}
+	case "subscribe", "unsubscribe":
+		var err error
+		if r.callback, err = url.Parse(req.PostForm.Get("hub.callback")); err != nil ||
+			(r.callback.Scheme != "http" && r.callback.Scheme != "https") || r.callback.Host == "" {
+			return fmt.Errorf("'hub.callback' MUST be a valid URL, got '%s'", req.PostForm.Get("hub.callback"))
+		}
+
+		if r.secret = req.PostForm.Get("hub.secret"); len(r.secret) >= maxSecretSize {
+			return fmt.Errorf("'hub.secret' MUST be less than %d bytes", maxSecretSize)
+		}
+
+		r.lease = config.WebSub.Lease
+
+		if seconds, err := strconv.Atoi(req.PostForm.Get("hub.lease_seconds")); err == nil && seconds > 0 &&
+			time.Duration(seconds)*time.Second < r.lease {
+			r.lease = time.Duration(seconds) * time.Second
+		}
+	}
+
+	var err error
+	if r.topic, err = url.Parse(rawTopic); err != nil || rawTopic == "" {
+		return fmt.Errorf("'hub.topic' MUST be a valid URL, got '%s'", rawTopic)
+	}
+
+	if !strings.EqualFold(r.topic.Host, config.HTTP.Host) {
+		return fmt.Errorf("cannot accept '%s': %w", rawTopic, ErrForbiddenTopic)
+	}
+
+	return nil
+}
+
+func newChallenge() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func WriteError(w http.ResponseWriter, description string, status int) {
+	out := &Error{ErrorDescription: description}
+
+	if status == http.StatusBadRequest {
+		out.Error = "invalid_request"
+	}
+
+	w.Header().Set(common.HeaderContentType, common.MIMEApplicationJSONCharsetUTF8)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,133 @@
+package hub_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/websub/hub"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	const (
+		content = `<div class="h-feed"></div>`
+		secret  = "s3cr3t"
+	)
+
+	verified := make(chan url.Values, 1)
+	delivered := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(common.HeaderContentType, common.MIMETextHTMLCharsetUTF8)
+		_, _ = io.WriteString(w, content)
+	})
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			verified <- r.URL.Query()
+			_, _ = io.WriteString(w, r.URL.Query().Get("hub.challenge"))
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		delivered <- r
+		bodies <- string(body)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	config := domain.TestConfig(t)
+	config.HTTP.Proto = "http"
+	config.HTTP.Host = strings.TrimPrefix(srv.URL, "http://")
+
+	h := hub.NewHub(srv.Client(), srv.Client(), *config, log.New(io.Discard, "", 0))
+	topic := srv.URL + "/feed"
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/websub", strings.NewReader(url.Values{
+		"hub.mode":          {"subscribe"},
+		"hub.callback":      {srv.URL + "/callback"},
+		"hub.topic":         {topic},
+		"hub.secret":        {secret},
+		"hub.lease_seconds": {"60"},
+	}.Encode()))
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if resp := w.Result(); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusAccepted)
+	}
+
+	select {
+	case query := <-verified:
+		if query.Get("hub.topic") != topic || query.Get("hub.lease_seconds") != "60" {
+			t.Errorf("unexpected verification request: %v", query)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("intent is not verified")
+	}
+
+	// NOTE(toby3d): wait for storing subscription after verification.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := h.Publish(context.Background(), domain.TestURL(t, topic)); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(delivered) > 0 {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	r := <-delivered
+	body := <-bodies
+
+	if body != content {
+		t.Errorf("expect %q distributed content, got %q", content, body)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(content))
+
+	if expect := "sha256=" + hex.EncodeToString(mac.Sum(nil)); r.Header.Get(hub.HeaderXHubSignature) != expect {
+		t.Errorf("expect %s signature, got %s", expect, r.Header.Get(hub.HeaderXHubSignature))
+	}
+}
+
+func TestHub_ForbiddenTopic(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/websub", strings.NewReader(url.Values{
+		"hub.mode":     {"subscribe"},
+		"hub.callback": {"https://example.net/callback"},
+		"hub.topic":    {"https://example.org/feed"},
+	}.Encode()))
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+	w := httptest.NewRecorder()
+	hub.NewHub(http.DefaultClient, http.DefaultClient, *domain.TestConfig(t), log.New(io.Discard, "", 0)).
+		ServeHTTP(w, req)
+
+	if resp := w.Result(); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("%s %s = %d, want %d", req.Method, req.RequestURI, resp.StatusCode, http.StatusBadRequest)
+	}
+}
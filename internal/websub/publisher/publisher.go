@@ -0,0 +1,157 @@
+// Package publisher provides a WebSub publisher which pings hubs about changed
+// feeds after entries creation, updating, deletion and undeletion.
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+)
+
+type (
+	Publisher struct {
+		logger *log.Logger
+		queue  chan job
+		hubs   []websub.Hub
+		config domain.Config
+	}
+
+	job struct {
+		hub     websub.Hub
+		topic   *url.URL
+		attempt int
+	}
+
+	remoteHub struct {
+		client   *http.Client
+		endpoint *url.URL
+		timeout  time.Duration
+	}
+)
+
+const queueSize int = 256
+
+func NewPublisher(config domain.Config, logger *log.Logger, hubs ...websub.Hub) *Publisher {
+	return &Publisher{
+		logger: logger,
+		config: config,
+		hubs:   hubs,
+		queue:  make(chan job, queueSize),
+	}
+}
+
+// NewRemoteHub creates a websub.Hub which pings external hub endpoint.
+func NewRemoteHub(client *http.Client, endpoint *url.URL, timeout time.Duration) websub.Hub {
+	return &remoteHub{
+		client:   client,
+		endpoint: endpoint,
+		timeout:  timeout,
+	}
+}
+
+// Handle implements entry.Hook. It enqueues pings of all hubs for every feed
+// which contains entry before or after change. Feeds contains only published
+// entries, so unpublished states are skipped.
+func (p *Publisher) Handle(_ context.Context, _ domain.Action, before, after *domain.Entry) {
+	topics := make(map[string]*url.URL)
+
+	for _, state := range []*domain.Entry{before, after} {
+		if state == nil || state.URL == nil || !state.IsPublished() {
+			continue
+		}
+
+		for _, topic := range p.Topics(*state) {
+			topics[topic.String()] = topic
+		}
+	}
+
+	for _, topic := range topics {
+		for _, hub := range p.hubs {
+			p.enqueue(job{hub: hub, topic: topic})
+		}
+	}
+}
+
+// Topics returns URLs of all feeds which contains provided entry: the home,
+// tag and archive pages and site, tag and post type feeds in every format.
+func (p *Publisher) Topics(e domain.Entry) []*url.URL {
+	return append(feed.Pages(p.config, e), feed.URLs(p.config, e)...)
+}
+
+// Run pings hubs until ctx is done. Failed pings are retried with exponential
+// backoff.
+func (p *Publisher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-p.queue:
+			err := j.hub.Publish(ctx, j.topic)
+			if err == nil {
+				continue
+			}
+
+			if j.attempt >= p.config.WebSub.Retries {
+				p.logger.Printf("cannot ping hub about %s, give up: %s", j.topic, err)
+
+				continue
+			}
+
+			delay := p.config.WebSub.Backoff << j.attempt
+			j.attempt++
+
+			time.AfterFunc(delay, func() { p.enqueue(j) })
+		}
+	}
+}
+
+func (p *Publisher) enqueue(j job) {
+	select {
+	case p.queue <- j:
+	default:
+		p.logger.Printf("websub queue is full, drop ping about %s", j.topic)
+	}
+}
+
+// Publish implements websub.Hub.
+func (h *remoteHub) Publish(ctx context.Context, topic *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	body := url.Values{
+		"hub.mode": []string{"publish"},
+		"hub.url":  []string{topic.String()},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint.String(),
+		strings.NewReader(body.Encode()))
+	if err != nil {
+		return fmt.Errorf("cannot create ping request: %w", err)
+	}
+
+	req.Header.Set(common.HeaderContentType, common.MIMEApplicationForm)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot ping hub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("cannot ping hub %s: got %d status code", h.endpoint, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package publisher_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+	"source.toby3d.me/toby3d/pub/internal/websub/publisher"
+)
+
+func TestPublisher_Handle(t *testing.T) {
+	t.Parallel()
+
+	mutex := new(sync.Mutex)
+	failures := 1
+	pings := make([]url.Values, 0)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if failures > 0 {
+			failures--
+
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_ = r.ParseForm()
+		pings = append(pings, r.PostForm)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := domain.TestConfig(t)
+	e := domain.TestEntry(t)
+	local := make(chan *url.URL, 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	p := publisher.NewPublisher(*config, log.New(io.Discard, "", 0),
+		publisher.NewRemoteHub(srv.Client(), domain.TestURL(t, srv.URL), time.Second),
+		websub.HubFunc(func(_ context.Context, topic *url.URL) error {
+			local <- topic
+
+			return nil
+		}))
+	go p.Run(ctx)
+
+	p.Handle(ctx, domain.ActionCreate, nil, e)
+
+	expect := make([]string, 0)
+	for _, topic := range p.Topics(*e) {
+		expect = append(expect, topic.String())
+	}
+
+	sort.Strings(expect)
+
+	received := make([]string, 0, len(expect))
+	for len(received) < len(expect) {
+		select {
+		case topic := <-local:
+			received = append(received, topic.String())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("local hub is pinged %d times, expect %d", len(received), len(expect))
+		}
+	}
+
+	sort.Strings(received)
+
+	if diff := cmp.Diff(received, expect); diff != "" {
+		t.Error(diff)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mutex.Lock()
+		n := len(pings)
+		mutex.Unlock()
+
+		if n >= len(expect) {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	pinged := make([]string, 0, len(pings))
+	for i := range pings {
+		if mode := pings[i].Get("hub.mode"); mode != "publish" {
+			t.Errorf("expect 'publish' hub.mode, got '%s'", mode)
+		}
+
+		pinged = append(pinged, pings[i].Get("hub.url"))
+	}
+
+	sort.Strings(pinged)
+
+	if diff := cmp.Diff(pinged, expect); diff != "" {
+		t.Error(diff)
+	}
+
+	if !contains(expect, config.HTTP.BaseURL().String()) ||
+		!contains(expect, config.HTTP.BaseURL().JoinPath("feed", "atom").String()) {
+		t.Errorf("expect home page and main feed in topics, got %v", expect)
+	}
+}
+
+func TestPublisher_Handle_Draft(t *testing.T) {
+	t.Parallel()
+
+	published := domain.TestEntry(t)
+	draft := domain.TestEntry(t)
+	draft.Status = domain.PostStatusDraft
+	local := make(chan *url.URL, 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	p := publisher.NewPublisher(*domain.TestConfig(t), log.New(io.Discard, "", 0),
+		websub.HubFunc(func(_ context.Context, topic *url.URL) error {
+			local <- topic
+
+			return nil
+		}))
+	go p.Run(ctx)
+
+	p.Handle(ctx, domain.ActionCreate, nil, draft)
+	p.Handle(ctx, domain.ActionUpdate, published, draft)
+
+	expect := len(p.Topics(*published))
+	received := 0
+
+	for timeout := time.After(5 * time.Second); received < expect; {
+		select {
+		case <-local:
+			received++
+		case <-timeout:
+			t.Fatalf("local hub is pinged %d times, expect %d", received, expect)
+		}
+	}
+
+	select {
+	case topic := <-local:
+		t.Errorf("unexpected ping of %s by draft", topic)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func contains(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRemoteHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := publisher.NewRemoteHub(srv.Client(), domain.TestURL(t, srv.URL), time.Second).
+		Publish(context.Background(), domain.TestURL(t, "https://example.com/"))
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expect status code error, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package websub provides a common mechanism for communication between
+// publishers of any kind of Web content and their subscribers, based on HTTP
+// web hooks.
+//
+// See: https://www.w3.org/TR/websub/
+package websub
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+// Hub notifies subscribers of topic about it's changes.
+type Hub interface {
+	Publish(ctx context.Context, topic *url.URL) error
+}
+
+// HubFunc is an adapter to allow the use of ordinary functions as Hub.
+type HubFunc func(ctx context.Context, topic *url.URL) error
+
+// Publish implements Hub.
+func (f HubFunc) Publish(ctx context.Context, topic *url.URL) error {
+	return f(ctx, topic)
+}
+
+// Endpoints returns all configured hubs endpoints, including built-in hub if
+// it's enabled. Invalid endpoints are skipped.
+func Endpoints(config domain.Config) []*url.URL {
+	out := make([]*url.URL, 0, len(config.WebSub.Hubs)+1)
+
+	for _, raw := range config.WebSub.Hubs {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			continue
+		}
+
+		out = append(out, u)
+	}
+
+	if config.WebSub.Hub {
+		out = append(out, config.HTTP.BaseURL().JoinPath("websub"))
+	}
+
+	return out
+}
+
+// Advertise adds Link headers with hubs and self topic URLs into h, so
+// subscribers can discover where to subscribe.
+func Advertise(h http.Header, self *url.URL, hubs ...*url.URL) {
+	if len(hubs) == 0 {
+		return
+	}
+
+	for _, hub := range hubs {
+		h.Add(common.HeaderLink, `<`+hub.String()+`>; rel="hub"`)
+	}
+
+	h.Add(common.HeaderLink, `<`+self.String()+`>; rel="self"`)
+}
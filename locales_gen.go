@@ -39,30 +39,100 @@ func init() {
 }
 
 var messageKeyToIndex = map[string]int{
-	"Content":              1,
-	"Name":                 0,
-	"Published after":      3,
-	"Published exactly at": 2,
-	"Send":                 5,
-	"Tags":                 4,
+	"Also on":                              19,
+	"Archive for %s":                       25,
+	"Bookmarked":                           16,
+	"Content":                              1,
+	"Delete permanently":                   39,
+	"Deleted %s":                           37,
+	"Deleted entries are purged after %s.": 35,
+	"Format":                               2,
+	"Gone":                                 21,
+	"In reply to":                          10,
+	"Liked":                                11,
+	"Likes":                                13,
+	"Markdown":                             4,
+	"Name":                                 0,
+	"Newer":                                27,
+	"Next":                                 33,
+	"Not Found":                            20,
+	"Note":                                 9,
+	"Nothing found.":                       31,
+	"Nothing here yet.":                    26,
+	"Older":                                28,
+	"Plain text":                           3,
+	"Previous":                             32,
+	"Published %s":                         17,
+	"Published after":                      6,
+	"Published exactly at":                 5,
+	"Replies":                              15,
+	"Reposted":                             12,
+	"Reposts":                              14,
+	"Restore":                              38,
+	"Search":                               30,
+	"Search results for \"%s\"":            29,
+	"Send":                                 8,
+	"Tagged #%s":                           24,
+	"Tags":                                 7,
+	"This page does not exist.":            22,
+	"This page has been deleted.":          23,
+	"Trash":                                34,
+	"Trash is empty.":                      36,
+	"Updated %s":                           18,
 }
 
-var enIndex = []uint32{ // 7 elements
-	0x00000000, 0x00000005, 0x0000000d, 0x00000022,
-	0x00000032, 0x00000037, 0x0000003c,
-} // Size: 52 bytes
+var enIndex = []uint32{ // 41 elements
+	// Entry 0 - 1F
+	0x00000000, 0x00000005, 0x0000000d, 0x00000014,
+	0x0000001f, 0x00000028, 0x0000003d, 0x0000004d,
+	0x00000052, 0x00000057, 0x0000005c, 0x00000068,
+	0x0000006e, 0x00000077, 0x0000007d, 0x00000085,
+	0x0000008d, 0x00000098, 0x000000a5, 0x000000b0,
+	0x000000b8, 0x000000c2, 0x000000c7, 0x000000e1,
+	0x000000fd, 0x00000108, 0x00000117, 0x00000129,
+	0x0000012f, 0x00000135, 0x0000014d, 0x00000154,
+	// Entry 20 - 3F
+	0x00000163, 0x0000016c, 0x00000171, 0x00000177,
+	0x0000019c, 0x000001ac, 0x000001b7, 0x000001bf,
+	0x000001d2,
+} // Size: 188 bytes
 
-const enData string = "" + // Size: 60 bytes
-	"\x02Name\x02Content\x02Published exactly at\x02Published after\x02Tags" +
-	"\x02Send"
+const enData string = "" + // Size: 466 bytes
+	"\x02Name\x02Content\x02Format\x02Plain text\x02Markdown\x02Published exa" +
+	"ctly at\x02Published after\x02Tags\x02Send\x02Note\x02In reply to\x02Lik" +
+	"ed\x02Reposted\x02Likes\x02Reposts\x02Replies\x02Bookmarked\x02Published" +
+	" %s\x02Updated %s\x02Also on\x02Not Found\x02Gone\x02This page does not " +
+	"exist.\x02This page has been deleted.\x02Tagged #%s\x02Archive for %s" +
+	"\x02Nothing here yet.\x02Newer\x02Older\x02Search results for \x22%s\x22" +
+	"\x02Search\x02Nothing found.\x02Previous\x02Next\x02Trash\x02Deleted ent" +
+	"ries are purged after %s.\x02Trash is empty.\x02Deleted %s\x02Restore" +
+	"\x02Delete permanently"
 
-var ruIndex = []uint32{ // 7 elements
-	0x00000000, 0x00000011, 0x00000026, 0x0000004d,
-	0x00000071, 0x0000007a, 0x0000008d,
-} // Size: 52 bytes
+var ruIndex = []uint32{ // 41 elements
+	// Entry 0 - 1F
+	0x00000000, 0x00000011, 0x00000026, 0x00000033,
+	0x0000004d, 0x00000056, 0x0000007d, 0x000000a1,
+	0x000000aa, 0x000000bd, 0x000000cc, 0x000000df,
+	0x000000f6, 0x00000103, 0x00000114, 0x00000123,
+	0x00000130, 0x00000146, 0x00000162, 0x00000178,
+	0x00000186, 0x0000019a, 0x000001a9, 0x000001e0,
+	0x00000211, 0x00000230, 0x00000243, 0x0000026c,
+	0x00000277, 0x00000284, 0x000002ad, 0x000002b8,
+	// Entry 20 - 3F
+	0x000002da, 0x000002e5, 0x000002f0, 0x000002ff,
+	0x00000341, 0x0000035c, 0x0000036e, 0x00000387,
+	0x000003a7,
+} // Size: 188 bytes
 
-const ruData string = "" + // Size: 141 bytes
-	"\x02Название\x02Содержимое\x02Опубликовать точно в\x02Опубликовать через" +
-	"\x02Тэги\x02Отправить"
+const ruData string = "" + // Size: 935 bytes
+	"\x02Название\x02Содержимое\x02Формат\x02Простой текст\x02Markdown\x02Опу" +
+	"бликовать точно в\x02Опубликовать через\x02Тэги\x02Отправить\x02Заметка" +
+	"\x02В ответ на\x02Понравилось\x02Репост\x02Нравится\x02Репосты\x02Ответы" +
+	"\x02В закладках\x02Опубликовано %s\x02Обновлено %s\x02Также в\x02Не найд" +
+	"ено\x02Удалено\x02Такой страницы не существует.\x02Эта страница была уд" +
+	"алена.\x02Записи с тегом #%s\x02Архив за %s\x02Здесь пока ничего нет." +
+	"\x02Новее\x02Старее\x02Результаты поиска «%s»\x02Поиск\x02Ничего не найд" +
+	"ено.\x02Назад\x02Далее\x02Корзина\x02Удалённые записи стираются через %" +
+	"s.\x02Корзина пуста.\x02Удалено %s\x02Восстановить\x02Удалить навсегда"
 
-	// Total table size 305 bytes (0KiB); checksum: 8DEE5E28
+	// Total table size 1777 bytes (1KiB); checksum: DEDE915C
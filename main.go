@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -20,12 +21,54 @@ import (
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 
+	"source.toby3d.me/toby3d/pub/internal/auth"
+	"source.toby3d.me/toby3d/pub/internal/citation"
+	citationucase "source.toby3d.me/toby3d/pub/internal/citation/usecase"
 	"source.toby3d.me/toby3d/pub/internal/common"
+	"source.toby3d.me/toby3d/pub/internal/contact"
+	contactmemoryrepo "source.toby3d.me/toby3d/pub/internal/contact/repository/memory"
+	contactucase "source.toby3d.me/toby3d/pub/internal/contact/usecase"
 	"source.toby3d.me/toby3d/pub/internal/domain"
+	entryhttpdelivery "source.toby3d.me/toby3d/pub/internal/entry/delivery/http"
+	entrywebdelivery "source.toby3d.me/toby3d/pub/internal/entry/delivery/web"
+	"source.toby3d.me/toby3d/pub/internal/entry/purger"
+	entrymemoryrepo "source.toby3d.me/toby3d/pub/internal/entry/repository/memory"
+	entryucase "source.toby3d.me/toby3d/pub/internal/entry/usecase"
+	"source.toby3d.me/toby3d/pub/internal/feed"
+	feedhttpdelivery "source.toby3d.me/toby3d/pub/internal/feed/delivery/http"
+	feeducase "source.toby3d.me/toby3d/pub/internal/feed/usecase"
+	"source.toby3d.me/toby3d/pub/internal/httputil"
+	"source.toby3d.me/toby3d/pub/internal/idempotency"
+	idempotencymemoryrepo "source.toby3d.me/toby3d/pub/internal/idempotency/repository/memory"
+	"source.toby3d.me/toby3d/pub/internal/media/collector"
 	mediahttpdelivery "source.toby3d.me/toby3d/pub/internal/media/delivery/http"
 	mediamemoryrepo "source.toby3d.me/toby3d/pub/internal/media/repository/memory"
 	mediaucase "source.toby3d.me/toby3d/pub/internal/media/usecase"
+	"source.toby3d.me/toby3d/pub/internal/redirect"
+	redirectmemoryrepo "source.toby3d.me/toby3d/pub/internal/redirect/repository/memory"
+	redirectucase "source.toby3d.me/toby3d/pub/internal/redirect/usecase"
+	"source.toby3d.me/toby3d/pub/internal/revision"
+	revisionmemoryrepo "source.toby3d.me/toby3d/pub/internal/revision/repository/memory"
+	revisionucase "source.toby3d.me/toby3d/pub/internal/revision/usecase"
+	"source.toby3d.me/toby3d/pub/internal/sanitize"
+	"source.toby3d.me/toby3d/pub/internal/search"
+	searchmemoryrepo "source.toby3d.me/toby3d/pub/internal/search/repository/memory"
+	searchucase "source.toby3d.me/toby3d/pub/internal/search/usecase"
+	"source.toby3d.me/toby3d/pub/internal/shortlink"
+	shortlinkhttpdelivery "source.toby3d.me/toby3d/pub/internal/shortlink/delivery/http"
+	shortlinkmemoryrepo "source.toby3d.me/toby3d/pub/internal/shortlink/repository/memory"
+	shortlinkucase "source.toby3d.me/toby3d/pub/internal/shortlink/usecase"
+	"source.toby3d.me/toby3d/pub/internal/syndication"
+	"source.toby3d.me/toby3d/pub/internal/syndication/target/mastodon"
+	syndicationucase "source.toby3d.me/toby3d/pub/internal/syndication/usecase"
 	"source.toby3d.me/toby3d/pub/internal/urlutil"
+	webmentionhttpdelivery "source.toby3d.me/toby3d/pub/internal/webmention/delivery/http"
+	webmentionmemoryrepo "source.toby3d.me/toby3d/pub/internal/webmention/repository/memory"
+	webmentionsender "source.toby3d.me/toby3d/pub/internal/webmention/sender"
+	webmentionucase "source.toby3d.me/toby3d/pub/internal/webmention/usecase"
+	"source.toby3d.me/toby3d/pub/internal/websub"
+	websubhub "source.toby3d.me/toby3d/pub/internal/websub/hub"
+	websubpublisher "source.toby3d.me/toby3d/pub/internal/websub/publisher"
 	"source.toby3d.me/toby3d/pub/web/template"
 )
 
@@ -49,30 +92,153 @@ func init() {
 }
 
 func main() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	mediaRepo := mediamemoryrepo.NewMemoryMediaRepository()
-	mediaUseCase := mediaucase.NewMediaUseCase(mediaRepo)
-	mediaHandler := mediahttpdelivery.NewHandler(mediaUseCase, *config)
+	mediaUseCase := mediaucase.NewMediaUseCase(mediaRepo, httputil.NewClient(config.Media.RemoteTimeout), *config)
+	idempotencyMiddleware := idempotency.NewMiddleware(idempotencymemoryrepo.NewMemoryIdempotencyRepository(),
+		config.Idempotency, logger)
+	authMiddleware := auth.NewMiddleware(config.Token)
+	mediaHandler := authMiddleware.Handle(idempotencyMiddleware.Handle(mediahttpdelivery.NewHandler(mediaUseCase,
+		*config)))
+	entryRepo := entrymemoryrepo.NewMemoryEntryRepository()
+	webmentionSender := webmentionsender.NewSender(httputil.NewClient(config.Webmention.Timeout), *config, logger)
+	websubHub := websubhub.NewHub(httputil.NewClient(config.WebSub.Timeout), &http.Client{
+		Timeout: config.WebSub.Timeout,
+	}, *config, logger)
+	websubHubs := make([]websub.Hub, 0)
+
+	for _, endpoint := range websub.Endpoints(*config) {
+		if config.WebSub.Hub && endpoint.Host == config.HTTP.Host {
+			websubHubs = append(websubHubs, websubHub)
+
+			continue
+		}
+
+		// NOTE(toby3d): hubs are configured by owner, so they are
+		// trusted and can be served from private network.
+		websubHubs = append(websubHubs, websubpublisher.NewRemoteHub(&http.Client{
+			Timeout: config.WebSub.Timeout,
+		}, endpoint, config.WebSub.Timeout))
+	}
+
+	websubPublisher := websubpublisher.NewPublisher(*config, logger, websubHubs...)
+	searchUseCase := searchucase.NewSearchUseCase(searchmemoryrepo.NewMemorySearchRepository(), entryRepo)
+	citationUseCase := citationucase.NewCitationUseCase(entryRepo, httputil.NewClient(config.Citation.Timeout),
+		*config, logger)
+	revisionRepo := revisionmemoryrepo.NewMemoryRevisionRepository()
+	redirectRepo := redirectmemoryrepo.NewMemoryRedirectRepository()
+	shortlinkUseCase := shortlinkucase.NewShortlinkUseCase(shortlinkmemoryrepo.NewMemoryShortlinkRepository(),
+		*config)
+	mediaCollector := collector.NewCollector(mediaRepo, entryRepo, *config, logger)
+	entryUseCase := entryucase.NewEntryUseCase(entryRepo, revision.NewHook(revisionRepo, logger),
+		redirect.NewHook(redirectRepo, logger), shortlink.NewHook(shortlinkUseCase, logger),
+		search.NewHook(searchUseCase, logger), citation.NewHook(citationUseCase, logger), webmentionSender,
+		websubPublisher, mediaCollector)
+	redirectUseCase := redirectucase.NewRedirectUseCase(redirectRepo)
+	entryPurger := purger.NewPurger(entryUseCase, config.Trash, logger)
+	revisionUseCase := revisionucase.NewRevisionUseCase(revisionRepo, entryUseCase)
+	syndicationTargets := make([]syndication.Target, 0)
+
+	if config.Syndication.Mastodon.Instance != "" {
+		// NOTE(toby3d): instance is configured by owner, so it is
+		// trusted and can be served from private network.
+		target, err := mastodon.NewMastodon(&http.Client{Timeout: config.Syndication.Timeout}, *config)
+		if err != nil {
+			logger.Fatalln("cannot create mastodon syndication target:", err)
+		}
 
+		syndicationTargets = append(syndicationTargets, target)
+	}
+
+	syndicationUseCase := syndicationucase.NewSyndicationUseCase(entryRepo, syndicationTargets...)
+	contactUseCase := contactucase.NewContactUseCase(contactmemoryrepo.NewMemoryContactRepository())
+
+	if config.Contacts != "" {
+		if err := importContacts(ctx, contactUseCase, config.Contacts); err != nil {
+			logger.Fatalln("cannot import contacts:", err)
+		}
+	}
+
+	entryHandler := authMiddleware.Handle(idempotencyMiddleware.Handle(entryhttpdelivery.NewHandler(entryUseCase,
+		mediaUseCase, syndicationUseCase, contactUseCase, searchUseCase, revisionUseCase, redirectUseCase,
+		shortlinkUseCase, citationUseCase, sanitize.NewPolicy(config.Sanitize))))
 	matcher := language.NewMatcher(message.DefaultCatalog.Languages())
+	webmentionUseCase := webmentionucase.NewWebmentionUseCase(webmentionmemoryrepo.NewMemoryWebmentionRepository(),
+		entryRepo, httputil.NewClient(config.Webmention.Timeout), *config, logger)
+	entryPageHandler := entrywebdelivery.NewHandler(entryUseCase, searchUseCase, redirectUseCase, webmentionUseCase,
+		matcher, *config)
+	entryTrashHandler := authMiddleware.Handle(entrywebdelivery.NewTrashHandler(entryUseCase, matcher, *config))
+	webmentionHandler := webmentionhttpdelivery.NewHandler(webmentionUseCase)
+	webmentionModerationHandler := authMiddleware.Handle(webmentionhttpdelivery.NewModerationHandler(
+		webmentionUseCase))
+	webmentionEndpoint := config.HTTP.BaseURL().JoinPath("webmention")
+	feedHandler := feedhttpdelivery.NewHandler(feeducase.NewFeedUseCase(entryRepo), *config)
+	shortlinkHandler := shortlinkhttpdelivery.NewHandler(shortlinkUseCase, *config)
+
 	server := http.Server{
 		ErrorLog: logger,
 		Addr:     config.HTTP.Bind,
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if shortlink.Match(*config, r) {
+				shortlinkHandler.ServeHTTP(w, r)
+
+				return
+			}
+
 			head, _ := urlutil.ShiftPath(r.RequestURI)
 
+			w.Header().Add(common.HeaderLink, `<`+webmentionEndpoint.String()+`>; rel="webmention"`)
+
 			switch head {
 			default:
-				tags, _, err := language.ParseAcceptLanguage(r.Header.Get(common.HeaderAcceptLanguage))
-				if err != nil {
-					tags = append(tags, language.English)
+				entryPageHandler.ServeHTTP(w, r)
+			case "":
+				for _, alternate := range []struct {
+					format    feed.Format
+					mediaType string
+				}{
+					{format: feed.FormatAtom, mediaType: common.MIMEApplicationAtomXML},
+					{format: feed.FormatRSS, mediaType: common.MIMEApplicationRSSXML},
+					{format: feed.FormatJSON, mediaType: common.MIMEApplicationFeedJSON},
+				} {
+					w.Header().Add(common.HeaderLink, `<`+feed.URL(*config, alternate.format,
+						feed.Options{}).String()+`>; rel="alternate"; type="`+alternate.mediaType+`"`)
 				}
 
-				tag, _, _ := matcher.Match(tags...)
+				entryPageHandler.ServeHTTP(w, r)
+			case "editor":
+				_, tail := urlutil.ShiftPath(r.URL.Path)
 
-				template.WriteTemplate(w, template.NewPageEditor(template.NewBaseOf(tag)))
+				switch head, _ := urlutil.ShiftPath(tail); head {
+				default:
+					template.WriteTemplate(w, template.NewPageEditor(template.NewBaseOf(
+						entrywebdelivery.Language(r, matcher))))
+				case "trash":
+					entryTrashHandler.ServeHTTP(w, r)
+				case "webmention":
+					webmentionModerationHandler.ServeHTTP(w, r)
+				}
+			case "api":
+				// NOTE(toby3d): the only author of changes is the owner
+				// of site, clients are known by their agents.
+				entryHandler.ServeHTTP(w, r.WithContext(revision.NewContext(r.Context(),
+					config.HTTP.BaseURL().String(), r.UserAgent())))
+			case "feed":
+				feedHandler.ServeHTTP(w, r)
 			case "media":
 				mediaHandler.ServeHTTP(w, r)
+			case "webmention":
+				webmentionHandler.ServeHTTP(w, r)
+			case "websub":
+				if !config.WebSub.Hub {
+					http.NotFound(w, r)
+
+					return
+				}
+
+				websubHub.ServeHTTP(w, r)
 			}
 		}),
 	}
@@ -100,9 +266,17 @@ func main() {
 		}
 	}()
 
+	go mediaCollector.Run(ctx)
+	go entryPurger.Run(ctx)
+	go webmentionSender.Run(ctx)
+	go webmentionUseCase.Run(ctx)
+	go websubPublisher.Run(ctx)
+	go idempotencyMiddleware.Run(ctx)
+
 	<-done
+	cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
+	if err := server.Shutdown(context.Background()); err != nil {
 		logger.Fatalln("failed shutdown of server:", err)
 	}
 
@@ -122,3 +296,25 @@ func main() {
 		logger.Fatalln("could not write memory profile:", err)
 	}
 }
+
+// importContacts creates contacts from JSON file on provided path.
+func importContacts(ctx context.Context, contacts contact.UseCase, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot open contacts file: %w", err)
+	}
+	defer f.Close()
+
+	src, err := contact.Decode(f)
+	if err != nil {
+		return fmt.Errorf("cannot read contacts file: %w", err)
+	}
+
+	for i := range src {
+		if err = contacts.Create(ctx, src[i]); err != nil {
+			return fmt.Errorf("cannot import contact #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
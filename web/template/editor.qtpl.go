@@ -148,16 +148,38 @@ func (pe *PageEditor) streambody(qw422016 *qt422016.Writer) {
     <label>
       `)
 //line web/template/editor.qtpl:59
-	pe.streamt(qw422016, `Published exactly at`)
+	pe.streamt(qw422016, `Format`)
 //line web/template/editor.qtpl:59
 	qw422016.N().S(`
+      <select name="mp-content-type">
+        <option value="text/plain" selected>`)
+//line web/template/editor.qtpl:61
+	pe.streamt(qw422016, `Plain text`)
+//line web/template/editor.qtpl:61
+	qw422016.N().S(`</option>
+        <option value="markdown">`)
+//line web/template/editor.qtpl:62
+	pe.streamt(qw422016, `Markdown`)
+//line web/template/editor.qtpl:62
+	qw422016.N().S(`</option>
+      </select>
+    </label>
+  </div>
+
+  <div>
+    <label>
+      `)
+//line web/template/editor.qtpl:69
+	pe.streamt(qw422016, `Published exactly at`)
+//line web/template/editor.qtpl:69
+	qw422016.N().S(`
       <input type="datetime-local"
              name="published"
              min="1970-01-01T00:00:00"
              value="`)
-//line web/template/editor.qtpl:63
+//line web/template/editor.qtpl:73
 	qw422016.E().S(pe.now.Format(`2006-01-02T15:04:05`))
-//line web/template/editor.qtpl:63
+//line web/template/editor.qtpl:73
 	qw422016.N().S(`"
              step="1" />
     </label>
@@ -166,9 +188,9 @@ func (pe *PageEditor) streambody(qw422016 *qt422016.Writer) {
   <div>
     <label>
       `)
-//line web/template/editor.qtpl:70
+//line web/template/editor.qtpl:80
 	pe.streamt(qw422016, `Published after`)
-//line web/template/editor.qtpl:70
+//line web/template/editor.qtpl:80
 	qw422016.N().S(`
       <input type="text"
              name="published"
@@ -180,9 +202,9 @@ func (pe *PageEditor) streambody(qw422016 *qt422016.Writer) {
   <div>
     <label>
       `)
-//line web/template/editor.qtpl:80
+//line web/template/editor.qtpl:90
 	pe.streamt(qw422016, `Tags`)
-//line web/template/editor.qtpl:80
+//line web/template/editor.qtpl:90
 	qw422016.N().S(`
       <input type="text"
              name="category"
@@ -194,39 +216,39 @@ func (pe *PageEditor) streambody(qw422016 *qt422016.Writer) {
   <div>
     <button type="submit">
       `)
-//line web/template/editor.qtpl:90
+//line web/template/editor.qtpl:100
 	pe.streamt(qw422016, `Send`)
-//line web/template/editor.qtpl:90
+//line web/template/editor.qtpl:100
 	qw422016.N().S(`
     </button>
   </div>
 </form>
 `)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 }
 
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 func (pe *PageEditor) writebody(qq422016 qtio422016.Writer) {
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	pe.streambody(qw422016)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	qt422016.ReleaseWriter(qw422016)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 }
 
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 func (pe *PageEditor) body() string {
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	qb422016 := qt422016.AcquireByteBuffer()
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	pe.writebody(qb422016)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	qs422016 := string(qb422016.B)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	qt422016.ReleaseByteBuffer(qb422016)
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 	return qs422016
-//line web/template/editor.qtpl:94
+//line web/template/editor.qtpl:104
 }
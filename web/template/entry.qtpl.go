@@ -0,0 +1,1079 @@
+// Code generated by qtc from "entry.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line web/template/entry.qtpl:1
+package template
+
+//line web/template/entry.qtpl:1
+import (
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+//line web/template/entry.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line web/template/entry.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line web/template/entry.qtpl:9
+type PageEntry struct {
+	*BaseOf
+	entry      *domain.Entry
+	permalink  *url.URL
+	webmention *url.URL
+	likes      []domain.Mention
+	reposts    []domain.Mention
+	replies    []domain.Mention
+}
+
+func NewPageEntry(base *BaseOf, e *domain.Entry, mentions []domain.Mention, permalink, webmention *url.URL,
+) *PageEntry {
+	out := &PageEntry{
+		BaseOf:     base,
+		entry:      e,
+		permalink:  permalink,
+		webmention: webmention,
+	}
+
+	for i := range mentions {
+		switch mentions[i].Type {
+		case domain.MentionTypeLike:
+			out.likes = append(out.likes, mentions[i])
+		case domain.MentionTypeRepost:
+			out.reposts = append(out.reposts, mentions[i])
+		case domain.MentionTypeReply:
+			out.replies = append(out.replies, mentions[i])
+		}
+	}
+
+	return out
+}
+
+//line web/template/entry.qtpl:43
+func (pe *PageEntry) streamtitle(qw422016 *qt422016.Writer) {
+//line web/template/entry.qtpl:43
+	qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:44
+	if pe.entry.Title != "" {
+//line web/template/entry.qtpl:44
+		qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:45
+		qw422016.E().S(pe.entry.Title)
+//line web/template/entry.qtpl:45
+		qw422016.N().S(` — Micropub
+`)
+//line web/template/entry.qtpl:46
+	} else {
+//line web/template/entry.qtpl:46
+		qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:47
+		pe.streamt(qw422016, `Note`)
+//line web/template/entry.qtpl:47
+		qw422016.N().S(` — Micropub
+`)
+//line web/template/entry.qtpl:48
+	}
+//line web/template/entry.qtpl:48
+	qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:49
+}
+
+//line web/template/entry.qtpl:49
+func (pe *PageEntry) writetitle(qq422016 qtio422016.Writer) {
+//line web/template/entry.qtpl:49
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:49
+	pe.streamtitle(qw422016)
+//line web/template/entry.qtpl:49
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:49
+}
+
+//line web/template/entry.qtpl:49
+func (pe *PageEntry) title() string {
+//line web/template/entry.qtpl:49
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:49
+	pe.writetitle(qb422016)
+//line web/template/entry.qtpl:49
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:49
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:49
+	return qs422016
+//line web/template/entry.qtpl:49
+}
+
+//line web/template/entry.qtpl:51
+func (pe *PageEntry) streamhead(qw422016 *qt422016.Writer) {
+//line web/template/entry.qtpl:51
+	qw422016.N().S(`
+<link rel="canonical"
+      href="`)
+//line web/template/entry.qtpl:53
+	qw422016.E().S(pe.permalink.String())
+//line web/template/entry.qtpl:53
+	qw422016.N().S(`" />
+`)
+//line web/template/entry.qtpl:54
+	if pe.webmention != nil {
+//line web/template/entry.qtpl:54
+		qw422016.N().S(`
+<link rel="webmention"
+      href="`)
+//line web/template/entry.qtpl:56
+		qw422016.E().S(pe.webmention.String())
+//line web/template/entry.qtpl:56
+		qw422016.N().S(`" />
+`)
+//line web/template/entry.qtpl:57
+	}
+//line web/template/entry.qtpl:57
+	qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:58
+}
+
+//line web/template/entry.qtpl:58
+func (pe *PageEntry) writehead(qq422016 qtio422016.Writer) {
+//line web/template/entry.qtpl:58
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:58
+	pe.streamhead(qw422016)
+//line web/template/entry.qtpl:58
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:58
+}
+
+//line web/template/entry.qtpl:58
+func (pe *PageEntry) head() string {
+//line web/template/entry.qtpl:58
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:58
+	pe.writehead(qb422016)
+//line web/template/entry.qtpl:58
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:58
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:58
+	return qs422016
+//line web/template/entry.qtpl:58
+}
+
+//line web/template/entry.qtpl:60
+func (pe *PageEntry) streamauthor(qw422016 *qt422016.Writer, c domain.Card) {
+//line web/template/entry.qtpl:60
+	qw422016.N().S(`
+<span class="p-author h-card">
+  `)
+//line web/template/entry.qtpl:62
+	if c.Photo != nil {
+//line web/template/entry.qtpl:62
+		qw422016.N().S(`
+  <img class="u-photo"
+       src="`)
+//line web/template/entry.qtpl:64
+		qw422016.E().S(c.Photo.String())
+//line web/template/entry.qtpl:64
+		qw422016.N().S(`"
+       alt="" />
+  `)
+//line web/template/entry.qtpl:66
+	}
+//line web/template/entry.qtpl:66
+	qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:67
+	if c.URL != nil {
+//line web/template/entry.qtpl:67
+		qw422016.N().S(`
+  <a class="p-name u-url"
+     href="`)
+//line web/template/entry.qtpl:69
+		qw422016.E().S(c.URL.String())
+//line web/template/entry.qtpl:69
+		qw422016.N().S(`">`)
+//line web/template/entry.qtpl:69
+		qw422016.E().S(c.Name)
+//line web/template/entry.qtpl:69
+		qw422016.N().S(`</a>
+  `)
+//line web/template/entry.qtpl:70
+	} else {
+//line web/template/entry.qtpl:70
+		qw422016.N().S(`
+  <span class="p-name">`)
+//line web/template/entry.qtpl:71
+		qw422016.E().S(c.Name)
+//line web/template/entry.qtpl:71
+		qw422016.N().S(`</span>
+  `)
+//line web/template/entry.qtpl:72
+	}
+//line web/template/entry.qtpl:72
+	qw422016.N().S(`
+</span>
+`)
+//line web/template/entry.qtpl:74
+}
+
+//line web/template/entry.qtpl:74
+func (pe *PageEntry) writeauthor(qq422016 qtio422016.Writer, c domain.Card) {
+//line web/template/entry.qtpl:74
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:74
+	pe.streamauthor(qw422016, c)
+//line web/template/entry.qtpl:74
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:74
+}
+
+//line web/template/entry.qtpl:74
+func (pe *PageEntry) author(c domain.Card) string {
+//line web/template/entry.qtpl:74
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:74
+	pe.writeauthor(qb422016, c)
+//line web/template/entry.qtpl:74
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:74
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:74
+	return qs422016
+//line web/template/entry.qtpl:74
+}
+
+//line web/template/entry.qtpl:76
+func (pe *PageEntry) streamcitation(qw422016 *qt422016.Writer, label, class string, c *domain.Citation) {
+//line web/template/entry.qtpl:76
+	qw422016.N().S(`
+<blockquote class="`)
+//line web/template/entry.qtpl:77
+	qw422016.E().S(class)
+//line web/template/entry.qtpl:77
+	qw422016.N().S(` h-cite">
+  <p>
+    `)
+//line web/template/entry.qtpl:79
+	pe.streamt(qw422016, label)
+//line web/template/entry.qtpl:79
+	qw422016.N().S(`
+    `)
+//line web/template/entry.qtpl:80
+	if c.Author.Name != "" {
+//line web/template/entry.qtpl:80
+		qw422016.N().S(`
+    `)
+//line web/template/entry.qtpl:81
+		pe.streamauthor(qw422016, c.Author)
+//line web/template/entry.qtpl:81
+		qw422016.N().S(`
+    `)
+//line web/template/entry.qtpl:82
+	}
+//line web/template/entry.qtpl:82
+	qw422016.N().S(`
+    `)
+//line web/template/entry.qtpl:83
+	if c.Name != "" {
+//line web/template/entry.qtpl:83
+		qw422016.N().S(`
+    <a class="p-name u-url"
+       href="`)
+//line web/template/entry.qtpl:85
+		qw422016.E().S(c.URL.String())
+//line web/template/entry.qtpl:85
+		qw422016.N().S(`">`)
+//line web/template/entry.qtpl:85
+		qw422016.E().S(c.Name)
+//line web/template/entry.qtpl:85
+		qw422016.N().S(`</a>
+    `)
+//line web/template/entry.qtpl:86
+	} else {
+//line web/template/entry.qtpl:86
+		qw422016.N().S(`
+    <a class="u-url"
+       href="`)
+//line web/template/entry.qtpl:88
+		qw422016.E().S(c.URL.String())
+//line web/template/entry.qtpl:88
+		qw422016.N().S(`">`)
+//line web/template/entry.qtpl:88
+		qw422016.E().S(c.URL.String())
+//line web/template/entry.qtpl:88
+		qw422016.N().S(`</a>
+    `)
+//line web/template/entry.qtpl:89
+	}
+//line web/template/entry.qtpl:89
+	qw422016.N().S(`
+  </p>
+  `)
+//line web/template/entry.qtpl:91
+	if c.Photo != nil {
+//line web/template/entry.qtpl:91
+		qw422016.N().S(`
+  <img class="u-photo"
+       src="`)
+//line web/template/entry.qtpl:93
+		qw422016.E().S(c.Photo.String())
+//line web/template/entry.qtpl:93
+		qw422016.N().S(`"
+       alt="" />
+  `)
+//line web/template/entry.qtpl:95
+	}
+//line web/template/entry.qtpl:95
+	qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:96
+	if c.Content != "" {
+//line web/template/entry.qtpl:96
+		qw422016.N().S(`
+  <p class="p-content">`)
+//line web/template/entry.qtpl:97
+		qw422016.E().S(c.Content)
+//line web/template/entry.qtpl:97
+		qw422016.N().S(`</p>
+  `)
+//line web/template/entry.qtpl:98
+	}
+//line web/template/entry.qtpl:98
+	qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:99
+	if !c.PublishedAt.IsZero() {
+//line web/template/entry.qtpl:99
+		qw422016.N().S(`
+  <time class="dt-published"
+        datetime="`)
+//line web/template/entry.qtpl:101
+		qw422016.E().S(c.PublishedAt.Format(time.RFC3339))
+//line web/template/entry.qtpl:101
+		qw422016.N().S(`">
+    `)
+//line web/template/entry.qtpl:102
+		qw422016.E().S(c.PublishedAt.Format(`2006-01-02 15:04`))
+//line web/template/entry.qtpl:102
+		qw422016.N().S(`
+  </time>
+  `)
+//line web/template/entry.qtpl:104
+	}
+//line web/template/entry.qtpl:104
+	qw422016.N().S(`
+</blockquote>
+`)
+//line web/template/entry.qtpl:106
+}
+
+//line web/template/entry.qtpl:106
+func (pe *PageEntry) writecitation(qq422016 qtio422016.Writer, label, class string, c *domain.Citation) {
+//line web/template/entry.qtpl:106
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:106
+	pe.streamcitation(qw422016, label, class, c)
+//line web/template/entry.qtpl:106
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:106
+}
+
+//line web/template/entry.qtpl:106
+func (pe *PageEntry) citation(label, class string, c *domain.Citation) string {
+//line web/template/entry.qtpl:106
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:106
+	pe.writecitation(qb422016, label, class, c)
+//line web/template/entry.qtpl:106
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:106
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:106
+	return qs422016
+//line web/template/entry.qtpl:106
+}
+
+//line web/template/entry.qtpl:108
+func (pe *PageEntry) streamreactions(qw422016 *qt422016.Writer, label, class string, mentions []domain.Mention) {
+//line web/template/entry.qtpl:108
+	qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:109
+	if len(mentions) > 0 {
+//line web/template/entry.qtpl:109
+		qw422016.N().S(`
+<section>
+  <h2>`)
+//line web/template/entry.qtpl:111
+		pe.streamt(qw422016, label)
+//line web/template/entry.qtpl:111
+		qw422016.N().S(`</h2>
+  <ul>
+    `)
+//line web/template/entry.qtpl:113
+		for _, m := range mentions {
+//line web/template/entry.qtpl:113
+			qw422016.N().S(`
+    <li class="`)
+//line web/template/entry.qtpl:114
+			qw422016.E().S(class)
+//line web/template/entry.qtpl:114
+			qw422016.N().S(` h-cite">
+      `)
+//line web/template/entry.qtpl:115
+			if m.Author.Name != "" {
+//line web/template/entry.qtpl:115
+				qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:116
+				pe.streamauthor(qw422016, m.Author)
+//line web/template/entry.qtpl:116
+				qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:117
+			}
+//line web/template/entry.qtpl:117
+			qw422016.N().S(`
+      <a class="u-url"
+         href="`)
+//line web/template/entry.qtpl:119
+			qw422016.E().S(m.Source.String())
+//line web/template/entry.qtpl:119
+			qw422016.N().S(`">`)
+//line web/template/entry.qtpl:119
+			qw422016.E().S(m.Source.Host)
+//line web/template/entry.qtpl:119
+			qw422016.N().S(`</a>
+    </li>
+    `)
+//line web/template/entry.qtpl:121
+		}
+//line web/template/entry.qtpl:121
+		qw422016.N().S(`
+  </ul>
+</section>
+`)
+//line web/template/entry.qtpl:124
+	}
+//line web/template/entry.qtpl:124
+	qw422016.N().S(`
+`)
+//line web/template/entry.qtpl:125
+}
+
+//line web/template/entry.qtpl:125
+func (pe *PageEntry) writereactions(qq422016 qtio422016.Writer, label, class string, mentions []domain.Mention) {
+//line web/template/entry.qtpl:125
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:125
+	pe.streamreactions(qw422016, label, class, mentions)
+//line web/template/entry.qtpl:125
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:125
+}
+
+//line web/template/entry.qtpl:125
+func (pe *PageEntry) reactions(label, class string, mentions []domain.Mention) string {
+//line web/template/entry.qtpl:125
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:125
+	pe.writereactions(qb422016, label, class, mentions)
+//line web/template/entry.qtpl:125
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:125
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:125
+	return qs422016
+//line web/template/entry.qtpl:125
+}
+
+//line web/template/entry.qtpl:127
+func (pe *PageEntry) streambody(qw422016 *qt422016.Writer) {
+//line web/template/entry.qtpl:127
+	qw422016.N().S(`
+<article class="h-entry">
+  `)
+//line web/template/entry.qtpl:129
+	if pe.entry.Title != "" {
+//line web/template/entry.qtpl:129
+		qw422016.N().S(`
+  <h1 class="p-name">`)
+//line web/template/entry.qtpl:130
+		qw422016.E().S(pe.entry.Title)
+//line web/template/entry.qtpl:130
+		qw422016.N().S(`</h1>
+  `)
+//line web/template/entry.qtpl:131
+	}
+//line web/template/entry.qtpl:131
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:133
+	for _, u := range pe.entry.InReplyTo {
+//line web/template/entry.qtpl:133
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:134
+		if c := pe.entry.Citation(u); c != nil {
+//line web/template/entry.qtpl:134
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:135
+			pe.streamcitation(qw422016, `In reply to`, `u-in-reply-to`, c)
+//line web/template/entry.qtpl:135
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:136
+		} else {
+//line web/template/entry.qtpl:136
+			qw422016.N().S(`
+  <p>
+    `)
+//line web/template/entry.qtpl:138
+			pe.streamt(qw422016, `In reply to`)
+//line web/template/entry.qtpl:138
+			qw422016.N().S(`
+    <a class="u-in-reply-to"
+       href="`)
+//line web/template/entry.qtpl:140
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:140
+			qw422016.N().S(`">`)
+//line web/template/entry.qtpl:140
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:140
+			qw422016.N().S(`</a>
+  </p>
+  `)
+//line web/template/entry.qtpl:142
+		}
+//line web/template/entry.qtpl:142
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:143
+	}
+//line web/template/entry.qtpl:143
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:145
+	for _, u := range pe.entry.LikeOf {
+//line web/template/entry.qtpl:145
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:146
+		if c := pe.entry.Citation(u); c != nil {
+//line web/template/entry.qtpl:146
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:147
+			pe.streamcitation(qw422016, `Liked`, `u-like-of`, c)
+//line web/template/entry.qtpl:147
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:148
+		} else {
+//line web/template/entry.qtpl:148
+			qw422016.N().S(`
+  <p>
+    `)
+//line web/template/entry.qtpl:150
+			pe.streamt(qw422016, `Liked`)
+//line web/template/entry.qtpl:150
+			qw422016.N().S(`
+    <a class="u-like-of"
+       href="`)
+//line web/template/entry.qtpl:152
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:152
+			qw422016.N().S(`">`)
+//line web/template/entry.qtpl:152
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:152
+			qw422016.N().S(`</a>
+  </p>
+  `)
+//line web/template/entry.qtpl:154
+		}
+//line web/template/entry.qtpl:154
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:155
+	}
+//line web/template/entry.qtpl:155
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:157
+	for _, u := range pe.entry.RepostOf {
+//line web/template/entry.qtpl:157
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:158
+		if c := pe.entry.Citation(u); c != nil {
+//line web/template/entry.qtpl:158
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:159
+			pe.streamcitation(qw422016, `Reposted`, `u-repost-of`, c)
+//line web/template/entry.qtpl:159
+			qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:160
+		} else {
+//line web/template/entry.qtpl:160
+			qw422016.N().S(`
+  <p>
+    `)
+//line web/template/entry.qtpl:162
+			pe.streamt(qw422016, `Reposted`)
+//line web/template/entry.qtpl:162
+			qw422016.N().S(`
+    <a class="u-repost-of"
+       href="`)
+//line web/template/entry.qtpl:164
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:164
+			qw422016.N().S(`">`)
+//line web/template/entry.qtpl:164
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:164
+			qw422016.N().S(`</a>
+  </p>
+  `)
+//line web/template/entry.qtpl:166
+		}
+//line web/template/entry.qtpl:166
+		qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:167
+	}
+//line web/template/entry.qtpl:167
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:169
+	for _, u := range pe.entry.BookmarkOf {
+//line web/template/entry.qtpl:169
+		qw422016.N().S(`
+  <p>
+    `)
+//line web/template/entry.qtpl:171
+		pe.streamt(qw422016, `Bookmarked`)
+//line web/template/entry.qtpl:171
+		qw422016.N().S(`
+    <a class="u-bookmark-of"
+       href="`)
+//line web/template/entry.qtpl:173
+		qw422016.E().S(u.String())
+//line web/template/entry.qtpl:173
+		qw422016.N().S(`">`)
+//line web/template/entry.qtpl:173
+		qw422016.E().S(u.String())
+//line web/template/entry.qtpl:173
+		qw422016.N().S(`</a>
+  </p>
+  `)
+//line web/template/entry.qtpl:175
+	}
+//line web/template/entry.qtpl:175
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:177
+	if pe.entry.Description != "" {
+//line web/template/entry.qtpl:177
+		qw422016.N().S(`
+  <p class="p-summary">`)
+//line web/template/entry.qtpl:178
+		qw422016.E().S(pe.entry.Description)
+//line web/template/entry.qtpl:178
+		qw422016.N().S(`</p>
+  `)
+//line web/template/entry.qtpl:179
+	}
+//line web/template/entry.qtpl:179
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:181
+	for _, u := range pe.entry.Photo {
+//line web/template/entry.qtpl:181
+		qw422016.N().S(`
+  <img class="u-photo"
+       src="`)
+//line web/template/entry.qtpl:183
+		qw422016.E().S(u.String())
+//line web/template/entry.qtpl:183
+		qw422016.N().S(`"
+       alt="" />
+  `)
+//line web/template/entry.qtpl:185
+	}
+//line web/template/entry.qtpl:185
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:187
+	for _, u := range pe.entry.Video {
+//line web/template/entry.qtpl:187
+		qw422016.N().S(`
+  <video class="u-video"
+         src="`)
+//line web/template/entry.qtpl:189
+		qw422016.E().S(u.String())
+//line web/template/entry.qtpl:189
+		qw422016.N().S(`"
+         controls></video>
+  `)
+//line web/template/entry.qtpl:191
+	}
+//line web/template/entry.qtpl:191
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:193
+	for _, u := range pe.entry.Audio {
+//line web/template/entry.qtpl:193
+		qw422016.N().S(`
+  <audio class="u-audio"
+         src="`)
+//line web/template/entry.qtpl:195
+		qw422016.E().S(u.String())
+//line web/template/entry.qtpl:195
+		qw422016.N().S(`"
+         controls></audio>
+  `)
+//line web/template/entry.qtpl:197
+	}
+//line web/template/entry.qtpl:197
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:199
+	if pe.entry.Content.HTML != nil {
+//line web/template/entry.qtpl:199
+		qw422016.N().S(`
+  <div class="e-content">`)
+//line web/template/entry.qtpl:200
+		qw422016.N().S(pe.entry.Content.RenderHTML())
+//line web/template/entry.qtpl:200
+		qw422016.N().S(`</div>
+  `)
+//line web/template/entry.qtpl:201
+	} else if pe.entry.Content.Text != "" {
+//line web/template/entry.qtpl:201
+		qw422016.N().S(`
+  <div class="e-content">`)
+//line web/template/entry.qtpl:202
+		qw422016.E().S(pe.entry.Content.Text)
+//line web/template/entry.qtpl:202
+		qw422016.N().S(`</div>
+  `)
+//line web/template/entry.qtpl:203
+	}
+//line web/template/entry.qtpl:203
+	qw422016.N().S(`
+
+  <footer>
+    <a class="u-url"
+       href="`)
+//line web/template/entry.qtpl:207
+	qw422016.E().S(pe.permalink.String())
+//line web/template/entry.qtpl:207
+	qw422016.N().S(`">
+      `)
+//line web/template/entry.qtpl:208
+	if !pe.entry.PublishedAt.IsZero() {
+//line web/template/entry.qtpl:208
+		qw422016.N().S(`
+      <time class="dt-published"
+            datetime="`)
+//line web/template/entry.qtpl:210
+		qw422016.E().S(pe.entry.PublishedAt.Format(time.RFC3339))
+//line web/template/entry.qtpl:210
+		qw422016.N().S(`">
+        `)
+//line web/template/entry.qtpl:211
+		pe.streamt(qw422016, `Published %s`, pe.entry.PublishedAt.Format(`2006-01-02 15:04`))
+//line web/template/entry.qtpl:211
+		qw422016.N().S(`
+      </time>
+      `)
+//line web/template/entry.qtpl:213
+	} else if !pe.entry.CreatedAt.IsZero() {
+//line web/template/entry.qtpl:213
+		qw422016.N().S(`
+      <time class="dt-published"
+            datetime="`)
+//line web/template/entry.qtpl:215
+		qw422016.E().S(pe.entry.CreatedAt.Format(time.RFC3339))
+//line web/template/entry.qtpl:215
+		qw422016.N().S(`">
+        `)
+//line web/template/entry.qtpl:216
+		pe.streamt(qw422016, `Published %s`, pe.entry.CreatedAt.Format(`2006-01-02 15:04`))
+//line web/template/entry.qtpl:216
+		qw422016.N().S(`
+      </time>
+      `)
+//line web/template/entry.qtpl:218
+	}
+//line web/template/entry.qtpl:218
+	qw422016.N().S(`
+    </a>
+
+    `)
+//line web/template/entry.qtpl:221
+	if !pe.entry.UpdatedAt.IsZero() {
+//line web/template/entry.qtpl:221
+		qw422016.N().S(`
+    <time class="dt-updated"
+          datetime="`)
+//line web/template/entry.qtpl:223
+		qw422016.E().S(pe.entry.UpdatedAt.Format(time.RFC3339))
+//line web/template/entry.qtpl:223
+		qw422016.N().S(`">
+      `)
+//line web/template/entry.qtpl:224
+		pe.streamt(qw422016, `Updated %s`, pe.entry.UpdatedAt.Format(`2006-01-02 15:04`))
+//line web/template/entry.qtpl:224
+		qw422016.N().S(`
+    </time>
+    `)
+//line web/template/entry.qtpl:226
+	}
+//line web/template/entry.qtpl:226
+	qw422016.N().S(`
+
+    `)
+//line web/template/entry.qtpl:228
+	if len(pe.entry.Tags) > 0 {
+//line web/template/entry.qtpl:228
+		qw422016.N().S(`
+    <ul>
+      `)
+//line web/template/entry.qtpl:230
+		for _, tag := range pe.entry.Tags {
+//line web/template/entry.qtpl:230
+			qw422016.N().S(`
+      <li>
+        <a class="p-category"
+           href="/tags/`)
+//line web/template/entry.qtpl:233
+			qw422016.N().U(tag)
+//line web/template/entry.qtpl:233
+			qw422016.N().S(`"
+           rel="tag">`)
+//line web/template/entry.qtpl:234
+			qw422016.E().S(tag)
+//line web/template/entry.qtpl:234
+			qw422016.N().S(`</a>
+      </li>
+      `)
+//line web/template/entry.qtpl:236
+		}
+//line web/template/entry.qtpl:236
+		qw422016.N().S(`
+    </ul>
+    `)
+//line web/template/entry.qtpl:238
+	}
+//line web/template/entry.qtpl:238
+	qw422016.N().S(`
+
+    `)
+//line web/template/entry.qtpl:240
+	if len(pe.entry.Syndications) > 0 {
+//line web/template/entry.qtpl:240
+		qw422016.N().S(`
+    <p>
+      `)
+//line web/template/entry.qtpl:242
+		pe.streamt(qw422016, `Also on`)
+//line web/template/entry.qtpl:242
+		qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:243
+		for _, u := range pe.entry.Syndications {
+//line web/template/entry.qtpl:243
+			qw422016.N().S(`
+      <a class="u-syndication"
+         href="`)
+//line web/template/entry.qtpl:245
+			qw422016.E().S(u.String())
+//line web/template/entry.qtpl:245
+			qw422016.N().S(`"
+         rel="syndication">`)
+//line web/template/entry.qtpl:246
+			qw422016.E().S(u.Host)
+//line web/template/entry.qtpl:246
+			qw422016.N().S(`</a>
+      `)
+//line web/template/entry.qtpl:247
+		}
+//line web/template/entry.qtpl:247
+		qw422016.N().S(`
+    </p>
+    `)
+//line web/template/entry.qtpl:249
+	}
+//line web/template/entry.qtpl:249
+	qw422016.N().S(`
+  </footer>
+
+  `)
+//line web/template/entry.qtpl:252
+	pe.streamreactions(qw422016, `Likes`, `u-like`, pe.likes)
+//line web/template/entry.qtpl:252
+	qw422016.N().S(`
+  `)
+//line web/template/entry.qtpl:253
+	pe.streamreactions(qw422016, `Reposts`, `u-repost`, pe.reposts)
+//line web/template/entry.qtpl:253
+	qw422016.N().S(`
+
+  `)
+//line web/template/entry.qtpl:255
+	if len(pe.replies) > 0 {
+//line web/template/entry.qtpl:255
+		qw422016.N().S(`
+  <section>
+    <h2>`)
+//line web/template/entry.qtpl:257
+		pe.streamt(qw422016, `Replies`)
+//line web/template/entry.qtpl:257
+		qw422016.N().S(`</h2>
+    `)
+//line web/template/entry.qtpl:258
+		for _, m := range pe.replies {
+//line web/template/entry.qtpl:258
+			qw422016.N().S(`
+    <article class="p-comment h-cite">
+      `)
+//line web/template/entry.qtpl:260
+			if m.Author.Name != "" {
+//line web/template/entry.qtpl:260
+				qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:261
+				pe.streamauthor(qw422016, m.Author)
+//line web/template/entry.qtpl:261
+				qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:262
+			}
+//line web/template/entry.qtpl:262
+			qw422016.N().S(`
+      `)
+//line web/template/entry.qtpl:263
+			if m.Content != "" {
+//line web/template/entry.qtpl:263
+				qw422016.N().S(`
+      <p class="p-content">`)
+//line web/template/entry.qtpl:264
+				qw422016.E().S(m.Content)
+//line web/template/entry.qtpl:264
+				qw422016.N().S(`</p>
+      `)
+//line web/template/entry.qtpl:265
+			}
+//line web/template/entry.qtpl:265
+			qw422016.N().S(`
+      <a class="u-url"
+         href="`)
+//line web/template/entry.qtpl:267
+			qw422016.E().S(m.Source.String())
+//line web/template/entry.qtpl:267
+			qw422016.N().S(`">
+        `)
+//line web/template/entry.qtpl:268
+			if !m.PublishedAt.IsZero() {
+//line web/template/entry.qtpl:268
+				qw422016.N().S(`
+        <time class="dt-published"
+              datetime="`)
+//line web/template/entry.qtpl:270
+				qw422016.E().S(m.PublishedAt.Format(time.RFC3339))
+//line web/template/entry.qtpl:270
+				qw422016.N().S(`">
+          `)
+//line web/template/entry.qtpl:271
+				qw422016.E().S(m.PublishedAt.Format(`2006-01-02 15:04`))
+//line web/template/entry.qtpl:271
+				qw422016.N().S(`
+        </time>
+        `)
+//line web/template/entry.qtpl:273
+			} else {
+//line web/template/entry.qtpl:273
+				qw422016.N().S(`
+        `)
+//line web/template/entry.qtpl:274
+				qw422016.E().S(m.Source.Host)
+//line web/template/entry.qtpl:274
+				qw422016.N().S(`
+        `)
+//line web/template/entry.qtpl:275
+			}
+//line web/template/entry.qtpl:275
+			qw422016.N().S(`
+      </a>
+    </article>
+    `)
+//line web/template/entry.qtpl:278
+		}
+//line web/template/entry.qtpl:278
+		qw422016.N().S(`
+  </section>
+  `)
+//line web/template/entry.qtpl:280
+	}
+//line web/template/entry.qtpl:280
+	qw422016.N().S(`
+</article>
+`)
+//line web/template/entry.qtpl:282
+}
+
+//line web/template/entry.qtpl:282
+func (pe *PageEntry) writebody(qq422016 qtio422016.Writer) {
+//line web/template/entry.qtpl:282
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/entry.qtpl:282
+	pe.streambody(qw422016)
+//line web/template/entry.qtpl:282
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/entry.qtpl:282
+}
+
+//line web/template/entry.qtpl:282
+func (pe *PageEntry) body() string {
+//line web/template/entry.qtpl:282
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/entry.qtpl:282
+	pe.writebody(qb422016)
+//line web/template/entry.qtpl:282
+	qs422016 := string(qb422016.B)
+//line web/template/entry.qtpl:282
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/entry.qtpl:282
+	return qs422016
+//line web/template/entry.qtpl:282
+}
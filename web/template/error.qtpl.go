@@ -0,0 +1,173 @@
+// Code generated by qtc from "error.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line web/template/error.qtpl:1
+package template
+
+//line web/template/error.qtpl:1
+import (
+	"net/http"
+)
+
+//line web/template/error.qtpl:5
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line web/template/error.qtpl:5
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line web/template/error.qtpl:6
+type PageError struct {
+	*BaseOf
+	status int
+}
+
+func NewPageError(base *BaseOf, status int) *PageError {
+	return &PageError{
+		BaseOf: base,
+		status: status,
+	}
+}
+
+//line web/template/error.qtpl:19
+func (pe *PageError) streamtitle(qw422016 *qt422016.Writer) {
+//line web/template/error.qtpl:19
+	qw422016.N().S(`
+`)
+//line web/template/error.qtpl:20
+	pe.streamt(qw422016, http.StatusText(pe.status))
+//line web/template/error.qtpl:20
+	qw422016.N().S(` — Micropub
+`)
+//line web/template/error.qtpl:21
+}
+
+//line web/template/error.qtpl:21
+func (pe *PageError) writetitle(qq422016 qtio422016.Writer) {
+//line web/template/error.qtpl:21
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/error.qtpl:21
+	pe.streamtitle(qw422016)
+//line web/template/error.qtpl:21
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/error.qtpl:21
+}
+
+//line web/template/error.qtpl:21
+func (pe *PageError) title() string {
+//line web/template/error.qtpl:21
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/error.qtpl:21
+	pe.writetitle(qb422016)
+//line web/template/error.qtpl:21
+	qs422016 := string(qb422016.B)
+//line web/template/error.qtpl:21
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/error.qtpl:21
+	return qs422016
+//line web/template/error.qtpl:21
+}
+
+//line web/template/error.qtpl:23
+func (pe *PageError) streamhead(qw422016 *qt422016.Writer) {
+//line web/template/error.qtpl:23
+}
+
+//line web/template/error.qtpl:23
+func (pe *PageError) writehead(qq422016 qtio422016.Writer) {
+//line web/template/error.qtpl:23
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/error.qtpl:23
+	pe.streamhead(qw422016)
+//line web/template/error.qtpl:23
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/error.qtpl:23
+}
+
+//line web/template/error.qtpl:23
+func (pe *PageError) head() string {
+//line web/template/error.qtpl:23
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/error.qtpl:23
+	pe.writehead(qb422016)
+//line web/template/error.qtpl:23
+	qs422016 := string(qb422016.B)
+//line web/template/error.qtpl:23
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/error.qtpl:23
+	return qs422016
+//line web/template/error.qtpl:23
+}
+
+//line web/template/error.qtpl:25
+func (pe *PageError) streambody(qw422016 *qt422016.Writer) {
+//line web/template/error.qtpl:25
+	qw422016.N().S(`
+<h1>`)
+//line web/template/error.qtpl:26
+	pe.streamt(qw422016, http.StatusText(pe.status))
+//line web/template/error.qtpl:26
+	qw422016.N().S(`</h1>
+
+`)
+//line web/template/error.qtpl:28
+	switch pe.status {
+//line web/template/error.qtpl:29
+	case http.StatusNotFound:
+//line web/template/error.qtpl:29
+		qw422016.N().S(`
+<p>`)
+//line web/template/error.qtpl:30
+		pe.streamt(qw422016, `This page does not exist.`)
+//line web/template/error.qtpl:30
+		qw422016.N().S(`</p>
+`)
+//line web/template/error.qtpl:31
+	case http.StatusGone:
+//line web/template/error.qtpl:31
+		qw422016.N().S(`
+<p>`)
+//line web/template/error.qtpl:32
+		pe.streamt(qw422016, `This page has been deleted.`)
+//line web/template/error.qtpl:32
+		qw422016.N().S(`</p>
+`)
+//line web/template/error.qtpl:33
+	}
+//line web/template/error.qtpl:33
+	qw422016.N().S(`
+`)
+//line web/template/error.qtpl:34
+}
+
+//line web/template/error.qtpl:34
+func (pe *PageError) writebody(qq422016 qtio422016.Writer) {
+//line web/template/error.qtpl:34
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/error.qtpl:34
+	pe.streambody(qw422016)
+//line web/template/error.qtpl:34
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/error.qtpl:34
+}
+
+//line web/template/error.qtpl:34
+func (pe *PageError) body() string {
+//line web/template/error.qtpl:34
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/error.qtpl:34
+	pe.writebody(qb422016)
+//line web/template/error.qtpl:34
+	qs422016 := string(qb422016.B)
+//line web/template/error.qtpl:34
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/error.qtpl:34
+	return qs422016
+//line web/template/error.qtpl:34
+}
@@ -0,0 +1,529 @@
+// Code generated by qtc from "feed.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line web/template/feed.qtpl:1
+package template
+
+//line web/template/feed.qtpl:1
+import (
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+//line web/template/feed.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line web/template/feed.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+// FeedTitle describes a listed entries: all site entries, entries with tag
+// or entries published in period.
+//
+//line web/template/feed.qtpl:9
+type FeedTitle struct {
+	Site   string
+	Tag    string
+	Period string // 2006 or 2006-01
+}
+
+type PageFeed struct {
+	*BaseOf
+	heading   FeedTitle
+	root      *url.URL
+	prev      *url.URL
+	next      *url.URL
+	alternate *url.URL
+	entries   []domain.Entry
+}
+
+func NewPageFeed(base *BaseOf, heading FeedTitle, root *url.URL, entries []domain.Entry, prev, next,
+	alternate *url.URL) *PageFeed {
+	return &PageFeed{
+		BaseOf:    base,
+		heading:   heading,
+		root:      root,
+		entries:   entries,
+		prev:      prev,
+		next:      next,
+		alternate: alternate,
+	}
+}
+
+//line web/template/feed.qtpl:41
+func (pf *PageFeed) streamname(qw422016 *qt422016.Writer) {
+//line web/template/feed.qtpl:41
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:42
+	switch {
+//line web/template/feed.qtpl:43
+	case pf.heading.Tag != "":
+//line web/template/feed.qtpl:43
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:44
+		pf.streamt(qw422016, `Tagged #%s`, pf.heading.Tag)
+//line web/template/feed.qtpl:44
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:45
+	case pf.heading.Period != "":
+//line web/template/feed.qtpl:45
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:46
+		pf.streamt(qw422016, `Archive for %s`, pf.heading.Period)
+//line web/template/feed.qtpl:46
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:47
+	default:
+//line web/template/feed.qtpl:47
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:48
+		qw422016.E().S(pf.heading.Site)
+//line web/template/feed.qtpl:48
+		qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:49
+	}
+//line web/template/feed.qtpl:49
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:50
+}
+
+//line web/template/feed.qtpl:50
+func (pf *PageFeed) writename(qq422016 qtio422016.Writer) {
+//line web/template/feed.qtpl:50
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/feed.qtpl:50
+	pf.streamname(qw422016)
+//line web/template/feed.qtpl:50
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/feed.qtpl:50
+}
+
+//line web/template/feed.qtpl:50
+func (pf *PageFeed) name() string {
+//line web/template/feed.qtpl:50
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/feed.qtpl:50
+	pf.writename(qb422016)
+//line web/template/feed.qtpl:50
+	qs422016 := string(qb422016.B)
+//line web/template/feed.qtpl:50
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/feed.qtpl:50
+	return qs422016
+//line web/template/feed.qtpl:50
+}
+
+//line web/template/feed.qtpl:52
+func (pf *PageFeed) streamtitle(qw422016 *qt422016.Writer) {
+//line web/template/feed.qtpl:52
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:53
+	pf.streamname(qw422016)
+//line web/template/feed.qtpl:53
+	qw422016.N().S(` — Micropub
+`)
+//line web/template/feed.qtpl:54
+}
+
+//line web/template/feed.qtpl:54
+func (pf *PageFeed) writetitle(qq422016 qtio422016.Writer) {
+//line web/template/feed.qtpl:54
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/feed.qtpl:54
+	pf.streamtitle(qw422016)
+//line web/template/feed.qtpl:54
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/feed.qtpl:54
+}
+
+//line web/template/feed.qtpl:54
+func (pf *PageFeed) title() string {
+//line web/template/feed.qtpl:54
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/feed.qtpl:54
+	pf.writetitle(qb422016)
+//line web/template/feed.qtpl:54
+	qs422016 := string(qb422016.B)
+//line web/template/feed.qtpl:54
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/feed.qtpl:54
+	return qs422016
+//line web/template/feed.qtpl:54
+}
+
+//line web/template/feed.qtpl:56
+func (pf *PageFeed) streamhead(qw422016 *qt422016.Writer) {
+//line web/template/feed.qtpl:56
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:57
+	if pf.alternate != nil {
+//line web/template/feed.qtpl:57
+		qw422016.N().S(`
+<link rel="alternate"
+      type="application/atom+xml"
+      href="`)
+//line web/template/feed.qtpl:60
+		qw422016.E().S(pf.alternate.String())
+//line web/template/feed.qtpl:60
+		qw422016.N().S(`" />
+`)
+//line web/template/feed.qtpl:61
+	}
+//line web/template/feed.qtpl:61
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:62
+	if pf.prev != nil {
+//line web/template/feed.qtpl:62
+		qw422016.N().S(`
+<link rel="prev"
+      href="`)
+//line web/template/feed.qtpl:64
+		qw422016.E().S(pf.prev.String())
+//line web/template/feed.qtpl:64
+		qw422016.N().S(`" />
+`)
+//line web/template/feed.qtpl:65
+	}
+//line web/template/feed.qtpl:65
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:66
+	if pf.next != nil {
+//line web/template/feed.qtpl:66
+		qw422016.N().S(`
+<link rel="next"
+      href="`)
+//line web/template/feed.qtpl:68
+		qw422016.E().S(pf.next.String())
+//line web/template/feed.qtpl:68
+		qw422016.N().S(`" />
+`)
+//line web/template/feed.qtpl:69
+	}
+//line web/template/feed.qtpl:69
+	qw422016.N().S(`
+`)
+//line web/template/feed.qtpl:70
+}
+
+//line web/template/feed.qtpl:70
+func (pf *PageFeed) writehead(qq422016 qtio422016.Writer) {
+//line web/template/feed.qtpl:70
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/feed.qtpl:70
+	pf.streamhead(qw422016)
+//line web/template/feed.qtpl:70
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/feed.qtpl:70
+}
+
+//line web/template/feed.qtpl:70
+func (pf *PageFeed) head() string {
+//line web/template/feed.qtpl:70
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/feed.qtpl:70
+	pf.writehead(qb422016)
+//line web/template/feed.qtpl:70
+	qs422016 := string(qb422016.B)
+//line web/template/feed.qtpl:70
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/feed.qtpl:70
+	return qs422016
+//line web/template/feed.qtpl:70
+}
+
+//line web/template/feed.qtpl:72
+func (pf *PageFeed) streambody(qw422016 *qt422016.Writer) {
+//line web/template/feed.qtpl:72
+	qw422016.N().S(`
+<main class="h-feed">
+  <h1 class="p-name">`)
+//line web/template/feed.qtpl:74
+	pf.streamname(qw422016)
+//line web/template/feed.qtpl:74
+	qw422016.N().S(`</h1>
+
+  `)
+//line web/template/feed.qtpl:76
+	if len(pf.entries) == 0 {
+//line web/template/feed.qtpl:76
+		qw422016.N().S(`
+  <p>`)
+//line web/template/feed.qtpl:77
+		pf.streamt(qw422016, `Nothing here yet.`)
+//line web/template/feed.qtpl:77
+		qw422016.N().S(`</p>
+  `)
+//line web/template/feed.qtpl:78
+	}
+//line web/template/feed.qtpl:78
+	qw422016.N().S(`
+
+  `)
+//line web/template/feed.qtpl:80
+	for i := range pf.entries {
+//line web/template/feed.qtpl:80
+		qw422016.N().S(`
+  `)
+//line web/template/feed.qtpl:81
+		pf.streamentry(qw422016, &pf.entries[i])
+//line web/template/feed.qtpl:81
+		qw422016.N().S(`
+  `)
+//line web/template/feed.qtpl:82
+	}
+//line web/template/feed.qtpl:82
+	qw422016.N().S(`
+
+  `)
+//line web/template/feed.qtpl:84
+	if pf.prev != nil || pf.next != nil {
+//line web/template/feed.qtpl:84
+		qw422016.N().S(`
+  <nav>
+    `)
+//line web/template/feed.qtpl:86
+		if pf.prev != nil {
+//line web/template/feed.qtpl:86
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/feed.qtpl:87
+			qw422016.E().S(pf.prev.String())
+//line web/template/feed.qtpl:87
+			qw422016.N().S(`"
+       rel="prev">`)
+//line web/template/feed.qtpl:88
+			pf.streamt(qw422016, `Newer`)
+//line web/template/feed.qtpl:88
+			qw422016.N().S(`</a>
+    `)
+//line web/template/feed.qtpl:89
+		}
+//line web/template/feed.qtpl:89
+		qw422016.N().S(`
+    `)
+//line web/template/feed.qtpl:90
+		if pf.next != nil {
+//line web/template/feed.qtpl:90
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/feed.qtpl:91
+			qw422016.E().S(pf.next.String())
+//line web/template/feed.qtpl:91
+			qw422016.N().S(`"
+       rel="next">`)
+//line web/template/feed.qtpl:92
+			pf.streamt(qw422016, `Older`)
+//line web/template/feed.qtpl:92
+			qw422016.N().S(`</a>
+    `)
+//line web/template/feed.qtpl:93
+		}
+//line web/template/feed.qtpl:93
+		qw422016.N().S(`
+  </nav>
+  `)
+//line web/template/feed.qtpl:95
+	}
+//line web/template/feed.qtpl:95
+	qw422016.N().S(`
+</main>
+`)
+//line web/template/feed.qtpl:97
+}
+
+//line web/template/feed.qtpl:97
+func (pf *PageFeed) writebody(qq422016 qtio422016.Writer) {
+//line web/template/feed.qtpl:97
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/feed.qtpl:97
+	pf.streambody(qw422016)
+//line web/template/feed.qtpl:97
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/feed.qtpl:97
+}
+
+//line web/template/feed.qtpl:97
+func (pf *PageFeed) body() string {
+//line web/template/feed.qtpl:97
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/feed.qtpl:97
+	pf.writebody(qb422016)
+//line web/template/feed.qtpl:97
+	qs422016 := string(qb422016.B)
+//line web/template/feed.qtpl:97
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/feed.qtpl:97
+	return qs422016
+//line web/template/feed.qtpl:97
+}
+
+//line web/template/feed.qtpl:99
+func (pf *PageFeed) streamentry(qw422016 *qt422016.Writer, e *domain.Entry) {
+//line web/template/feed.qtpl:99
+	qw422016.N().S(`
+<article class="h-entry">
+  `)
+//line web/template/feed.qtpl:101
+	if e.Title != "" {
+//line web/template/feed.qtpl:101
+		qw422016.N().S(`
+  <h2 class="p-name">
+    <a href="`)
+//line web/template/feed.qtpl:103
+		qw422016.E().S(pf.root.ResolveReference(e.URL).String())
+//line web/template/feed.qtpl:103
+		qw422016.N().S(`">`)
+//line web/template/feed.qtpl:103
+		qw422016.E().S(e.Title)
+//line web/template/feed.qtpl:103
+		qw422016.N().S(`</a>
+  </h2>
+  `)
+//line web/template/feed.qtpl:105
+	}
+//line web/template/feed.qtpl:105
+	qw422016.N().S(`
+
+  `)
+//line web/template/feed.qtpl:107
+	if e.Description != "" {
+//line web/template/feed.qtpl:107
+		qw422016.N().S(`
+  <p class="p-summary">`)
+//line web/template/feed.qtpl:108
+		qw422016.E().S(e.Description)
+//line web/template/feed.qtpl:108
+		qw422016.N().S(`</p>
+  `)
+//line web/template/feed.qtpl:109
+	}
+//line web/template/feed.qtpl:109
+	qw422016.N().S(`
+
+  `)
+//line web/template/feed.qtpl:111
+	for _, u := range e.Photo {
+//line web/template/feed.qtpl:111
+		qw422016.N().S(`
+  <img class="u-photo"
+       src="`)
+//line web/template/feed.qtpl:113
+		qw422016.E().S(u.String())
+//line web/template/feed.qtpl:113
+		qw422016.N().S(`"
+       alt="" />
+  `)
+//line web/template/feed.qtpl:115
+	}
+//line web/template/feed.qtpl:115
+	qw422016.N().S(`
+
+  `)
+//line web/template/feed.qtpl:117
+	if e.Content.HTML != nil || e.Content.Text != "" {
+//line web/template/feed.qtpl:117
+		qw422016.N().S(`
+  <div class="e-content">`)
+//line web/template/feed.qtpl:118
+		qw422016.N().S(e.Content.RenderHTML())
+//line web/template/feed.qtpl:118
+		qw422016.N().S(`</div>
+  `)
+//line web/template/feed.qtpl:119
+	}
+//line web/template/feed.qtpl:119
+	qw422016.N().S(`
+
+  <footer>
+    <a class="u-url"
+       href="`)
+//line web/template/feed.qtpl:123
+	qw422016.E().S(pf.root.ResolveReference(e.URL).String())
+//line web/template/feed.qtpl:123
+	qw422016.N().S(`">
+      <time class="dt-published"
+            datetime="`)
+//line web/template/feed.qtpl:125
+	qw422016.E().S(e.Date().Format(time.RFC3339))
+//line web/template/feed.qtpl:125
+	qw422016.N().S(`">
+        `)
+//line web/template/feed.qtpl:126
+	pf.streamt(qw422016, `Published %s`, e.Date().Format(`2006-01-02 15:04`))
+//line web/template/feed.qtpl:126
+	qw422016.N().S(`
+      </time>
+    </a>
+
+    `)
+//line web/template/feed.qtpl:130
+	for _, tag := range e.Tags {
+//line web/template/feed.qtpl:130
+		qw422016.N().S(`
+    <a class="p-category"
+       href="/tags/`)
+//line web/template/feed.qtpl:132
+		qw422016.N().U(tag)
+//line web/template/feed.qtpl:132
+		qw422016.N().S(`"
+       rel="tag">#`)
+//line web/template/feed.qtpl:133
+		qw422016.E().S(tag)
+//line web/template/feed.qtpl:133
+		qw422016.N().S(`</a>
+    `)
+//line web/template/feed.qtpl:134
+	}
+//line web/template/feed.qtpl:134
+	qw422016.N().S(`
+  </footer>
+</article>
+`)
+//line web/template/feed.qtpl:137
+}
+
+//line web/template/feed.qtpl:137
+func (pf *PageFeed) writeentry(qq422016 qtio422016.Writer, e *domain.Entry) {
+//line web/template/feed.qtpl:137
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/feed.qtpl:137
+	pf.streamentry(qw422016, e)
+//line web/template/feed.qtpl:137
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/feed.qtpl:137
+}
+
+//line web/template/feed.qtpl:137
+func (pf *PageFeed) entry(e *domain.Entry) string {
+//line web/template/feed.qtpl:137
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/feed.qtpl:137
+	pf.writeentry(qb422016, e)
+//line web/template/feed.qtpl:137
+	qs422016 := string(qb422016.B)
+//line web/template/feed.qtpl:137
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/feed.qtpl:137
+	return qs422016
+//line web/template/feed.qtpl:137
+}
@@ -0,0 +1,354 @@
+// Code generated by qtc from "search.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line web/template/search.qtpl:1
+package template
+
+//line web/template/search.qtpl:1
+import (
+	"net/url"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+//line web/template/search.qtpl:7
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line web/template/search.qtpl:7
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line web/template/search.qtpl:8
+type PageSearch struct {
+	*PageFeed
+	term string
+}
+
+func NewPageSearch(base *BaseOf, root *url.URL, term string, entries []domain.Entry, prev,
+	next *url.URL) *PageSearch {
+	return &PageSearch{
+		PageFeed: NewPageFeed(base, FeedTitle{}, root, entries, prev, next, nil),
+		term:     term,
+	}
+}
+
+//line web/template/search.qtpl:22
+func (ps *PageSearch) streamname(qw422016 *qt422016.Writer) {
+//line web/template/search.qtpl:22
+	qw422016.N().S(`
+`)
+//line web/template/search.qtpl:23
+	if ps.term != "" {
+//line web/template/search.qtpl:23
+		qw422016.N().S(`
+`)
+//line web/template/search.qtpl:24
+		ps.streamt(qw422016, `Search results for "%s"`, ps.term)
+//line web/template/search.qtpl:24
+		qw422016.N().S(`
+`)
+//line web/template/search.qtpl:25
+	} else {
+//line web/template/search.qtpl:25
+		qw422016.N().S(`
+`)
+//line web/template/search.qtpl:26
+		ps.streamt(qw422016, `Search`)
+//line web/template/search.qtpl:26
+		qw422016.N().S(`
+`)
+//line web/template/search.qtpl:27
+	}
+//line web/template/search.qtpl:27
+	qw422016.N().S(`
+`)
+//line web/template/search.qtpl:28
+}
+
+//line web/template/search.qtpl:28
+func (ps *PageSearch) writename(qq422016 qtio422016.Writer) {
+//line web/template/search.qtpl:28
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/search.qtpl:28
+	ps.streamname(qw422016)
+//line web/template/search.qtpl:28
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/search.qtpl:28
+}
+
+//line web/template/search.qtpl:28
+func (ps *PageSearch) name() string {
+//line web/template/search.qtpl:28
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/search.qtpl:28
+	ps.writename(qb422016)
+//line web/template/search.qtpl:28
+	qs422016 := string(qb422016.B)
+//line web/template/search.qtpl:28
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/search.qtpl:28
+	return qs422016
+//line web/template/search.qtpl:28
+}
+
+//line web/template/search.qtpl:30
+func (ps *PageSearch) streamtitle(qw422016 *qt422016.Writer) {
+//line web/template/search.qtpl:30
+	qw422016.N().S(`
+`)
+//line web/template/search.qtpl:31
+	ps.streamname(qw422016)
+//line web/template/search.qtpl:31
+	qw422016.N().S(` — Micropub
+`)
+//line web/template/search.qtpl:32
+}
+
+//line web/template/search.qtpl:32
+func (ps *PageSearch) writetitle(qq422016 qtio422016.Writer) {
+//line web/template/search.qtpl:32
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/search.qtpl:32
+	ps.streamtitle(qw422016)
+//line web/template/search.qtpl:32
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/search.qtpl:32
+}
+
+//line web/template/search.qtpl:32
+func (ps *PageSearch) title() string {
+//line web/template/search.qtpl:32
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/search.qtpl:32
+	ps.writetitle(qb422016)
+//line web/template/search.qtpl:32
+	qs422016 := string(qb422016.B)
+//line web/template/search.qtpl:32
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/search.qtpl:32
+	return qs422016
+//line web/template/search.qtpl:32
+}
+
+//line web/template/search.qtpl:34
+func (ps *PageSearch) streamhead(qw422016 *qt422016.Writer) {
+//line web/template/search.qtpl:34
+	qw422016.N().S(`
+<meta name="robots"
+      content="noindex" />
+`)
+//line web/template/search.qtpl:37
+	if ps.prev != nil {
+//line web/template/search.qtpl:37
+		qw422016.N().S(`
+<link rel="prev"
+      href="`)
+//line web/template/search.qtpl:39
+		qw422016.E().S(ps.prev.String())
+//line web/template/search.qtpl:39
+		qw422016.N().S(`" />
+`)
+//line web/template/search.qtpl:40
+	}
+//line web/template/search.qtpl:40
+	qw422016.N().S(`
+`)
+//line web/template/search.qtpl:41
+	if ps.next != nil {
+//line web/template/search.qtpl:41
+		qw422016.N().S(`
+<link rel="next"
+      href="`)
+//line web/template/search.qtpl:43
+		qw422016.E().S(ps.next.String())
+//line web/template/search.qtpl:43
+		qw422016.N().S(`" />
+`)
+//line web/template/search.qtpl:44
+	}
+//line web/template/search.qtpl:44
+	qw422016.N().S(`
+`)
+//line web/template/search.qtpl:45
+}
+
+//line web/template/search.qtpl:45
+func (ps *PageSearch) writehead(qq422016 qtio422016.Writer) {
+//line web/template/search.qtpl:45
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/search.qtpl:45
+	ps.streamhead(qw422016)
+//line web/template/search.qtpl:45
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/search.qtpl:45
+}
+
+//line web/template/search.qtpl:45
+func (ps *PageSearch) head() string {
+//line web/template/search.qtpl:45
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/search.qtpl:45
+	ps.writehead(qb422016)
+//line web/template/search.qtpl:45
+	qs422016 := string(qb422016.B)
+//line web/template/search.qtpl:45
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/search.qtpl:45
+	return qs422016
+//line web/template/search.qtpl:45
+}
+
+//line web/template/search.qtpl:47
+func (ps *PageSearch) streambody(qw422016 *qt422016.Writer) {
+//line web/template/search.qtpl:47
+	qw422016.N().S(`
+<main class="h-feed">
+  <h1 class="p-name">`)
+//line web/template/search.qtpl:49
+	ps.streamname(qw422016)
+//line web/template/search.qtpl:49
+	qw422016.N().S(`</h1>
+
+  <form method="get"
+        action="/search"
+        role="search">
+    <input type="search"
+           name="q"
+           value="`)
+//line web/template/search.qtpl:56
+	qw422016.E().S(ps.term)
+//line web/template/search.qtpl:56
+	qw422016.N().S(`"
+           aria-label="`)
+//line web/template/search.qtpl:57
+	ps.streamt(qw422016, `Search`)
+//line web/template/search.qtpl:57
+	qw422016.N().S(`"
+           required />
+    <button type="submit">`)
+//line web/template/search.qtpl:59
+	ps.streamt(qw422016, `Search`)
+//line web/template/search.qtpl:59
+	qw422016.N().S(`</button>
+  </form>
+
+  `)
+//line web/template/search.qtpl:62
+	if ps.term != "" && len(ps.entries) == 0 {
+//line web/template/search.qtpl:62
+		qw422016.N().S(`
+  <p>`)
+//line web/template/search.qtpl:63
+		ps.streamt(qw422016, `Nothing found.`)
+//line web/template/search.qtpl:63
+		qw422016.N().S(`</p>
+  `)
+//line web/template/search.qtpl:64
+	}
+//line web/template/search.qtpl:64
+	qw422016.N().S(`
+
+  `)
+//line web/template/search.qtpl:66
+	for i := range ps.entries {
+//line web/template/search.qtpl:66
+		qw422016.N().S(`
+  `)
+//line web/template/search.qtpl:67
+		ps.streamentry(qw422016, &ps.entries[i])
+//line web/template/search.qtpl:67
+		qw422016.N().S(`
+  `)
+//line web/template/search.qtpl:68
+	}
+//line web/template/search.qtpl:68
+	qw422016.N().S(`
+
+  `)
+//line web/template/search.qtpl:70
+	if ps.prev != nil || ps.next != nil {
+//line web/template/search.qtpl:70
+		qw422016.N().S(`
+  <nav>
+    `)
+//line web/template/search.qtpl:72
+		if ps.prev != nil {
+//line web/template/search.qtpl:72
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/search.qtpl:73
+			qw422016.E().S(ps.prev.String())
+//line web/template/search.qtpl:73
+			qw422016.N().S(`"
+       rel="prev">`)
+//line web/template/search.qtpl:74
+			ps.streamt(qw422016, `Previous`)
+//line web/template/search.qtpl:74
+			qw422016.N().S(`</a>
+    `)
+//line web/template/search.qtpl:75
+		}
+//line web/template/search.qtpl:75
+		qw422016.N().S(`
+    `)
+//line web/template/search.qtpl:76
+		if ps.next != nil {
+//line web/template/search.qtpl:76
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/search.qtpl:77
+			qw422016.E().S(ps.next.String())
+//line web/template/search.qtpl:77
+			qw422016.N().S(`"
+       rel="next">`)
+//line web/template/search.qtpl:78
+			ps.streamt(qw422016, `Next`)
+//line web/template/search.qtpl:78
+			qw422016.N().S(`</a>
+    `)
+//line web/template/search.qtpl:79
+		}
+//line web/template/search.qtpl:79
+		qw422016.N().S(`
+  </nav>
+  `)
+//line web/template/search.qtpl:81
+	}
+//line web/template/search.qtpl:81
+	qw422016.N().S(`
+</main>
+`)
+//line web/template/search.qtpl:83
+}
+
+//line web/template/search.qtpl:83
+func (ps *PageSearch) writebody(qq422016 qtio422016.Writer) {
+//line web/template/search.qtpl:83
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/search.qtpl:83
+	ps.streambody(qw422016)
+//line web/template/search.qtpl:83
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/search.qtpl:83
+}
+
+//line web/template/search.qtpl:83
+func (ps *PageSearch) body() string {
+//line web/template/search.qtpl:83
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/search.qtpl:83
+	ps.writebody(qb422016)
+//line web/template/search.qtpl:83
+	qs422016 := string(qb422016.B)
+//line web/template/search.qtpl:83
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/search.qtpl:83
+	return qs422016
+//line web/template/search.qtpl:83
+}
@@ -0,0 +1,415 @@
+// Code generated by qtc from "trash.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line web/template/trash.qtpl:1
+package template
+
+//line web/template/trash.qtpl:1
+import (
+	"net/url"
+	"time"
+
+	"source.toby3d.me/toby3d/pub/internal/domain"
+)
+
+//line web/template/trash.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line web/template/trash.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line web/template/trash.qtpl:9
+type PageTrash struct {
+	*PageFeed
+	retention time.Duration
+}
+
+func NewPageTrash(base *BaseOf, root *url.URL, entries []domain.Entry, prev, next *url.URL,
+	retention time.Duration) *PageTrash {
+	return &PageTrash{
+		PageFeed:  NewPageFeed(base, FeedTitle{}, root, entries, prev, next, nil),
+		retention: retention,
+	}
+}
+
+//line web/template/trash.qtpl:23
+func (pt *PageTrash) streamtitle(qw422016 *qt422016.Writer) {
+//line web/template/trash.qtpl:23
+	qw422016.N().S(`
+`)
+//line web/template/trash.qtpl:24
+	pt.streamt(qw422016, `Trash`)
+//line web/template/trash.qtpl:24
+	qw422016.N().S(` — Micropub
+`)
+//line web/template/trash.qtpl:25
+}
+
+//line web/template/trash.qtpl:25
+func (pt *PageTrash) writetitle(qq422016 qtio422016.Writer) {
+//line web/template/trash.qtpl:25
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/trash.qtpl:25
+	pt.streamtitle(qw422016)
+//line web/template/trash.qtpl:25
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/trash.qtpl:25
+}
+
+//line web/template/trash.qtpl:25
+func (pt *PageTrash) title() string {
+//line web/template/trash.qtpl:25
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/trash.qtpl:25
+	pt.writetitle(qb422016)
+//line web/template/trash.qtpl:25
+	qs422016 := string(qb422016.B)
+//line web/template/trash.qtpl:25
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/trash.qtpl:25
+	return qs422016
+//line web/template/trash.qtpl:25
+}
+
+//line web/template/trash.qtpl:27
+func (pt *PageTrash) streamhead(qw422016 *qt422016.Writer) {
+//line web/template/trash.qtpl:27
+	qw422016.N().S(`
+<meta name="robots"
+      content="noindex" />
+`)
+//line web/template/trash.qtpl:30
+	if pt.prev != nil {
+//line web/template/trash.qtpl:30
+		qw422016.N().S(`
+<link rel="prev"
+      href="`)
+//line web/template/trash.qtpl:32
+		qw422016.E().S(pt.prev.String())
+//line web/template/trash.qtpl:32
+		qw422016.N().S(`" />
+`)
+//line web/template/trash.qtpl:33
+	}
+//line web/template/trash.qtpl:33
+	qw422016.N().S(`
+`)
+//line web/template/trash.qtpl:34
+	if pt.next != nil {
+//line web/template/trash.qtpl:34
+		qw422016.N().S(`
+<link rel="next"
+      href="`)
+//line web/template/trash.qtpl:36
+		qw422016.E().S(pt.next.String())
+//line web/template/trash.qtpl:36
+		qw422016.N().S(`" />
+`)
+//line web/template/trash.qtpl:37
+	}
+//line web/template/trash.qtpl:37
+	qw422016.N().S(`
+`)
+//line web/template/trash.qtpl:38
+}
+
+//line web/template/trash.qtpl:38
+func (pt *PageTrash) writehead(qq422016 qtio422016.Writer) {
+//line web/template/trash.qtpl:38
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/trash.qtpl:38
+	pt.streamhead(qw422016)
+//line web/template/trash.qtpl:38
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/trash.qtpl:38
+}
+
+//line web/template/trash.qtpl:38
+func (pt *PageTrash) head() string {
+//line web/template/trash.qtpl:38
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/trash.qtpl:38
+	pt.writehead(qb422016)
+//line web/template/trash.qtpl:38
+	qs422016 := string(qb422016.B)
+//line web/template/trash.qtpl:38
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/trash.qtpl:38
+	return qs422016
+//line web/template/trash.qtpl:38
+}
+
+//line web/template/trash.qtpl:40
+func (pt *PageTrash) streambody(qw422016 *qt422016.Writer) {
+//line web/template/trash.qtpl:40
+	qw422016.N().S(`
+<main>
+  <h1>`)
+//line web/template/trash.qtpl:42
+	pt.streamt(qw422016, `Trash`)
+//line web/template/trash.qtpl:42
+	qw422016.N().S(`</h1>
+
+  `)
+//line web/template/trash.qtpl:44
+	if pt.retention > 0 {
+//line web/template/trash.qtpl:44
+		qw422016.N().S(`
+  <p>`)
+//line web/template/trash.qtpl:45
+		pt.streamt(qw422016, `Deleted entries are purged after %s.`, pt.retention.String())
+//line web/template/trash.qtpl:45
+		qw422016.N().S(`</p>
+  `)
+//line web/template/trash.qtpl:46
+	}
+//line web/template/trash.qtpl:46
+	qw422016.N().S(`
+
+  `)
+//line web/template/trash.qtpl:48
+	if len(pt.entries) == 0 {
+//line web/template/trash.qtpl:48
+		qw422016.N().S(`
+  <p>`)
+//line web/template/trash.qtpl:49
+		pt.streamt(qw422016, `Trash is empty.`)
+//line web/template/trash.qtpl:49
+		qw422016.N().S(`</p>
+  `)
+//line web/template/trash.qtpl:50
+	}
+//line web/template/trash.qtpl:50
+	qw422016.N().S(`
+
+  `)
+//line web/template/trash.qtpl:52
+	for i := range pt.entries {
+//line web/template/trash.qtpl:52
+		qw422016.N().S(`
+  `)
+//line web/template/trash.qtpl:53
+		pt.streamdeleted(qw422016, &pt.entries[i])
+//line web/template/trash.qtpl:53
+		qw422016.N().S(`
+  `)
+//line web/template/trash.qtpl:54
+	}
+//line web/template/trash.qtpl:54
+	qw422016.N().S(`
+
+  `)
+//line web/template/trash.qtpl:56
+	if pt.prev != nil || pt.next != nil {
+//line web/template/trash.qtpl:56
+		qw422016.N().S(`
+  <nav>
+    `)
+//line web/template/trash.qtpl:58
+		if pt.prev != nil {
+//line web/template/trash.qtpl:58
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/trash.qtpl:59
+			qw422016.E().S(pt.prev.String())
+//line web/template/trash.qtpl:59
+			qw422016.N().S(`"
+       rel="prev">`)
+//line web/template/trash.qtpl:60
+			pt.streamt(qw422016, `Newer`)
+//line web/template/trash.qtpl:60
+			qw422016.N().S(`</a>
+    `)
+//line web/template/trash.qtpl:61
+		}
+//line web/template/trash.qtpl:61
+		qw422016.N().S(`
+    `)
+//line web/template/trash.qtpl:62
+		if pt.next != nil {
+//line web/template/trash.qtpl:62
+			qw422016.N().S(`
+    <a href="`)
+//line web/template/trash.qtpl:63
+			qw422016.E().S(pt.next.String())
+//line web/template/trash.qtpl:63
+			qw422016.N().S(`"
+       rel="next">`)
+//line web/template/trash.qtpl:64
+			pt.streamt(qw422016, `Older`)
+//line web/template/trash.qtpl:64
+			qw422016.N().S(`</a>
+    `)
+//line web/template/trash.qtpl:65
+		}
+//line web/template/trash.qtpl:65
+		qw422016.N().S(`
+  </nav>
+  `)
+//line web/template/trash.qtpl:67
+	}
+//line web/template/trash.qtpl:67
+	qw422016.N().S(`
+</main>
+`)
+//line web/template/trash.qtpl:69
+}
+
+//line web/template/trash.qtpl:69
+func (pt *PageTrash) writebody(qq422016 qtio422016.Writer) {
+//line web/template/trash.qtpl:69
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/trash.qtpl:69
+	pt.streambody(qw422016)
+//line web/template/trash.qtpl:69
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/trash.qtpl:69
+}
+
+//line web/template/trash.qtpl:69
+func (pt *PageTrash) body() string {
+//line web/template/trash.qtpl:69
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/trash.qtpl:69
+	pt.writebody(qb422016)
+//line web/template/trash.qtpl:69
+	qs422016 := string(qb422016.B)
+//line web/template/trash.qtpl:69
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/trash.qtpl:69
+	return qs422016
+//line web/template/trash.qtpl:69
+}
+
+//line web/template/trash.qtpl:71
+func (pt *PageTrash) streamdeleted(qw422016 *qt422016.Writer, e *domain.Entry) {
+//line web/template/trash.qtpl:71
+	qw422016.N().S(`
+<article>
+  <h2>
+    `)
+//line web/template/trash.qtpl:74
+	if e.Title != "" {
+//line web/template/trash.qtpl:74
+		qw422016.N().S(`
+    `)
+//line web/template/trash.qtpl:75
+		qw422016.E().S(e.Title)
+//line web/template/trash.qtpl:75
+		qw422016.N().S(`
+    `)
+//line web/template/trash.qtpl:76
+	} else {
+//line web/template/trash.qtpl:76
+		qw422016.N().S(`
+    `)
+//line web/template/trash.qtpl:77
+		qw422016.E().S(e.URL.Path)
+//line web/template/trash.qtpl:77
+		qw422016.N().S(`
+    `)
+//line web/template/trash.qtpl:78
+	}
+//line web/template/trash.qtpl:78
+	qw422016.N().S(`
+  </h2>
+
+  `)
+//line web/template/trash.qtpl:81
+	if e.Description != "" {
+//line web/template/trash.qtpl:81
+		qw422016.N().S(`
+  <p>`)
+//line web/template/trash.qtpl:82
+		qw422016.E().S(e.Description)
+//line web/template/trash.qtpl:82
+		qw422016.N().S(`</p>
+  `)
+//line web/template/trash.qtpl:83
+	}
+//line web/template/trash.qtpl:83
+	qw422016.N().S(`
+
+  <p>
+    <time datetime="`)
+//line web/template/trash.qtpl:86
+	qw422016.E().S(e.DeletedAt.Format(time.RFC3339))
+//line web/template/trash.qtpl:86
+	qw422016.N().S(`">
+      `)
+//line web/template/trash.qtpl:87
+	pt.streamt(qw422016, `Deleted %s`, e.DeletedAt.Format(`2006-01-02 15:04`))
+//line web/template/trash.qtpl:87
+	qw422016.N().S(`
+    </time>
+  </p>
+
+  <form method="post"
+        action="/api"
+        accept-charset="utf-8"
+        enctype="application/x-www-form-urlencoded">
+    <input type="hidden"
+           name="url"
+           value="`)
+//line web/template/trash.qtpl:97
+	qw422016.E().S(pt.root.ResolveReference(e.URL).String())
+//line web/template/trash.qtpl:97
+	qw422016.N().S(`" />
+
+    <button type="submit"
+            name="action"
+            value="undelete">
+      `)
+//line web/template/trash.qtpl:102
+	pt.streamt(qw422016, `Restore`)
+//line web/template/trash.qtpl:102
+	qw422016.N().S(`
+    </button>
+
+    <button type="submit"
+            name="action"
+            value="purge">
+      `)
+//line web/template/trash.qtpl:108
+	pt.streamt(qw422016, `Delete permanently`)
+//line web/template/trash.qtpl:108
+	qw422016.N().S(`
+    </button>
+  </form>
+</article>
+`)
+//line web/template/trash.qtpl:112
+}
+
+//line web/template/trash.qtpl:112
+func (pt *PageTrash) writedeleted(qq422016 qtio422016.Writer, e *domain.Entry) {
+//line web/template/trash.qtpl:112
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line web/template/trash.qtpl:112
+	pt.streamdeleted(qw422016, e)
+//line web/template/trash.qtpl:112
+	qt422016.ReleaseWriter(qw422016)
+//line web/template/trash.qtpl:112
+}
+
+//line web/template/trash.qtpl:112
+func (pt *PageTrash) deleted(e *domain.Entry) string {
+//line web/template/trash.qtpl:112
+	qb422016 := qt422016.AcquireByteBuffer()
+//line web/template/trash.qtpl:112
+	pt.writedeleted(qb422016, e)
+//line web/template/trash.qtpl:112
+	qs422016 := string(qb422016.B)
+//line web/template/trash.qtpl:112
+	qt422016.ReleaseByteBuffer(qb422016)
+//line web/template/trash.qtpl:112
+	return qs422016
+//line web/template/trash.qtpl:112
+}